@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/mattn/go-oci8"
+	_ "github.com/mattn/go-oci8"
 )
 
 type ID string
@@ -33,17 +33,13 @@ func main() {
 		return
 	}
 
-	res, err := db.Exec("insert into lastinsertid_example(id, data) values(:1, :2)", "001", "こんにちわ世界")
+	// Oracle does not have auto increment ids, so get the rowid of the inserted row with returning into
+	var rowID string
+	_, err = db.Exec("insert into lastinsertid_example(id, data) values(:1, :2) returning rowid into :3", "001", "こんにちわ世界", sql.Out{Dest: &rowID})
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
-	lastInsertId, err := res.LastInsertId()
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-	rowID := oci8.GetLastInsertId(lastInsertId)
 	var id string
 	err = db.QueryRow("select id from lastinsertid_example where rowid = :1", rowID).Scan(&id)
 	if err != nil {
@@ -0,0 +1,40 @@
+package oci8
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// WaitAlert registers the connection for the DBMS_ALERT alert then waits until the alert is signaled, returning the alert message.
+// If the context is done while waiting, OCIBreak is called and the context error is returned.
+// The connection stays registered for the alert until RemoveAlert is called.
+// Needs execute on DBMS_ALERT.
+func (conn *OCI8Conn) WaitAlert(ctx context.Context, alertName string) (string, error) {
+	var message string
+	var status int64
+	err := conn.execArgs(ctx, "begin DBMS_ALERT.REGISTER(:1); DBMS_ALERT.WAITONE(:1, :2, :3); end;",
+		alertName, sql.Out{Dest: &message}, sql.Out{Dest: &status})
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", err
+	}
+	if status != 0 {
+		// status 1 is DBMS_ALERT.MAXWAIT timed out
+		return "", errors.New("wait alert timed out")
+	}
+	return message, nil
+}
+
+// SignalAlert signals the DBMS_ALERT alert with the message.
+// Alerts are sent on commit, so when the connection is in a transaction the alert is sent when the transaction is committed.
+func (conn *OCI8Conn) SignalAlert(alertName string, message string) error {
+	return conn.execArgs(context.Background(), "begin DBMS_ALERT.SIGNAL(:1, :2); end;", alertName, message)
+}
+
+// RemoveAlert removes the registration of the connection for the DBMS_ALERT alert
+func (conn *OCI8Conn) RemoveAlert(alertName string) error {
+	return conn.execArgs(context.Background(), "begin DBMS_ALERT.REMOVE(:1); end;", alertName)
+}
@@ -0,0 +1,54 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"context"
+	"fmt"
+)
+
+// DescribeAnyType describes an object type by name, for example the name returned by ANYDATA.GetTypeName,
+// so the contents of an ANYDATA value can be introspected without knowing the type at compile time.
+// It is DescribeType with the attribute names and type codes.
+func (conn *OCI8Conn) DescribeAnyType(ctx context.Context, typeName string) (*OCI8AnyType, error) {
+	typeDescriptor, err := conn.DescribeType(ctx, typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	anyType := &OCI8AnyType{typeName: typeName, typeCode: C.OCITypeCode(typeDescriptor.TypeCode)}
+	if len(typeDescriptor.Attributes) > 0 {
+		anyType.attrs = make([]oci8TypeAttr, len(typeDescriptor.Attributes))
+		for i, attribute := range typeDescriptor.Attributes {
+			anyType.attrs[i] = oci8TypeAttr{name: attribute.Name, typeCode: C.OCITypeCode(attribute.TypeCode)}
+		}
+	}
+
+	return anyType, nil
+}
+
+// TypeName returns the name of the described type
+func (anyType *OCI8AnyType) TypeName() string {
+	return anyType.typeName
+}
+
+// GetTypeCode returns the OCITypeCode of the described type, for example 108 for OCI_TYPECODE_OBJECT
+func (anyType *OCI8AnyType) GetTypeCode() (int, error) {
+	return int(anyType.typeCode), nil
+}
+
+// NumAttrs returns the number of attributes of the described type
+func (anyType *OCI8AnyType) NumAttrs() int {
+	return len(anyType.attrs)
+}
+
+// GetAttrInfo returns the name and OCITypeCode of the attribute at position pos.
+// Positions start from 1.
+func (anyType *OCI8AnyType) GetAttrInfo(pos int) (string, int, error) {
+	if pos < 1 || pos > len(anyType.attrs) {
+		return "", 0, fmt.Errorf("invalid attribute position %v for type %v with %v attributes", pos, anyType.typeName, len(anyType.attrs))
+	}
+	attr := anyType.attrs[pos-1]
+	return attr.name, int(attr.typeCode), nil
+}
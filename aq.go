@@ -0,0 +1,127 @@
+package oci8
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const (
+	// aqEnqueueQuery enqueues the payload with DBMS_AQ.ENQUEUE then returns the message id
+	aqEnqueueQuery = `declare
+	enqueue_options DBMS_AQ.ENQUEUE_OPTIONS_T;
+	message_properties DBMS_AQ.MESSAGE_PROPERTIES_T;
+	message_id RAW(16);
+begin
+	message_properties.correlation := :1;
+	message_properties.priority := :2;
+	message_properties.delay := :3;
+	DBMS_AQ.ENQUEUE(queue_name => :4, enqueue_options => enqueue_options, message_properties => message_properties, payload => :5, msgid => message_id);
+	:6 := message_id;
+end;`
+
+	// aqDequeueQuery dequeues a message with DBMS_AQ.DEQUEUE then returns 1 and the message, or 0 on ORA-25228 timeout
+	aqDequeueQuery = `declare
+	dequeue_options DBMS_AQ.DEQUEUE_OPTIONS_T;
+	message_properties DBMS_AQ.MESSAGE_PROPERTIES_T;
+	message_id RAW(16);
+	payload RAW(32767);
+	dequeue_timeout exception;
+	pragma exception_init(dequeue_timeout, -25228);
+begin
+	dequeue_options.consumer_name := :1;
+	dequeue_options.correlation := :2;
+	dequeue_options.wait := :3;
+	if :4 = 1 then
+		dequeue_options.dequeue_mode := DBMS_AQ.BROWSE;
+	end if;
+	DBMS_AQ.DEQUEUE(queue_name => :5, dequeue_options => dequeue_options, message_properties => message_properties, payload => payload, msgid => message_id);
+	:6 := 1;
+	:7 := message_id;
+	:8 := payload;
+	:9 := message_properties.correlation;
+	:10 := message_properties.priority;
+	:11 := message_properties.delay;
+exception
+	when dequeue_timeout then
+		:6 := 0;
+end;`
+)
+
+// EnqueueMessage enqueues the message with DBMS_AQ.ENQUEUE on the queue, which must have a RAW payload type, then sets the message ID.
+// The message is visible on commit, which is after the enqueue when not in a transaction.
+// Needs execute on DBMS_AQ.
+func (conn *OCI8Conn) EnqueueMessage(ctx context.Context, queueName string, msg *AQMessage) error {
+	var correlation interface{}
+	if msg.Correlation != "" {
+		correlation = msg.Correlation
+	}
+
+	var messageID []byte
+	err := conn.execArgs(ctx, aqEnqueueQuery,
+		correlation, int64(msg.Priority), aqSeconds(msg.Delay), queueName, msg.Payload, sql.Out{Dest: &messageID})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+
+	msg.ID = messageID
+	return nil
+}
+
+// DequeueMessage dequeues a message with DBMS_AQ.DEQUEUE from the queue, which must have a RAW payload type.
+// Returns ErrAQTimeout if there is no message before the wait ends.
+// If the context is done while waiting, OCIBreak is called and the context error is returned.
+// Needs execute on DBMS_AQ.
+func (conn *OCI8Conn) DequeueMessage(ctx context.Context, queueName string, opts DequeueOptions) (*AQMessage, error) {
+	var consumerName interface{}
+	if opts.ConsumerName != "" {
+		consumerName = opts.ConsumerName
+	}
+	var correlation interface{}
+	if opts.Correlation != "" {
+		correlation = opts.Correlation
+	}
+	wait := int64(-1) // DBMS_AQ.FOREVER
+	if opts.Wait >= 0 {
+		wait = aqSeconds(opts.Wait)
+	}
+	var browse int64
+	if opts.Browse {
+		browse = 1
+	}
+
+	var found int64
+	var msg AQMessage
+	var msgCorrelation sql.NullString
+	var priority int64
+	var delay int64
+	err := conn.execArgs(ctx, aqDequeueQuery,
+		consumerName, correlation, wait, browse, queueName,
+		sql.Out{Dest: &found}, sql.Out{Dest: &msg.ID}, sql.Out{Dest: &msg.Payload},
+		sql.Out{Dest: &msgCorrelation}, sql.Out{Dest: &priority}, sql.Out{Dest: &delay})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	if found == 0 {
+		return nil, ErrAQTimeout
+	}
+
+	msg.Correlation = msgCorrelation.String
+	msg.Priority = int(priority)
+	msg.Delay = time.Duration(delay) * time.Second
+	return &msg, nil
+}
+
+// aqSeconds returns the duration in whole seconds rounded up, for DBMS_AQ wait and delay
+func aqSeconds(duration time.Duration) int64 {
+	if duration <= 0 {
+		return 0
+	}
+	return int64((duration + time.Second - 1) / time.Second)
+}
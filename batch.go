@@ -0,0 +1,253 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// ExecBatch executes the statement once for each row with array binds, in one round trip, using OCI_BATCH_ERRORS mode.
+// Rows that fail do not stop the other rows, their errors are returned by BatchErrors.
+// Each row must have a value for each bind. Values in a column must have the same type,
+// which can be nil, int64, float64, bool, string, or []byte up to 32767 bytes, after driver.DefaultParameterConverter conversion.
+// If the context is done while executing, OCIBreak is called and the context error is returned.
+// With the autoreturn_rowid DSN parameter, an INSERT statement is prepared again without the added RETURNING clause.
+func (stmt *OCI8Stmt) ExecBatch(ctx context.Context, rows [][]interface{}) (driver.Result, error) {
+	stmt.batchErrors = nil
+	if len(rows) < 1 {
+		return nil, errors.New("batch has no rows")
+	}
+
+	err := stmt.prepareWithoutReturningRowid(ctx)
+	if err != nil {
+		return nil, err
+	}
+	binds, err := stmt.bindBatch(rows)
+	if err != nil {
+		return nil, err
+	}
+	defer freeBinds(binds)
+
+	mode := C.ub4(C.OCI_BATCH_ERRORS)
+	if stmt.conn.inTransaction == false {
+		mode = mode | C.OCI_COMMIT_ON_SUCCESS
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	done := make(chan struct{})
+	go stmt.conn.ociBreakDone(ctx, done)
+	err = stmt.ociStmtExecute(C.ub4(len(rows)), mode)
+	close(done)
+	// row errors are OCI_SUCCESS_WITH_INFO, ORA-24381: error(s) in array DML
+	if err != nil && err != ErrOCISuccessWithInfo {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+
+	stmt.batchErrors, err = stmt.getBatchErrors()
+	if err != nil {
+		return nil, err
+	}
+
+	result := OCI8Result{stmt: stmt, rowidErr: ErrNoRowid, callTimeErr: ErrNoCallTime, returnRowidErr: ErrNoRowid}
+	result.rowsAffected, result.rowsAffectedErr = stmt.rowsAffected()
+
+	return &result, nil
+}
+
+// BatchErrors returns the row errors of the last ExecBatch, which are empty if all rows succeeded
+func (stmt *OCI8Stmt) BatchErrors() ([]BatchError, error) {
+	if stmt.closed {
+		return nil, errors.New("statement is closed")
+	}
+	batchErrors := make([]BatchError, len(stmt.batchErrors))
+	copy(batchErrors, stmt.batchErrors)
+	return batchErrors, nil
+}
+
+// bindBatch binds a buffer, indicator array, and length array for each column of the rows
+func (stmt *OCI8Stmt) bindBatch(rows [][]interface{}) ([]oci8Bind, error) {
+	columnCount := len(rows[0])
+	values := make([][]driver.Value, len(rows))
+	for i, row := range rows {
+		if len(row) != columnCount {
+			return nil, fmt.Errorf("row %v has %v values, expected %v", i, len(row), columnCount)
+		}
+		values[i] = make([]driver.Value, columnCount)
+		for j, value := range row {
+			var err error
+			values[i][j], err = driver.DefaultParameterConverter.ConvertValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("row %v value %v error: %v", i, j, err)
+			}
+			values[i][j] = stmt.conn.normalizeValue(values[i][j])
+		}
+	}
+
+	var binds []oci8Bind
+	var bytesSent int64
+	for j := 0; j < columnCount; j++ {
+		data := make([][]byte, len(rows))
+		var dataType C.ub2
+		size := 1
+		for i := range rows {
+			if values[i][j] == nil {
+				continue
+			}
+			valueData, valueType, err := batchData(values[i][j])
+			if err != nil {
+				freeBinds(binds)
+				return nil, fmt.Errorf("row %v value %v error: %v", i, j, err)
+			}
+			if dataType == 0 {
+				dataType = valueType
+			} else if valueType != dataType {
+				freeBinds(binds)
+				return nil, fmt.Errorf("row %v value %v type %T is not the type of the column", i, j, values[i][j])
+			}
+			data[i] = valueData
+			if len(valueData) > size {
+				size = len(valueData)
+			}
+		}
+		if dataType == 0 {
+			dataType = C.SQLT_CHR
+		}
+
+		var sbind oci8Bind
+		sbind.dataType = dataType
+		sbind.maxSize = C.sb4(size)
+		sbind.pbuf = C.malloc(C.size_t(size * len(rows)))
+		sbind.length = (*C.ub2)(C.malloc(C.size_t(C.sizeof_ub2 * len(rows))))
+		sbind.indicator = (*C.sb2)(C.malloc(C.size_t(C.sizeof_sb2 * len(rows))))
+		binds = append(binds, sbind)
+
+		buffer := (*[1 << 30]byte)(sbind.pbuf)[: size*len(rows) : size*len(rows)]
+		lengths := (*[1 << 28]C.ub2)(unsafe.Pointer(sbind.length))[:len(rows):len(rows)]
+		indicators := (*[1 << 28]C.sb2)(unsafe.Pointer(sbind.indicator))[:len(rows):len(rows)]
+		for i := range rows {
+			if values[i][j] == nil {
+				lengths[i] = 0
+				indicators[i] = -1 // set to null
+				continue
+			}
+			copy(buffer[i*size:], data[i])
+			lengths[i] = C.ub2(len(data[i]))
+			indicators[i] = 0
+			bytesSent += int64(len(data[i]))
+		}
+
+		err := stmt.ociBindByPos(C.ub4(j+1), &binds[len(binds)-1])
+		if err != nil {
+			freeBinds(binds)
+			return nil, err
+		}
+	}
+
+	stmt.conn.addStats(ConnStats{BytesSent: bytesSent})
+
+	return binds, nil
+}
+
+// batchData returns the bind data and data type of a converted driver value of a batch
+func batchData(value driver.Value) ([]byte, C.ub2, error) {
+	switch value := value.(type) {
+	case int64:
+		buffer := bytes.Buffer{}
+		binary.Write(&buffer, binary.LittleEndian, value)
+		return buffer.Bytes(), C.SQLT_INT, nil
+	case float64:
+		buffer := bytes.Buffer{}
+		binary.Write(&buffer, binary.LittleEndian, value)
+		return buffer.Bytes(), C.SQLT_BDOUBLE, nil
+	case bool: // oracle does not have bool, handle as 0/1 int
+		buffer := bytes.Buffer{}
+		var number int64
+		if value {
+			number = 1
+		}
+		binary.Write(&buffer, binary.LittleEndian, number)
+		return buffer.Bytes(), C.SQLT_INT, nil
+	case string:
+		if len(value) > 32767 {
+			return nil, 0, fmt.Errorf("string of length %v is larger than 32767", len(value))
+		}
+		return []byte(value), C.SQLT_CHR, nil
+	case []byte:
+		if len(value) > 32767 {
+			return nil, 0, fmt.Errorf("[]byte of length %v is larger than 32767", len(value))
+		}
+		return value, C.SQLT_BIN, nil
+	}
+	return nil, 0, fmt.Errorf("invalid batch type: %T", value)
+}
+
+// getBatchErrors reads the row errors of an OCI_BATCH_ERRORS execute, using OCIParamGet on the error handle for each error
+func (stmt *OCI8Stmt) getBatchErrors() ([]BatchError, error) {
+	var errorCount C.ub4
+	_, err := stmt.ociAttrGet(unsafe.Pointer(&errorCount), C.OCI_ATTR_NUM_DML_ERRORS)
+	if err != nil {
+		return nil, err
+	}
+	if errorCount < 1 {
+		return nil, nil
+	}
+
+	conn := stmt.conn
+	handle, _, err := conn.ociHandleAlloc(C.OCI_HTYPE_ERROR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("allocate error handle error: %v", err)
+	}
+	rowErrHandle := (*C.OCIError)(*handle)
+	defer C.OCIHandleFree(unsafe.Pointer(rowErrHandle), C.OCI_HTYPE_ERROR)
+
+	batchErrors := make([]BatchError, 0, errorCount)
+	for i := C.ub4(0); i < errorCount; i++ {
+		rowErrPointer := unsafe.Pointer(rowErrHandle)
+		result := C.OCIParamGet(
+			unsafe.Pointer(conn.errHandle), // The error handle of the execute
+			C.OCI_HTYPE_ERROR,              // Handle type: OCI_HTYPE_ERROR, for an error handle
+			conn.errHandle,                 // An error handle
+			&rowErrPointer,                 // The error handle that gets the row error
+			i,                              // The row error index, starts from 0
+		)
+		if result != C.OCI_SUCCESS {
+			return nil, conn.getError(result)
+		}
+
+		var rowOffset C.ub4
+		result = C.OCIAttrGet(
+			rowErrPointer,              // Pointer to a handle type
+			C.OCI_HTYPE_ERROR,          // The handle type: OCI_HTYPE_ERROR, for an error handle
+			unsafe.Pointer(&rowOffset), // Pointer to the storage for an attribute value
+			nil,                        // The size of the attribute value
+			C.OCI_ATTR_DML_ROW_OFFSET,  // The attribute type: OCI_ATTR_DML_ROW_OFFSET, the row offset of the error
+			conn.errHandle,             // An error handle
+		)
+		if result != C.OCI_SUCCESS {
+			return nil, conn.getError(result)
+		}
+
+		errorCode, rowErr := ociErrorGet((*C.OCIError)(rowErrPointer))
+		batchErrors = append(batchErrors, BatchError{
+			RowIndex:     int(rowOffset),
+			ErrorCode:    errorCode,
+			ErrorMessage: strings.TrimSpace(rowErr.Error()),
+		})
+	}
+
+	return batchErrors, nil
+}
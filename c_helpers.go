@@ -114,6 +114,8 @@ func freeBuffer(buffer unsafe.Pointer, dataType C.ub2) {
 	switch dataType {
 	case C.SQLT_CLOB, C.SQLT_BLOB:
 		C.OCIDescriptorFree(*(*unsafe.Pointer)(buffer), C.OCI_DTYPE_LOB)
+	case C.SQLT_FILE:
+		C.OCIDescriptorFree(*(*unsafe.Pointer)(buffer), C.OCI_DTYPE_FILE)
 	case C.SQLT_TIMESTAMP:
 		C.OCIDescriptorFree(*(*unsafe.Pointer)(buffer), C.OCI_DTYPE_TIMESTAMP)
 	case C.SQLT_TIMESTAMP_TZ:
@@ -0,0 +1,89 @@
+package oci8
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// compareObjectsQuery is the PL/SQL block that creates the comparison then compares the objects with row differences.
+// If the compare fails the comparison it created is dropped, if the create fails nothing is dropped,
+// so an existing comparison with the same name is kept.
+// Binds are comparison name, schema, object, dblink, remote schema, remote object, and the returned scan id.
+const compareObjectsQuery = `declare
+	scan_info DBMS_COMPARISON.COMPARISON_TYPE;
+	consistent boolean;
+begin
+	DBMS_COMPARISON.CREATE_COMPARISON(comparison_name => :1, schema_name => :2, object_name => :3,
+		dblink_name => :4, remote_schema_name => :5, remote_object_name => :6);
+	begin
+		consistent := DBMS_COMPARISON.COMPARE(comparison_name => :1, scan_info => scan_info, perform_row_dif => true);
+	exception when others then
+		DBMS_COMPARISON.DROP_COMPARISON(:1);
+		raise;
+	end;
+	:7 := scan_info.scan_id;
+end;`
+
+// compareObjectsCountQuery counts the row differences of all the scans of the root scan.
+// Rows missing in the source have no local rowid and rows missing in the dest have no remote rowid.
+const compareObjectsCountQuery = `select
+	count(case when r.LOCAL_ROWID is not null and r.REMOTE_ROWID is not null then 1 end),
+	count(case when r.LOCAL_ROWID is null then 1 end),
+	count(case when r.REMOTE_ROWID is null then 1 end)
+from USER_COMPARISON_ROW_DIF r, USER_COMPARISON_SCAN s
+where r.COMPARISON_NAME = :1 and s.COMPARISON_NAME = r.COMPARISON_NAME and s.SCAN_ID = r.SCAN_ID and s.ROOT_SCAN_ID = :2 and r.STATUS = 'DIF'`
+
+// CompareObjects compares the source object with the dest object using DBMS_COMPARISON, then returns the counts of the row differences.
+// DBMS_COMPARISON.CREATE_COMPARISON creates the comparison, DBMS_COMPARISON.COMPARE compares the rows,
+// the differences are counted from USER_COMPARISON_ROW_DIF, then the comparison is dropped.
+// Returns an error without dropping it if a comparison with the same name already exists.
+// The objects need a primary key or unique index. Needs execute on DBMS_COMPARISON and the EXECUTE_CATALOG_ROLE role.
+func (conn *OCI8Conn) CompareObjects(ctx context.Context, scan ComparisonScan) (*ComparisonResult, error) {
+	if scan.ComparisonName == "" {
+		return nil, errors.New("comparison name is empty")
+	}
+	if scan.ObjectName == "" {
+		return nil, errors.New("object name is empty")
+	}
+
+	var schemaName, dbLink, remoteSchemaName, remoteObjectName interface{}
+	if scan.SchemaName != "" {
+		schemaName = scan.SchemaName
+	}
+	if scan.DBLink != "" {
+		dbLink = scan.DBLink
+	}
+	if scan.RemoteSchemaName != "" {
+		remoteSchemaName = scan.RemoteSchemaName
+	}
+	if scan.RemoteObjectName != "" {
+		remoteObjectName = scan.RemoteObjectName
+	}
+
+	var scanID int64
+	err := conn.execArgs(ctx, compareObjectsQuery, scan.ComparisonName, schemaName, scan.ObjectName, dbLink, remoteSchemaName, remoteObjectName, sql.Out{Dest: &scanID})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("compare error: %v", err)
+	}
+	defer conn.execArgs(context.Background(), "begin DBMS_COMPARISON.DROP_COMPARISON(:1); end;", scan.ComparisonName)
+
+	values, err := conn.queryRowArgs(ctx, compareObjectsCountQuery, scan.ComparisonName, scanID)
+	if err != nil {
+		return nil, fmt.Errorf("count row differences error: %v", err)
+	}
+
+	differentCount, _ := values[0].(float64)
+	missingInSource, _ := values[1].(float64)
+	missingInDest, _ := values[2].(float64)
+	return &ComparisonResult{
+		ScanID:          scanID,
+		DifferentCount:  int(differentCount),
+		MissingInSource: int(missingInSource),
+		MissingInDest:   int(missingInDest),
+	}, nil
+}
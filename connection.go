@@ -6,15 +6,31 @@ import "C"
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 	"unsafe"
 )
 
-// Ping database connection
+// Ping database connection. It is PingContext, so database/sql PingContext deadlines are respected.
 func (conn *OCI8Conn) Ping(ctx context.Context) error {
+	return conn.PingContext(ctx)
+}
+
+// PingContext pings the database with OCIPing, a round trip to the server.
+// If the context is already done the context error is returned. If the context is done while pinging, OCIBreak is called
+// and driver.ErrBadConn is returned, so a ping with a deadline, like a health check, fails fast when the server does not respond,
+// and database/sql discards the interrupted session. Other ping failures also return driver.ErrBadConn.
+func (conn *OCI8Conn) PingContext(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
 	done := make(chan struct{})
 	go conn.ociBreakDone(ctx, done)
 	result := C.OCIPing(conn.svc, conn.errHandle, C.OCI_DEFAULT)
@@ -29,6 +45,11 @@ func (conn *OCI8Conn) Ping(ctx context.Context) error {
 		// See https://github.com/rana/ora/issues/224
 		return nil
 	}
+	if ctx.Err() != nil {
+		// the session is not usable after the break
+		conn.logger.Print("Ping error: ", ctx.Err())
+		return driver.ErrBadConn
+	}
 
 	conn.logger.Print("Ping error: ", err)
 	return driver.ErrBadConn
@@ -39,9 +60,35 @@ func (conn *OCI8Conn) Close() error {
 	if conn.closed {
 		return nil
 	}
+	conn.logDBMSOutput()
 	conn.closed = true
 
+	for _, stmt := range conn.cachedStmts {
+		stmt.Close()
+	}
+	conn.cachedStmts = nil
+	conn.freeXMLContext()
+
 	var err error
+	if conn.sessionGet {
+		// the service context is freed by OCISessionRelease
+		if rv := C.OCISessionRelease(
+			conn.svc,
+			conn.errHandle,
+			nil,
+			0,
+			C.OCI_DEFAULT,
+		); rv != C.OCI_SUCCESS {
+			err = conn.getError(rv)
+		}
+		C.OCIHandleFree(unsafe.Pointer(conn.errHandle), C.OCI_HTYPE_ERROR)
+		C.OCIHandleFree(unsafe.Pointer(conn.env), C.OCI_HTYPE_ENV)
+		conn.svc = nil
+		conn.errHandle = nil
+		conn.env = nil
+		return err
+	}
+
 	if useOCISessionBegin {
 		if rv := C.OCISessionEnd(
 			conn.svc,
@@ -86,19 +133,80 @@ func (conn *OCI8Conn) Prepare(query string) (driver.Stmt, error) {
 	return conn.PrepareContext(context.Background(), query)
 }
 
-// PrepareContext prepares a query with context
+// PrepareContext prepares a query with context.
+// If the context is done while preparing, OCIBreak is called and the context error is returned.
 func (conn *OCI8Conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
 	if conn.enableQMPlaceholders {
-		query = placeholders(query)
+		var mixed bool
+		query, mixed = placeholders(query)
+		if mixed && !conn.warnedMixedPlaceholders {
+			conn.warnedMixedPlaceholders = true
+			conn.logger.Print("question mark placeholders are not converted in SQL that also has Oracle binds: ", query)
+		}
+	}
+
+	var returnRowid bool
+	if conn.autoReturnRowid {
+		query, returnRowid = returningRowidQuery(query)
+	}
+
+	if hint, ok := ctx.Value(hintKey{}).(string); ok {
+		var err error
+		query, err = hintQuery(query, hint)
+		if err != nil {
+			return nil, err
+		}
 	}
 
+	// PL/SQL blocks longer than 32767 bytes get ORA-01460, so they are run with EXECUTE IMMEDIATE of the block as a CLOB
+	var largePLSQL string
+	if isLargePLSQL(query) {
+		largePLSQL = query
+		query = "begin execute immediate :" + largePLSQLBind + "; end;"
+	}
+
+	stmt, err := conn.prepare(ctx, query, returnRowid)
+	if err != nil {
+		return nil, err
+	}
+	stmt.largePLSQL = largePLSQL
+
+	if (conn.xmlAsCLOB && !xmlNative) || conn.objectAsJSON {
+		var selectQuery string
+		selectQuery, err = stmt.selectListQuery(ctx, query)
+		if err != nil {
+			stmt.Close()
+			return nil, err
+		}
+		if selectQuery != query {
+			stmt.Close()
+			stmt, err = conn.prepare(ctx, selectQuery, returnRowid)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return stmt, nil
+}
+
+// prepare prepares the query with OCIStmtPrepare2.
+// If the context is done while preparing, OCIBreak is called and the context error is returned.
+func (conn *OCI8Conn) prepare(ctx context.Context, query string, returnRowid bool) (*OCI8Stmt, error) {
 	queryP := cString(query)
 	defer C.free(unsafe.Pointer(queryP))
 
+	done := make(chan struct{})
+	go conn.ociBreakDone(ctx, done)
+
 	// statement handle
 	var stmtTemp *C.OCIStmt
 	stmt := &stmtTemp
-	if rv := C.OCIStmtPrepare2(
+	rv := C.OCIStmtPrepare2(
 		conn.svc,                // service context handle
 		stmt,                    // pointer to the statement handle returned
 		conn.errHandle,          // error handle
@@ -108,11 +216,25 @@ func (conn *OCI8Conn) PrepareContext(ctx context.Context, query string) (driver.
 		C.ub4(0),                // length of the key
 		C.ub4(C.OCI_NTV_SYNTAX), // syntax - OCI_NTV_SYNTAX: syntax depends upon the version of the server
 		C.ub4(C.OCI_DEFAULT),    // mode
-	); rv != C.OCI_SUCCESS {
+	)
+	close(done)
+	if rv != C.OCI_SUCCESS {
+		conn.addStats(ConnStats{ErrorCount: 1})
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		return nil, conn.getError(rv)
 	}
 
-	return &OCI8Stmt{conn: conn, stmt: *stmt}, nil
+	if ctx.Err() != nil {
+		// context done while preparing, release the statement handle
+		C.OCIStmtRelease(*stmt, conn.errHandle, nil, 0, C.OCI_DEFAULT)
+		return nil, ctx.Err()
+	}
+
+	conn.addStats(ConnStats{PrepareCount: 1})
+
+	return &OCI8Stmt{conn: conn, stmt: *stmt, returnRowid: returnRowid}, nil
 }
 
 // Begin starts a transaction
@@ -122,6 +244,8 @@ func (conn *OCI8Conn) Begin() (driver.Tx, error) {
 
 // BeginTx starts a transaction
 func (conn *OCI8Conn) BeginTx(ctx context.Context, txOptions driver.TxOptions) (driver.Tx, error) {
+	tx := &OCI8Tx{conn: conn}
+
 	if conn.transactionMode != C.OCI_TRANS_READWRITE {
 		// transaction handle
 		trans, _, err := conn.ociHandleAlloc(C.OCI_HTYPE_TRANS, 0)
@@ -135,10 +259,9 @@ func (conn *OCI8Conn) BeginTx(ctx context.Context, txOptions driver.TxOptions) (
 			C.OCIHandleFree(*trans, C.OCI_HTYPE_TRANS)
 			return nil, err
 		}
+		tx.trans = *trans
 
-		// transaction handle should be freed by something once attached to the service context
-		// but I cannot find anything in the documentation explicitly calling this out
-		// going by examples: https://docs.oracle.com/cd/B28359_01/appdev.111/b28395/oci17msc006.htm#i428845
+		// the transaction handle is detached from the service context and freed by Commit or Rollback
 
 		if rv := C.OCITransStart(
 			conn.svc,
@@ -152,8 +275,39 @@ func (conn *OCI8Conn) BeginTx(ctx context.Context, txOptions driver.TxOptions) (
 	}
 
 	conn.inTransaction = true
+	conn.transactionID = ""
+
+	tx.id = strconv.FormatUint(atomic.AddUint64(&transactionIDCounter, 1), 10)
+	tx.sendEvent(TxBegin)
 
-	return &OCI8Tx{conn}, nil
+	return tx, nil
+}
+
+// WatchTransaction sets the listener that is called synchronously when a transaction begins, commits, or rolls back.
+// Events are sent after the operation succeeds. Setting a nil listener stops the events.
+func (conn *OCI8Conn) WatchTransaction(listener func(event TxEvent)) {
+	conn.txListener = listener
+}
+
+// GetTransactionID returns the Oracle transaction id of the current transaction, like 10.5.1234, from DBMS_TRANSACTION.LOCAL_TRANSACTION_ID,
+// which can be used to correlate tracing spans with V$TRANSACTION. Oracle starts the transaction when it has no DML yet.
+// The id is read once per transaction. Returns an error when the connection is not in a transaction started with Begin.
+func (conn *OCI8Conn) GetTransactionID() (string, error) {
+	if !conn.inTransaction {
+		return "", errors.New("not in a transaction")
+	}
+	if conn.transactionID != "" {
+		return conn.transactionID, nil
+	}
+
+	var transactionID string
+	err := conn.execArgs(context.Background(), "begin :1 := DBMS_TRANSACTION.LOCAL_TRANSACTION_ID(TRUE); end;", sql.Out{Dest: &transactionID})
+	if err != nil {
+		return "", fmt.Errorf("get transaction id error: %v", err)
+	}
+
+	conn.transactionID = transactionID
+	return transactionID, nil
 }
 
 // getError gets error from return result (sword) or OCIError
@@ -175,41 +329,100 @@ func (conn *OCI8Conn) getError(result C.sword) error {
 		return ErrOCIStillExecuting
 	case C.OCI_ERROR:
 		errorCode, err := conn.ociGetError()
-		switch errorCode {
-		/*
-			bad connection errors:
-			ORA-00028: your session has been killed
-			ORA-01012: Not logged on
-			ORA-01033: ORACLE initialization or shutdown in progress
-			ORA-01034: ORACLE not available
-			ORA-01089: immediate shutdown in progress - no operations are permitted
-			ORA-03113: end-of-file on communication channel
-			ORA-03114: Not Connected to Oracle
-			ORA-03135: connection lost contact
-			ORA-12528: TNS:listener: all appropriate instances are blocking new connections
-			ORA-12537: TNS:connection closed
-		*/
-		case 28, 1012, 1033, 1034, 1089, 3113, 3114, 3135, 12528, 12537:
-			return driver.ErrBadConn
-		}
-		return err
+		return convertOCIError(errorCode, err)
 	}
 	return fmt.Errorf("received result code %d", result)
 }
 
+// convertOCIError converts an OCI error code and error text error to the error returned by the driver
+func convertOCIError(errorCode int, err error) error {
+	if isBadConnError(errorCode) {
+		return driver.ErrBadConn
+	}
+	if errorCode == 1555 {
+		// ORA-01555: snapshot too old
+		return &snapshotTooOldError{err: err}
+	}
+	return err
+}
+
+// isBadConnError returns true if the ORA error code means the session is not usable, converted to driver.ErrBadConn
+func isBadConnError(errorCode int) bool {
+	switch errorCode {
+	/*
+		bad connection errors:
+		ORA-00028: your session has been killed
+		ORA-01012: Not logged on
+		ORA-01033: ORACLE initialization or shutdown in progress
+		ORA-01034: ORACLE not available
+		ORA-01089: immediate shutdown in progress - no operations are permitted
+		ORA-03113: end-of-file on communication channel
+		ORA-03114: Not Connected to Oracle
+		ORA-03135: connection lost contact
+		ORA-12528: TNS:listener: all appropriate instances are blocking new connections
+		ORA-12537: TNS:connection closed
+	*/
+	case 28, 1012, 1033, 1034, 1089, 3113, 3114, 3135, 12528, 12537:
+		return true
+	}
+	return false
+}
+
+// isNetError returns true if the ORA error code is an Oracle Net error, ORA-12150 to ORA-12699,
+// which OCIServerAttach returns when the listener or service cannot be reached
+func isNetError(errorCode int) bool {
+	return errorCode >= 12150 && errorCode <= 12699
+}
+
+// oraErrorCode returns the error code of an error with ORA-NNNNN: text, or 0 if the error text does not start with it
+func oraErrorCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	text := err.Error()
+	if len(text) < 10 || !strings.HasPrefix(text, "ORA-") || text[9] != ':' {
+		return 0
+	}
+	code, err := strconv.Atoi(text[4:9])
+	if err != nil {
+		return 0
+	}
+	return code
+}
+
+// snapshotTooOldError is ORA-01555, the error text is kept and it unwraps to ErrSnapshotTooOld
+type snapshotTooOldError struct {
+	err error
+}
+
+// Error returns the ORA-01555 error text
+func (e *snapshotTooOldError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns ErrSnapshotTooOld
+func (e *snapshotTooOldError) Unwrap() error {
+	return ErrSnapshotTooOld
+}
+
 // ociGetError calls OCIErrorGet then returs error code and text
 func (conn *OCI8Conn) ociGetError() (int, error) {
+	return ociErrorGet(conn.errHandle)
+}
+
+// ociErrorGet calls OCIErrorGet on the error handle then returs error code and text
+func ociErrorGet(errHandle *C.OCIError) (int, error) {
 	var errorCode C.sb4
 	errorText := make([]byte, 1024)
 
 	result := C.OCIErrorGet(
-		unsafe.Pointer(conn.errHandle), // error handle
-		1,                              // status record number, starts from 1
-		nil,                            // sqlstate, not supported in release 8.x or later
-		&errorCode,                     // error code
-		(*C.OraText)(&errorText[0]),    // error message text
-		1024,                           // size of the buffer provided in number of bytes
-		C.OCI_HTYPE_ERROR,              // type of the handle (OCI_HTYPE_ERR or OCI_HTYPE_ENV)
+		unsafe.Pointer(errHandle),   // error handle
+		1,                           // status record number, starts from 1
+		nil,                         // sqlstate, not supported in release 8.x or later
+		&errorCode,                  // error code
+		(*C.OraText)(&errorText[0]), // error message text
+		1024,                        // size of the buffer provided in number of bytes
+		C.OCI_HTYPE_ERROR,           // type of the handle (OCI_HTYPE_ERR or OCI_HTYPE_ENV)
 	)
 	if result != C.OCI_SUCCESS {
 		return 3114, errors.New("OCIErrorGet failed")
@@ -258,6 +471,73 @@ func (conn *OCI8Conn) ociAttrSet(
 	return conn.getError(result)
 }
 
+// ociParamGet calls OCIParamGet on a describe parameter list then returns OCIParam and error.
+// The returned OCIParam is freed with the describe handle.
+func (conn *OCI8Conn) ociParamGet(paramList *C.OCIParam, position C.ub4) (*C.OCIParam, error) {
+	var paramTemp *C.OCIParam
+	param := &paramTemp
+
+	result := C.OCIParamGet(
+		unsafe.Pointer(paramList),                // A describe parameter list
+		C.OCI_DTYPE_PARAM,                        // Handle type: OCI_DTYPE_PARAM, for a parameter descriptor
+		conn.errHandle,                           // An error handle
+		(*unsafe.Pointer)(unsafe.Pointer(param)), // A descriptor of the parameter at the position
+		position,                                 // Position number in the parameter list, starts from 1
+	)
+
+	err := conn.getError(result)
+	if err != nil {
+		return nil, err
+	}
+
+	return *param, nil
+}
+
+// ociDescribeAny calls OCIDescribeAny for the named schema object
+// then returns describe handle, top level parameter, and error.
+// OCIHandleFree must be called on returned describe handle, which also frees the parameter.
+func (conn *OCI8Conn) ociDescribeAny(name string, objectType C.ub1) (*C.OCIDescribe, *C.OCIParam, error) {
+	describeP, _, err := conn.ociHandleAlloc(C.OCI_HTYPE_DESCRIBE, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("allocate describe handle error: %v", err)
+	}
+	describe := (*C.OCIDescribe)(*describeP)
+
+	nameP := cString(name)
+	defer C.free(unsafe.Pointer(nameP))
+
+	result := C.OCIDescribeAny(
+		conn.svc,              // service context handle
+		conn.errHandle,        // error handle
+		unsafe.Pointer(nameP), // the name of the object to be described, can be in the form schema.name
+		C.ub4(len(name)),      // length of the name
+		C.OCI_OTYPE_NAME,      // the object is specified by name
+		C.OCI_DEFAULT,         // info level, reserved
+		objectType,            // type of object to be described: OCI_PTYPE_TABLE, OCI_PTYPE_TYPE, etc.
+		describe,              // describe handle that is populated with describe information
+	)
+	if result != C.OCI_SUCCESS {
+		C.OCIHandleFree(unsafe.Pointer(describe), C.OCI_HTYPE_DESCRIBE)
+		return nil, nil, conn.getError(result)
+	}
+
+	var param *C.OCIParam
+	result = C.OCIAttrGet(
+		unsafe.Pointer(describe), // Pointer to a handle type
+		C.OCI_HTYPE_DESCRIBE,     // The handle type: OCI_HTYPE_DESCRIBE, for a describe handle
+		unsafe.Pointer(&param),   // Pointer to the storage for an attribute value
+		nil,                      // The size of the attribute value
+		C.OCI_ATTR_PARAM,         // The attribute type: OCI_ATTR_PARAM, the top level parameter
+		conn.errHandle,           // An error handle
+	)
+	if result != C.OCI_SUCCESS {
+		C.OCIHandleFree(unsafe.Pointer(describe), C.OCI_HTYPE_DESCRIBE)
+		return nil, nil, conn.getError(result)
+	}
+
+	return describe, param, nil
+}
+
 // ociHandleAlloc calls OCIHandleAlloc then returns
 // handle pointer to pointer, buffer pointer to pointer, and error
 func (conn *OCI8Conn) ociHandleAlloc(handleType C.ub4, size C.size_t) (*unsafe.Pointer, *unsafe.Pointer, error) {
@@ -442,6 +722,64 @@ func (conn *OCI8Conn) ociLobWrite(lobLocator *C.OCILobLocator, form C.ub1, data
 	return nil
 }
 
+// ociLobFileGetName calls OCILobFileGetName then returns the directory and file name of the BFILE locator
+func (conn *OCI8Conn) ociLobFileGetName(fileLocator *C.OCILobLocator) (BFileRef, error) {
+	// directory alias is up to 128 bytes, file name is up to 255 bytes
+	directory := cStringN("", 128)
+	defer C.free(unsafe.Pointer(directory))
+	directoryLength := C.ub2(128)
+	fileName := cStringN("", 255)
+	defer C.free(unsafe.Pointer(fileName))
+	fileNameLength := C.ub2(255)
+
+	result := C.OCILobFileGetName(
+		conn.env,         // environment handle
+		conn.errHandle,   // error handle
+		fileLocator,      // BFILE locator
+		directory,        // buffer for the directory alias name
+		&directoryLength, // size of the directory buffer, returns the length of the directory alias name
+		fileName,         // buffer for the file name
+		&fileNameLength,  // size of the file name buffer, returns the length of the file name
+	)
+	err := conn.getError(result)
+	if err != nil {
+		return BFileRef{}, err
+	}
+
+	return BFileRef{Directory: cGoStringN(directory, int(directoryLength)), FileName: cGoStringN(fileName, int(fileNameLength))}, nil
+}
+
+// ociLobFileSetName allocates a BFILE locator then calls OCILobFileSetName with the directory and file name.
+// OCIDescriptorFree must be called on returned locator.
+func (conn *OCI8Conn) ociLobFileSetName(bfile BFileRef) (*unsafe.Pointer, error) {
+	fileP, _, err := conn.ociDescriptorAlloc(C.OCI_DTYPE_FILE, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	directory := cString(bfile.Directory)
+	defer C.free(unsafe.Pointer(directory))
+	fileName := cString(bfile.FileName)
+	defer C.free(unsafe.Pointer(fileName))
+
+	result := C.OCILobFileSetName(
+		conn.env,       // environment handle
+		conn.errHandle, // error handle
+		(**C.OCILobLocator)(unsafe.Pointer(fileP)), // pointer to the BFILE locator
+		directory,                   // directory alias name
+		C.ub2(len(bfile.Directory)), // length of the directory alias name
+		fileName,                    // file name
+		C.ub2(len(bfile.FileName)),  // length of the file name
+	)
+	err = conn.getError(result)
+	if err != nil {
+		C.OCIDescriptorFree(*fileP, C.OCI_DTYPE_FILE)
+		return nil, err
+	}
+
+	return fileP, nil
+}
+
 // ociDateTimeToTime coverts OCIDateTime to Go Time
 func (conn *OCI8Conn) ociDateTimeToTime(dateTime *C.OCIDateTime, ociDateTimeHasTimeZone bool) (*time.Time, error) {
 	// get date
@@ -503,6 +841,11 @@ func (conn *OCI8Conn) ociDateTimeToTime(dateTime *C.OCIDateTime, ociDateTimeHasT
 	// return Go Time using OCI time zone offset
 	aTime := time.Date(int(year), time.Month(month), int(day), int(hour), int(min), int(sec), int(fsec),
 		timezoneToLocation(int64(timeZoneHour), int64(timeZoneMin)))
+	// the cached locations are named time zones, which can have had another offset at the time, like for daylight saving time
+	if _, offset := aTime.Zone(); offset != 3600*int(timeZoneHour)+60*int(timeZoneMin) {
+		aTime = time.Date(int(year), time.Month(month), int(day), int(hour), int(min), int(sec), int(fsec),
+			timezoneFixedZone(int64(timeZoneHour), int64(timeZoneMin)))
+	}
 	return &aTime, nil
 }
 
@@ -582,12 +925,346 @@ func (conn *OCI8Conn) ociBreakDone(ctx context.Context, done chan struct{}) {
 
 // ociBreak calls OCIBreak
 func (conn *OCI8Conn) ociBreak() {
+	err := conn.CancelExec()
+	if err != nil {
+		conn.logger.Print("OCIBreak error: ", err)
+	}
+}
+
+// CancelExec interrupts the statement running on the connection by calling OCIBreak.
+// It is safe to call from another goroutine while a Query or Exec is running on the connection,
+// which then returns ORA-01013: user requested cancel of current operation.
+// For most uses context cancellation should be used instead.
+func (conn *OCI8Conn) CancelExec() error {
 	result := C.OCIBreak(
 		unsafe.Pointer(conn.svc), // service or server context handle
 		conn.errHandle,           // error handle
 	)
-	err := conn.getError(result)
+	return conn.getError(result)
+}
+
+// SetLockTimeout sets the session DDL_LOCK_TIMEOUT, the time DDL statements wait for DML locks before failing.
+// The lock timeout is whole seconds, rounded up, from 0 to 1000000 seconds. The lock_timeout DSN parameter sets it for new connections.
+func (conn *OCI8Conn) SetLockTimeout(ctx context.Context, lockTimeout time.Duration) error {
+	if lockTimeout < 0 || lockTimeout > maxLockTimeout {
+		return fmt.Errorf("invalid lock timeout: %v", lockTimeout)
+	}
+	_, err := conn.execScriptStatement(ctx, lockTimeoutQuery(lockTimeout))
+	return err
+}
+
+// EnableCallTimeStats sets OCI_ATTR_COLLECT_CALL_TIME on the session so the server measures the time of each call.
+// The server call time of an exec is then returned by OCI8Result CallTime.
+func (conn *OCI8Conn) EnableCallTimeStats() error {
+	session, err := conn.ociSession()
 	if err != nil {
-		conn.logger.Print("OCIBreak error: ", err)
+		return err
+	}
+
+	collectCallTime := C.boolean(1)
+	err = conn.ociAttrSet(session, C.OCI_HTYPE_SESSION, unsafe.Pointer(&collectCallTime), 0, C.OCI_ATTR_COLLECT_CALL_TIME)
+	if err != nil {
+		return fmt.Errorf("collect call time attribute set error: %v", err)
+	}
+	conn.collectCallTime = true
+
+	return nil
+}
+
+// PurgeStatementCache clears the OCI statement cache of the session, so statements prepared after DDL changes are parsed again.
+// OCI_ATTR_STMTCACHESIZE is set to 0, which frees the cached statements, then set back to its size.
+// The statements cached for internal queries are closed too. Open statements are not changed.
+func (conn *OCI8Conn) PurgeStatementCache() error {
+	for _, stmt := range conn.cachedStmts {
+		stmt.Close()
+	}
+	conn.cachedStmts = nil
+
+	var cacheSize C.ub4
+	result := C.OCIAttrGet(
+		unsafe.Pointer(conn.svc),   // Pointer to a handle type
+		C.OCI_HTYPE_SVCCTX,         // The handle type: OCI_HTYPE_SVCCTX, for a service context
+		unsafe.Pointer(&cacheSize), // Pointer to the storage for an attribute value
+		nil,                        // The size of the attribute value
+		C.OCI_ATTR_STMTCACHESIZE,   // The attribute type: OCI_ATTR_STMTCACHESIZE, the statement cache size
+		conn.errHandle,             // An error handle
+	)
+	if result != C.OCI_SUCCESS {
+		return fmt.Errorf("statement cache size attribute get error: %v", conn.getError(result))
+	}
+	if cacheSize == 0 {
+		// statement cache is not enabled
+		return nil
+	}
+
+	var zero C.ub4
+	err := conn.ociAttrSet(unsafe.Pointer(conn.svc), C.OCI_HTYPE_SVCCTX, unsafe.Pointer(&zero), 0, C.OCI_ATTR_STMTCACHESIZE)
+	if err != nil {
+		return fmt.Errorf("statement cache size attribute set error: %v", err)
+	}
+	err = conn.ociAttrSet(unsafe.Pointer(conn.svc), C.OCI_HTYPE_SVCCTX, unsafe.Pointer(&cacheSize), 0, C.OCI_ATTR_STMTCACHESIZE)
+	if err != nil {
+		return fmt.Errorf("statement cache size attribute set error: %v", err)
+	}
+
+	return nil
+}
+
+// setDefaultLOBPrefetchSize sets OCI_ATTR_DEFAULT_LOBPREFETCH_SIZE on the session,
+// the number of LOB bytes prefetched with each LOB locator by statements on the connection.
+func (conn *OCI8Conn) setDefaultLOBPrefetchSize(size C.ub4) error {
+	session, err := conn.ociSession()
+	if err != nil {
+		return err
+	}
+
+	err = conn.ociAttrSet(session, C.OCI_HTYPE_SESSION, unsafe.Pointer(&size), 0, C.OCI_ATTR_DEFAULT_LOBPREFETCH_SIZE)
+	if err != nil {
+		return fmt.Errorf("default LOB prefetch size attribute set error: %v", err)
+	}
+
+	return nil
+}
+
+// SetEndToEndInfo sets the session end-to-end tracing attributes OCI_ATTR_MODULE, OCI_ATTR_ACTION, and OCI_ATTR_CLIENT_INFO,
+// which are seen in V$SESSION, V$SQL_MONITOR, and SYS_CONTEXT('USERENV', ...).
+// The attributes are sent to the server with the next call on the connection, without an extra round trip.
+func (conn *OCI8Conn) SetEndToEndInfo(module string, action string, clientInfo string) error {
+	session, err := conn.ociSession()
+	if err != nil {
+		return err
+	}
+
+	attributes := []struct {
+		name          string
+		value         string
+		attributeType C.ub4
+	}{
+		{name: "module", value: module, attributeType: C.OCI_ATTR_MODULE},
+		{name: "action", value: action, attributeType: C.OCI_ATTR_ACTION},
+		{name: "client info", value: clientInfo, attributeType: C.OCI_ATTR_CLIENT_INFO},
+	}
+
+	for _, attribute := range attributes {
+		value := cString(attribute.value)
+		err = conn.ociAttrSet(session, C.OCI_HTYPE_SESSION, unsafe.Pointer(value), C.ub4(len(attribute.value)), attribute.attributeType)
+		C.free(unsafe.Pointer(value))
+		if err != nil {
+			return fmt.Errorf("%v attribute set error: %v", attribute.name, err)
+		}
+	}
+
+	return nil
+}
+
+// SessionID returns the session SID, from SYS_CONTEXT('USERENV', 'SID'), which is the V$SESSION SID.
+// The SID is queried once per connection then cached.
+func (conn *OCI8Conn) SessionID() (int, error) {
+	if conn.sessionID > 0 {
+		return conn.sessionID, nil
+	}
+
+	values, err := conn.queryRowArgs(context.Background(), "select SYS_CONTEXT('USERENV', 'SID') from dual")
+	if err != nil {
+		return 0, err
+	}
+
+	sid, _ := values[0].(string)
+	sessionID, err := strconv.Atoi(sid)
+	if err != nil || sessionID < 1 {
+		return 0, fmt.Errorf("invalid session id: %v", values[0])
+	}
+	conn.sessionID = sessionID
+
+	return sessionID, nil
+}
+
+// ChangePassword changes the password of the session user from the old password to the new password with OCIPasswordChange,
+// which also works in a session with a password in the grace period after it expired, ORA-28002.
+// The session is already authenticated, so it stays usable without logging in again.
+// The connections of ExecParallel use the new password, but the DSN of new connections must be updated with the new password.
+// When the password has expired, ORA-28001, there is no session to call it on, so use the new_password DSN parameter to change it at logon.
+func (conn *OCI8Conn) ChangePassword(oldPassword string, newPassword string) error {
+	if conn.closed {
+		return driver.ErrBadConn
 	}
+	if newPassword == "" {
+		return errors.New("new password is empty")
+	}
+
+	values, err := conn.queryRowArgs(context.Background(), "select SYS_CONTEXT('USERENV', 'SESSION_USER') from dual")
+	if err != nil {
+		return fmt.Errorf("get session user error: %v", err)
+	}
+	username, _ := values[0].(string)
+
+	usernameP := cString(username)
+	defer C.free(unsafe.Pointer(usernameP))
+	oldPasswordP := cString(oldPassword)
+	defer C.free(unsafe.Pointer(oldPasswordP))
+	newPasswordP := cString(newPassword)
+	defer C.free(unsafe.Pointer(newPasswordP))
+
+	result := C.OCIPasswordChange(
+		conn.svc,                // service context handle with the user session
+		conn.errHandle,          // error handle
+		usernameP,               // user name
+		C.ub4(len(username)),    // length of the user name
+		oldPasswordP,            // old password
+		C.ub4(len(oldPassword)), // length of the old password
+		newPasswordP,            // new password
+		C.ub4(len(newPassword)), // length of the new password
+		C.OCI_DEFAULT,           // mode: OCI_DEFAULT, the user session is already established
+	)
+	err = conn.getError(result)
+	if err != nil {
+		return err
+	}
+
+	conn.dsn.Password = newPassword
+	return nil
+}
+
+// changeExpiredPassword changes the expired password of the user session that failed to begin with ORA-28001,
+// with OCIPasswordChange in OCI_AUTH mode, which begins the user session of the service context with the new password
+func (conn *OCI8Conn) changeExpiredPassword(username string, oldPassword string, newPassword string) error {
+	// sets the authentication context attribute of the service context, which the password change begins
+	err := conn.ociAttrSet(unsafe.Pointer(conn.svc), C.OCI_HTYPE_SVCCTX, unsafe.Pointer(conn.usrSession), 0, C.OCI_ATTR_SESSION)
+	if err != nil {
+		return fmt.Errorf("authentication context attribute set error: %v", err)
+	}
+
+	usernameP := cString(username)
+	defer C.free(unsafe.Pointer(usernameP))
+	oldPasswordP := cString(oldPassword)
+	defer C.free(unsafe.Pointer(oldPasswordP))
+	newPasswordP := cString(newPassword)
+	defer C.free(unsafe.Pointer(newPasswordP))
+
+	result := C.OCIPasswordChange(
+		conn.svc,                // service context handle with the server and the user session handle
+		conn.errHandle,          // error handle
+		usernameP,               // user name
+		C.ub4(len(username)),    // length of the user name
+		oldPasswordP,            // old password
+		C.ub4(len(oldPassword)), // length of the old password
+		newPasswordP,            // new password
+		C.ub4(len(newPassword)), // length of the new password
+		C.OCI_AUTH,              // mode: OCI_AUTH, creates the user session then changes the password, the user stays logged in
+	)
+	if result != C.OCI_SUCCESS && result != C.OCI_SUCCESS_WITH_INFO {
+		return fmt.Errorf("change expired password error: %v", conn.getError(result))
+	}
+	return nil
+}
+
+// isPasswordExpiredError returns true if the error is ORA-28001: the password has expired
+func isPasswordExpiredError(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "ORA-28001:")
+}
+
+// EnableParallelDML enables parallel DML for the session with ALTER SESSION ENABLE PARALLEL DML.
+// If the degree is more than 0, parallel DML is forced with the degree of parallelism by ALTER SESSION FORCE PARALLEL DML PARALLEL degree.
+// Parallel DML can not be enabled in a transaction, and a table modified by parallel DML can not be read or modified
+// in the same transaction until commit or rollback.
+func (conn *OCI8Conn) EnableParallelDML(degree int) error {
+	query := "alter session enable parallel dml"
+	if degree > 0 {
+		query = "alter session force parallel dml parallel " + strconv.Itoa(degree)
+	}
+	_, err := conn.execScriptStatement(context.Background(), query)
+	return err
+}
+
+// DisableParallelDML disables parallel DML for the session with ALTER SESSION DISABLE PARALLEL DML
+func (conn *OCI8Conn) DisableParallelDML() error {
+	_, err := conn.execScriptStatement(context.Background(), "alter session disable parallel dml")
+	return err
+}
+
+// SetEdition sets the edition the session uses for editioned objects with ALTER SESSION SET EDITION.
+// OCI only has OCI_ATTR_EDITION, which is set before the session begins, so ALTER SESSION is used to switch editions on a connection.
+// The edition must be an unquoted identifier and the user needs the USE privilege on it. Can not be called in a transaction.
+func (conn *OCI8Conn) SetEdition(edition string) error {
+	if !isIdentifier(edition) {
+		return fmt.Errorf("invalid edition: %v", edition)
+	}
+	_, err := conn.execScriptStatement(context.Background(), "alter session set edition = "+strings.ToUpper(edition))
+	return err
+}
+
+// CursorCount returns the number of cursors the session has open, the opened cursors current statistic from V$MYSTAT.
+// OCI has no attribute for the open cursor count, so it is queried. Closed statements may be kept open by the OCI statement cache,
+// and cursors are closed on the server with the next round trip. Needs select on V$MYSTAT and V$STATNAME.
+func (conn *OCI8Conn) CursorCount() (int, error) {
+	values, err := conn.queryRowArgs(context.Background(),
+		"select m.VALUE from V$MYSTAT m, V$STATNAME n where m.STATISTIC# = n.STATISTIC# and n.NAME = 'opened cursors current'")
+	if err != nil {
+		return 0, err
+	}
+
+	count, _ := values[0].(float64)
+	return int(count), nil
+}
+
+// MaxCursors returns the open_cursors database parameter, which is the most cursors a session can have open.
+// Needs select on V$PARAMETER.
+func (conn *OCI8Conn) MaxCursors() (int, error) {
+	values, err := conn.queryRowArgs(context.Background(), "select VALUE from V$PARAMETER where NAME = 'open_cursors'")
+	if err != nil {
+		return 0, err
+	}
+
+	value, _ := values[0].(string)
+	maxCursors, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid open_cursors: %v", values[0])
+	}
+	return maxCursors, nil
+}
+
+// ociCallTime returns OCI_ATTR_CALL_TIME, the server time of the preceding call
+func (conn *OCI8Conn) ociCallTime() (time.Duration, error) {
+	session, err := conn.ociSession()
+	if err != nil {
+		return 0, err
+	}
+
+	var callTime C.ub8
+	result := C.OCIAttrGet(
+		session,                   // Pointer to a handle type
+		C.OCI_HTYPE_SESSION,       // The handle type: OCI_HTYPE_SESSION, for a session handle
+		unsafe.Pointer(&callTime), // Pointer to the storage for an attribute value
+		nil,                       // The size of the attribute value
+		C.OCI_ATTR_CALL_TIME,      // The attribute type: OCI_ATTR_CALL_TIME, call time in microseconds
+		conn.errHandle,            // An error handle
+	)
+	if result != C.OCI_SUCCESS {
+		return 0, conn.getError(result)
+	}
+
+	return time.Duration(callTime) * time.Microsecond, nil
+}
+
+// ociSession returns the session handle of the service context.
+// Sessions from OCISessionGet are not in usrSession so it is read from OCI_ATTR_SESSION.
+func (conn *OCI8Conn) ociSession() (unsafe.Pointer, error) {
+	if conn.usrSession != nil {
+		return unsafe.Pointer(conn.usrSession), nil
+	}
+
+	var session unsafe.Pointer
+	result := C.OCIAttrGet(
+		unsafe.Pointer(conn.svc), // Pointer to a handle type
+		C.OCI_HTYPE_SVCCTX,       // The handle type: OCI_HTYPE_SVCCTX, for a service context
+		unsafe.Pointer(&session), // Pointer to the storage for an attribute value
+		nil,                      // The size of the attribute value
+		C.OCI_ATTR_SESSION,       // The attribute type: OCI_ATTR_SESSION, the session handle
+		conn.errHandle,           // An error handle
+	)
+	if result != C.OCI_SUCCESS {
+		return nil, conn.getError(result)
+	}
+
+	return session, nil
 }
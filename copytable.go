@@ -0,0 +1,68 @@
+package oci8
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// CopyTable creates the dest table with the columns of the src table then copies the src rows matching the where condition,
+// with the binds as positional binds in the where condition. An empty where copies all rows.
+// DDL can not have binds, so instead of a single CREATE TABLE AS SELECT, the table is created
+// with CREATE TABLE AS SELECT with no rows, then the rows are copied with INSERT SELECT.
+// If copying the rows fails, the dest table is dropped. The table names must be unquoted identifiers with an optional schema.
+// CREATE is DDL, so it commits the current transaction.
+func (conn *OCI8Conn) CopyTable(ctx context.Context, src string, dest string, where string, binds ...interface{}) error {
+	createQuery, insertQuery, err := copyTableQueries(src, dest, where)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.execScriptStatement(ctx, createQuery)
+	if err != nil {
+		return fmt.Errorf("create table error: %v", err)
+	}
+
+	err = conn.execArgs(ctx, insertQuery, binds...)
+	if err != nil {
+		conn.execScriptStatement(context.Background(), "drop table "+dest+" purge")
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("copy rows error: %v", err)
+	}
+
+	return nil
+}
+
+// copyTableQueries returns the CREATE TABLE and INSERT SELECT statements of CopyTable
+func copyTableQueries(src string, dest string, where string) (string, string, error) {
+	if !isTableName(src) {
+		return "", "", fmt.Errorf("invalid table name: %v", src)
+	}
+	if !isTableName(dest) {
+		return "", "", fmt.Errorf("invalid table name: %v", dest)
+	}
+
+	createQuery := "create table " + dest + " as select * from " + src + " where 1 = 0"
+	insertQuery := "insert into " + dest + " select * from " + src
+	if strings.TrimSpace(where) != "" {
+		insertQuery += " where " + where
+	}
+
+	return createQuery, insertQuery, nil
+}
+
+// isTableName returns true if the name is an unquoted identifier or a schema and unquoted identifier separated by a dot
+func isTableName(name string) bool {
+	parts := strings.Split(name, ".")
+	if len(parts) > 2 {
+		return false
+	}
+	for _, part := range parts {
+		if !isIdentifier(part) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package oci8
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// encryptQuery is the PL/SQL block that encrypts a BLOB with DBMS_CRYPTO.ENCRYPT using a random IV from DBMS_CRYPTO.RANDOMBYTES,
+// then returns the IV followed by the encrypted data. Binds are the data, IV size, algorithm, key, and result.
+const encryptQuery = `declare
+	src blob := :1;
+	iv raw(16) := DBMS_CRYPTO.RANDOMBYTES(:2);
+	encrypted blob;
+	dst blob;
+begin
+	DBMS_LOB.CREATETEMPORARY(encrypted, true);
+	DBMS_CRYPTO.ENCRYPT(encrypted, src, :3, :4, iv);
+	DBMS_LOB.CREATETEMPORARY(dst, true);
+	DBMS_LOB.WRITEAPPEND(dst, UTL_RAW.LENGTH(iv), iv);
+	DBMS_LOB.APPEND(dst, encrypted);
+	:5 := dst;
+end;`
+
+// decryptQuery is the PL/SQL block that splits the IV from the start of the encrypted BLOB, then decrypts the rest
+// with DBMS_CRYPTO.DECRYPT. Binds are the encrypted data, IV size, algorithm, key, and result.
+const decryptQuery = `declare
+	src blob := :1;
+	iv_size integer := :2;
+	iv raw(16) := DBMS_LOB.SUBSTR(src, iv_size, 1);
+	encrypted blob;
+	dst blob;
+begin
+	DBMS_LOB.CREATETEMPORARY(encrypted, true);
+	DBMS_LOB.COPY(encrypted, src, DBMS_LOB.GETLENGTH(src) - iv_size, 1, iv_size + 1);
+	DBMS_LOB.CREATETEMPORARY(dst, true);
+	DBMS_CRYPTO.DECRYPT(dst, encrypted, :3, :4, iv);
+	:5 := dst;
+end;`
+
+// EncryptRAW encrypts the data with DBMS_CRYPTO.ENCRYPT in the database using the key and algorithm,
+// returning the encrypted data. The data and key are bound as RAW, or as BLOB when longer than 32767 bytes,
+// and the encrypted data is returned as a BLOB, so the size is not limited to the RAW max size.
+// Each call uses a random IV from DBMS_CRYPTO.RANDOMBYTES, which is the start of the encrypted data,
+// so equal data encrypts differently each time. Needs execute on DBMS_CRYPTO.
+func (conn *OCI8Conn) EncryptRAW(ctx context.Context, data []byte, key []byte, algorithm CryptoAlgorithm) ([]byte, error) {
+	return conn.crypto(ctx, encryptQuery, data, key, algorithm)
+}
+
+// DecryptRAW decrypts the encrypted data with DBMS_CRYPTO.DECRYPT in the database using the key and algorithm,
+// returning the decrypted data. The key and algorithm must be the ones used by EncryptRAW, and the encrypted data
+// must start with the IV, as returned by EncryptRAW. Needs execute on DBMS_CRYPTO.
+func (conn *OCI8Conn) DecryptRAW(ctx context.Context, encrypted []byte, key []byte, algorithm CryptoAlgorithm) ([]byte, error) {
+	if blockSize := cryptoBlockSize(algorithm); blockSize > 0 && len(encrypted) <= blockSize {
+		return nil, fmt.Errorf("encrypted data of length %v is not longer than the %v byte IV", len(encrypted), blockSize)
+	}
+	return conn.crypto(ctx, decryptQuery, encrypted, key, algorithm)
+}
+
+// cryptoBlockSize returns the block size of the algorithm, which is the size of its IV, or 0 for an invalid algorithm
+func cryptoBlockSize(algorithm CryptoAlgorithm) int {
+	switch algorithm {
+	case CryptoAES128, CryptoAES192, CryptoAES256:
+		return 16
+	case Crypto3DES:
+		return 8
+	}
+	return 0
+}
+
+// crypto runs the encrypt or decrypt query
+func (conn *OCI8Conn) crypto(ctx context.Context, query string, data []byte, key []byte, algorithm CryptoAlgorithm) ([]byte, error) {
+	blockSize := cryptoBlockSize(algorithm)
+	if blockSize == 0 {
+		return nil, fmt.Errorf("invalid crypto algorithm: %v", algorithm)
+	}
+	if len(data) < 1 {
+		return nil, errors.New("data is empty")
+	}
+	if len(key) < 1 {
+		return nil, errors.New("key is empty")
+	}
+
+	var result []byte
+	err := conn.execArgs(ctx, query, data, int64(blockSize), int64(algorithm), key, lobOut{dest: &result})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"unsafe"
+)
+
+// Rows returns the rows of the cursor opened by the executed statement the cursor was bound to.
+// The rows must be read on the same connection, so use an OCI8Conn or a sql.Conn, not a sql.DB.
+// Closing the rows frees the cursor. Rows can only be called once for each execute.
+func (cursor *OCICursorBind) Rows() (driver.Rows, error) {
+	if cursor.stmt == nil {
+		return nil, errors.New("cursor is not bound or rows already returned")
+	}
+
+	stmt := &OCI8Stmt{conn: cursor.conn, stmt: cursor.stmt, cursor: true}
+	cursor.stmt = nil
+
+	rows, err := stmt.defineRows(context.Background())
+	if err != nil {
+		stmt.Close()
+		return nil, err
+	}
+	rows.closeStmt = true
+
+	return rows, nil
+}
+
+// Close frees the cursor statement handle if Rows was not called
+func (cursor *OCICursorBind) Close() error {
+	if cursor.stmt == nil {
+		return nil
+	}
+	result := C.OCIHandleFree(unsafe.Pointer(cursor.stmt), C.OCI_HTYPE_STMT)
+	cursor.stmt = nil
+	return cursor.conn.getError(result)
+}
+
+// allocate frees the statement handle of a previous bind, if Rows was not called, then allocates a statement handle for the bind
+func (cursor *OCICursorBind) allocate(conn *OCI8Conn) error {
+	err := cursor.Close()
+	if err != nil {
+		return err
+	}
+
+	handle, _, err := conn.ociHandleAlloc(C.OCI_HTYPE_STMT, 0)
+	if err != nil {
+		return err
+	}
+	cursor.conn = conn
+	cursor.stmt = (*C.OCIStmt)(*handle)
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"context"
+	"fmt"
+)
+
+// StartupDatabase starts the instance with OCIDBStartup.
+// The connection needs the DSN parameters as=sysdba and prelim_auth=true.
+// The started instance is not mounted or opened, to do so reconnect without prelim_auth then run
+// alter database mount and alter database open.
+func (conn *OCI8Conn) StartupDatabase(mode StartupMode) error {
+	var flags C.ub4
+	switch mode {
+	case StartupDefault:
+		flags = C.OCI_DEFAULT
+	case StartupForce:
+		flags = C.OCI_DBSTARTUPFLAG_FORCE
+	case StartupRestrict:
+		flags = C.OCI_DBSTARTUPFLAG_RESTRICT
+	default:
+		return fmt.Errorf("invalid startup mode: %v", mode)
+	}
+
+	result := C.OCIDBStartup(
+		conn.svc,       // service context handle
+		conn.errHandle, // error handle
+		nil,            // administration handle, nil uses the default server parameter file
+		C.OCI_DEFAULT,  // mode, must be OCI_DEFAULT
+		flags,          // startup flags: OCI_DEFAULT, OCI_DBSTARTUPFLAG_FORCE, or OCI_DBSTARTUPFLAG_RESTRICT
+	)
+	return conn.getError(result)
+}
+
+// ShutdownDatabase shuts down the database with OCIDBShutdown. The connection needs the DSN parameter as=sysdba.
+// For ShutdownTransactional and ShutdownImmediate the database is then closed, dismounted,
+// and the instance shut down with the final OCIDBShutdown.
+// ShutdownAbort shuts down the instance without closing the database.
+// ShutdownFinal is only the final OCIDBShutdown, after the database has already been closed and dismounted.
+func (conn *OCI8Conn) ShutdownDatabase(mode ShutdownMode) error {
+	var ociMode C.ub4
+	switch mode {
+	case ShutdownTransactional:
+		ociMode = C.OCI_DBSHUTDOWN_TRANSACTIONAL
+	case ShutdownImmediate:
+		ociMode = C.OCI_DBSHUTDOWN_IMMEDIATE
+	case ShutdownAbort:
+		ociMode = C.OCI_DBSHUTDOWN_ABORT
+	case ShutdownFinal:
+		ociMode = C.OCI_DBSHUTDOWN_FINAL
+	default:
+		return fmt.Errorf("invalid shutdown mode: %v", mode)
+	}
+
+	err := conn.ociDBShutdown(ociMode)
+	if err != nil || mode == ShutdownAbort || mode == ShutdownFinal {
+		return err
+	}
+
+	_, err = conn.execScriptStatement(context.Background(), "alter database close normal")
+	if err != nil {
+		return fmt.Errorf("close database error: %v", err)
+	}
+	_, err = conn.execScriptStatement(context.Background(), "alter database dismount")
+	if err != nil {
+		return fmt.Errorf("dismount database error: %v", err)
+	}
+
+	return conn.ociDBShutdown(C.OCI_DBSHUTDOWN_FINAL)
+}
+
+// ociDBShutdown calls OCIDBShutdown
+func (conn *OCI8Conn) ociDBShutdown(mode C.ub4) error {
+	result := C.OCIDBShutdown(
+		conn.svc,       // service context handle
+		conn.errHandle, // error handle
+		nil,            // administration handle, not used
+		mode,           // shutdown mode
+	)
+	return conn.getError(result)
+}
@@ -0,0 +1,76 @@
+package oci8
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	// minDBMSOutputBuffer is the DBMS_OUTPUT.ENABLE min buffer size in bytes
+	minDBMSOutputBuffer = 2000
+	// maxDBMSOutputBuffer is the DBMS_OUTPUT.ENABLE max buffer size in bytes
+	maxDBMSOutputBuffer = 1000000
+)
+
+// EnableDBMSOutput enables DBMS_OUTPUT for the session with the buffer size in bytes, from 2000 to 1000000,
+// or 0 for an unlimited buffer. While enabled, the buffered lines are logged to the driver Logger when rows are closed
+// and when the connection is closed, so the buffer does not fill up with ORA-20000: buffer overflow.
+func (conn *OCI8Conn) EnableDBMSOutput(bufferSize int) error {
+	var bufferValue interface{}
+	if bufferSize != 0 {
+		if bufferSize < minDBMSOutputBuffer || bufferSize > maxDBMSOutputBuffer {
+			return fmt.Errorf("invalid buffer size: %v", bufferSize)
+		}
+		bufferValue = int64(bufferSize)
+	}
+
+	err := conn.execArgs(context.Background(), "begin DBMS_OUTPUT.ENABLE(:1); end;", bufferValue)
+	if err != nil {
+		return fmt.Errorf("enable DBMS_OUTPUT error: %v", err)
+	}
+	conn.dbmsOutput = true
+	return nil
+}
+
+// DisableDBMSOutput disables DBMS_OUTPUT for the session, which discards the buffered lines
+func (conn *OCI8Conn) DisableDBMSOutput() error {
+	err := conn.execArgs(context.Background(), "begin DBMS_OUTPUT.DISABLE; end;")
+	if err != nil {
+		return fmt.Errorf("disable DBMS_OUTPUT error: %v", err)
+	}
+	conn.dbmsOutput = false
+	return nil
+}
+
+// FlushDBMSOutput returns and removes the lines in the DBMS_OUTPUT buffer with DBMS_OUTPUT.GET_LINES
+func (conn *OCI8Conn) FlushDBMSOutput(ctx context.Context) ([]string, error) {
+	var output string
+	err := conn.execArgs(ctx, "declare lines DBMS_OUTPUT.CHARARR; line_count integer := 2147483647; output clob; "+
+		"begin DBMS_OUTPUT.GET_LINES(lines, line_count); for i in 1 .. line_count loop output := output || lines(i) || chr(10); end loop; :1 := output; end;",
+		lobOut{dest: &output})
+	if err != nil {
+		return nil, fmt.Errorf("get DBMS_OUTPUT lines error: %v", err)
+	}
+	if output == "" {
+		return nil, nil
+	}
+
+	return strings.Split(strings.TrimSuffix(output, "\n"), "\n"), nil
+}
+
+// logDBMSOutput logs the lines in the DBMS_OUTPUT buffer to the driver Logger, if DBMS_OUTPUT is enabled by EnableDBMSOutput
+func (conn *OCI8Conn) logDBMSOutput() {
+	if !conn.dbmsOutput || conn.closed {
+		return
+	}
+
+	lines, err := conn.FlushDBMSOutput(context.Background())
+	if err != nil {
+		conn.logger.Print(err)
+		return
+	}
+	for _, line := range lines {
+		conn.logger.Print("DBMS_OUTPUT: ", line)
+	}
+}
@@ -0,0 +1,186 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unsafe"
+)
+
+// ddlBind is the name of the CLOB out bind of the ExportObjectDDL PL/SQL block
+const ddlBind = "oci8_ddl"
+
+// ExportObjectDDL returns a reader of the DDL of a schema object, read from the DBMS_METADATA CLOB in chunks.
+// The objectType is a DBMS_METADATA object type like TABLE, INDEX, VIEW, or PACKAGE, which uses DBMS_METADATA.GET_DDL.
+// The dependent types OBJECT_GRANT, CONSTRAINT, REF_CONSTRAINT, and COMMENT use DBMS_METADATA.GET_DEPENDENT_DDL,
+// with the name being the base object, like the table, and return the DDL of all the dependent objects.
+// An empty owner is the current schema. The owner and name are upper cased, so must be unquoted identifiers.
+// The reader must be closed to free the temporary LOB, and read before the connection is closed or used by another goroutine.
+func (conn *OCI8Conn) ExportObjectDDL(ctx context.Context, objectType string, owner string, name string) (io.ReadCloser, error) {
+	var ownerValue interface{}
+	if owner != "" {
+		if !isIdentifier(owner) {
+			return nil, fmt.Errorf("invalid owner: %v", owner)
+		}
+		ownerValue = strings.ToUpper(owner)
+	}
+	if !isIdentifier(name) {
+		return nil, fmt.Errorf("invalid name: %v", name)
+	}
+
+	stmt, err := conn.prepare(ctx, exportDDLQuery(objectType), false)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	binds, err := stmt.bindValues(ctx, nil, []driver.NamedValue{
+		{Ordinal: 1, Value: strings.ToUpper(objectType)}, {Ordinal: 2, Value: strings.ToUpper(name)}, {Ordinal: 3, Value: ownerValue}})
+	if err != nil {
+		return nil, err
+	}
+	defer freeBinds(binds)
+
+	lobP, _, err := conn.ociDescriptorAlloc(C.OCI_DTYPE_LOB, 0)
+	if err != nil {
+		return nil, err
+	}
+	lobLocator := (*C.OCILobLocator)(*lobP)
+
+	var sbind oci8Bind
+	sbind.dataType = C.SQLT_CLOB
+	sbind.pbuf = unsafe.Pointer(lobP)
+	sbind.maxSize = C.sb4(sizeOfNilPointer)
+	sbind.length = (*C.ub2)(C.malloc(C.sizeof_ub2))
+	*sbind.length = C.ub2(sizeOfNilPointer)
+	sbind.indicator = (*C.sb2)(C.malloc(C.sizeof_sb2))
+	*sbind.indicator = 0
+	// the locator is freed by the reader, so only the length and indicator are freed with the binds
+	binds = append(binds, oci8Bind{length: sbind.length, indicator: sbind.indicator})
+
+	err = stmt.ociBindByName([]byte(":"+ddlBind), &sbind)
+	if err != nil {
+		C.OCIDescriptorFree(unsafe.Pointer(lobLocator), C.OCI_DTYPE_LOB)
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	go conn.ociBreakDone(ctx, done)
+	err = stmt.ociStmtExecute(1, C.OCI_DEFAULT)
+	close(done)
+	if err != nil {
+		C.OCIDescriptorFree(unsafe.Pointer(lobLocator), C.OCI_DTYPE_LOB)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("export DDL error: %v", err)
+	}
+
+	reader := &oci8LobReader{conn: conn, lobLocator: lobLocator, offset: 1}
+	result := C.OCILobGetLength2(
+		conn.svc,       // service context handle
+		conn.errHandle, // error handle
+		lobLocator,     // LOB locator
+		&reader.length, // the length of the LOB, in characters for a CLOB
+	)
+	if result != C.OCI_SUCCESS {
+		err = conn.getError(result)
+		reader.Close()
+		return nil, err
+	}
+
+	return reader, nil
+}
+
+// exportDDLQuery returns the ExportObjectDDL PL/SQL block of the object type, with the type, name, and owner binds
+func exportDDLQuery(objectType string) string {
+	function := "GET_DDL"
+	switch strings.ToUpper(objectType) {
+	case "OBJECT_GRANT", "CONSTRAINT", "REF_CONSTRAINT", "COMMENT":
+		function = "GET_DEPENDENT_DDL"
+	}
+	// the CLOB bind is after the positional binds so their positions are not changed
+	return "declare ddl clob; begin ddl := DBMS_METADATA." + function + "(:1, :2, nvl(:3, user)); :" + ddlBind + " := ddl; end;"
+}
+
+// Read reads the next chunk of the CLOB, each chunk is a round trip of up to lobBufferSize characters.
+func (reader *oci8LobReader) Read(p []byte) (int, error) {
+	if reader.closed {
+		return 0, errors.New("reader is closed")
+	}
+
+	if len(reader.pending) < 1 {
+		if reader.offset > reader.length {
+			return 0, io.EOF
+		}
+
+		if reader.buffer == nil {
+			// up to 4 bytes for each character in the client character set
+			reader.buffer = make([]byte, 4*lobBufferSize)
+		}
+		byteAmount := C.oraub8(0)
+		charAmount := C.oraub8(lobBufferSize)
+		conn := reader.conn
+		result := C.OCILobRead2(
+			conn.svc,                          // service context handle
+			conn.errHandle,                    // error handle
+			reader.lobLocator,                 // LOB or BFILE locator
+			&byteAmount,                       // OUT - the number of bytes read
+			&charAmount,                       // IN - the number of characters to read. OUT - the number of characters read
+			reader.offset,                     // the offset in characters from the start of the CLOB, starting from 1
+			unsafe.Pointer(&reader.buffer[0]), // pointer to a buffer into which the piece will be read
+			C.oraub8(len(reader.buffer)),      // length of the buffer
+			C.OCI_ONE_PIECE,                   // read the amount in one call
+			nil,                               // context pointer for the callback function
+			nil,                               // no callback function
+			0,                                 // character set ID of the buffer data, 0 is the client character set
+			C.SQLCS_IMPLICIT,                  // character set form of the buffer data
+		)
+		if result != C.OCI_SUCCESS {
+			return 0, conn.getError(result)
+		}
+		if charAmount < 1 {
+			return 0, io.EOF
+		}
+		reader.offset += charAmount
+		reader.pending = reader.buffer[:byteAmount]
+	}
+
+	n := copy(p, reader.pending)
+	reader.pending = reader.pending[n:]
+	return n, nil
+}
+
+// Close frees the temporary LOB and the LOB locator
+func (reader *oci8LobReader) Close() error {
+	if reader.closed {
+		return nil
+	}
+	reader.closed = true
+
+	conn := reader.conn
+	var err error
+	var isTemporary C.boolean
+	result := C.OCILobIsTemporary(
+		conn.env,          // environment handle
+		conn.errHandle,    // error handle
+		reader.lobLocator, // LOB locator
+		&isTemporary,      // TRUE if the LOB is temporary
+	)
+	if result == C.OCI_SUCCESS && isTemporary == C.TRUE {
+		result = C.OCILobFreeTemporary(conn.svc, conn.errHandle, reader.lobLocator)
+	}
+	if result != C.OCI_SUCCESS {
+		err = conn.getError(result)
+	}
+
+	C.OCIDescriptorFree(unsafe.Pointer(reader.lobLocator), C.OCI_DTYPE_LOB)
+	reader.lobLocator = nil
+	return err
+}
@@ -0,0 +1,332 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+const (
+	// dirPathColumnSize is the max size of a column value, column values are sent as SQLT_CHR
+	dirPathColumnSize = 4000
+	// dirPathDateFormat is the date format of time.Time values
+	dirPathDateFormat = "YYYY-MM-DD HH24:MI:SS"
+)
+
+// NewDirectPathLoader prepares a direct path load of the table columns, the table can be schema.table.
+// Rows are loaded with Load then committed with Finish or discarded with Abort, one of which must be called to free the loader.
+// Values are converted to text: string, []byte as hex for RAW columns, int, int64, float64, bool as 1 or 0,
+// time.Time for DATE columns with seconds precision, and nil for NULL.
+// Direct path loads lock the table and do not fire triggers or check enabled constraints other than NOT NULL, UNIQUE, and PRIMARY KEY.
+func (conn *OCI8Conn) NewDirectPathLoader(table string, columns []string) (*DirectPathLoader, error) {
+	if len(columns) < 1 {
+		return nil, errors.New("direct path load needs columns")
+	}
+
+	schemaName := ""
+	tableName := table
+	if i := strings.IndexByte(table, '.'); i >= 0 {
+		schemaName = table[:i]
+		tableName = table[i+1:]
+	}
+
+	dpctxP, _, err := conn.ociHandleAlloc(C.OCI_HTYPE_DIRPATH_CTX, 0)
+	if err != nil {
+		return nil, fmt.Errorf("allocate direct path context handle error: %v", err)
+	}
+	loader := &DirectPathLoader{conn: conn, dpctx: (*C.OCIDirPathCtx)(*dpctxP), columnCount: len(columns)}
+
+	err = loader.prepare(schemaName, tableName, columns)
+	if err != nil {
+		loader.free()
+		return nil, err
+	}
+
+	return loader, nil
+}
+
+// prepare sets the table and column attributes of the direct path context then calls OCIDirPathPrepare
+// and allocates the column array and stream
+func (loader *DirectPathLoader) prepare(schemaName string, tableName string, columns []string) error {
+	conn := loader.conn
+	dpctx := unsafe.Pointer(loader.dpctx)
+
+	if schemaName != "" {
+		schemaNameP := cString(schemaName)
+		defer C.free(unsafe.Pointer(schemaNameP))
+		err := conn.ociAttrSet(dpctx, C.OCI_HTYPE_DIRPATH_CTX, unsafe.Pointer(schemaNameP), C.ub4(len(schemaName)), C.OCI_ATTR_SCHEMA_NAME)
+		if err != nil {
+			return fmt.Errorf("schema name attribute set error: %v", err)
+		}
+	}
+
+	tableNameP := cString(tableName)
+	defer C.free(unsafe.Pointer(tableNameP))
+	err := conn.ociAttrSet(dpctx, C.OCI_HTYPE_DIRPATH_CTX, unsafe.Pointer(tableNameP), C.ub4(len(tableName)), C.OCI_ATTR_NAME)
+	if err != nil {
+		return fmt.Errorf("table name attribute set error: %v", err)
+	}
+
+	dateFormat := cString(dirPathDateFormat)
+	defer C.free(unsafe.Pointer(dateFormat))
+	err = conn.ociAttrSet(dpctx, C.OCI_HTYPE_DIRPATH_CTX, unsafe.Pointer(dateFormat), C.ub4(len(dirPathDateFormat)), C.OCI_ATTR_DATEFORMAT)
+	if err != nil {
+		return fmt.Errorf("date format attribute set error: %v", err)
+	}
+
+	columnCount := C.ub2(len(columns))
+	err = conn.ociAttrSet(dpctx, C.OCI_HTYPE_DIRPATH_CTX, unsafe.Pointer(&columnCount), 0, C.OCI_ATTR_NUM_COLS)
+	if err != nil {
+		return fmt.Errorf("number of columns attribute set error: %v", err)
+	}
+
+	var columnList *C.OCIParam // parameter list of the columns
+	result := C.OCIAttrGet(
+		dpctx,                       // Pointer to a handle type
+		C.OCI_HTYPE_DIRPATH_CTX,     // The handle type: OCI_HTYPE_DIRPATH_CTX, for a direct path context
+		unsafe.Pointer(&columnList), // Pointer to the storage for an attribute value
+		nil,                         // The size of the attribute value
+		C.OCI_ATTR_LIST_COLUMNS,     // The attribute type: OCI_ATTR_LIST_COLUMNS, the column parameter list
+		conn.errHandle,              // An error handle
+	)
+	if result != C.OCI_SUCCESS {
+		return fmt.Errorf("column list attribute get error: %v", conn.getError(result))
+	}
+
+	for i, column := range columns {
+		err = loader.setColumn(columnList, C.ub4(i+1), column)
+		if err != nil {
+			return fmt.Errorf("column %v error: %v", column, err)
+		}
+	}
+
+	result = C.OCIDirPathPrepare(loader.dpctx, conn.svc, conn.errHandle)
+	if result != C.OCI_SUCCESS {
+		return conn.getError(result)
+	}
+
+	var handle unsafe.Pointer
+	result = C.OCIHandleAlloc(dpctx, &handle, C.OCI_HTYPE_DIRPATH_COLUMN_ARRAY, 0, nil)
+	if result != C.OCI_SUCCESS {
+		return fmt.Errorf("allocate direct path column array handle error: %v", conn.getError(result))
+	}
+	loader.colArray = (*C.OCIDirPathColArray)(handle)
+
+	result = C.OCIHandleAlloc(dpctx, &handle, C.OCI_HTYPE_DIRPATH_STREAM, 0, nil)
+	if result != C.OCI_SUCCESS {
+		return fmt.Errorf("allocate direct path stream handle error: %v", conn.getError(result))
+	}
+	loader.stream = (*C.OCIDirPathStream)(handle)
+
+	var maxRows C.ub4 // max number of rows in the column array
+	result = C.OCIAttrGet(
+		unsafe.Pointer(loader.colArray),  // Pointer to a handle type
+		C.OCI_HTYPE_DIRPATH_COLUMN_ARRAY, // The handle type: OCI_HTYPE_DIRPATH_COLUMN_ARRAY, for a direct path column array
+		unsafe.Pointer(&maxRows),         // Pointer to the storage for an attribute value
+		nil,                              // The size of the attribute value
+		C.OCI_ATTR_NUM_ROWS,              // The attribute type: OCI_ATTR_NUM_ROWS, the number of rows in the column array
+		conn.errHandle,                   // An error handle
+	)
+	if result != C.OCI_SUCCESS {
+		return fmt.Errorf("number of rows attribute get error: %v", conn.getError(result))
+	}
+	loader.maxRows = int(maxRows)
+	if loader.maxRows < 1 {
+		loader.maxRows = 1
+	}
+
+	return nil
+}
+
+// setColumn sets the name, data type, and data size of the column parameter at the position
+func (loader *DirectPathLoader) setColumn(columnList *C.OCIParam, position C.ub4, column string) error {
+	conn := loader.conn
+	param, err := conn.ociParamGet(columnList, position)
+	if err != nil {
+		return err
+	}
+	defer C.OCIDescriptorFree(unsafe.Pointer(param), C.OCI_DTYPE_PARAM)
+
+	columnName := cString(column)
+	defer C.free(unsafe.Pointer(columnName))
+	err = conn.ociAttrSet(unsafe.Pointer(param), C.OCI_DTYPE_PARAM, unsafe.Pointer(columnName), C.ub4(len(column)), C.OCI_ATTR_NAME)
+	if err != nil {
+		return fmt.Errorf("name attribute set error: %v", err)
+	}
+
+	dataType := C.ub2(C.SQLT_CHR)
+	err = conn.ociAttrSet(unsafe.Pointer(param), C.OCI_DTYPE_PARAM, unsafe.Pointer(&dataType), 0, C.OCI_ATTR_DATA_TYPE)
+	if err != nil {
+		return fmt.Errorf("data type attribute set error: %v", err)
+	}
+
+	dataSize := C.ub4(dirPathColumnSize)
+	err = conn.ociAttrSet(unsafe.Pointer(param), C.OCI_DTYPE_PARAM, unsafe.Pointer(&dataSize), 0, C.OCI_ATTR_DATA_SIZE)
+	if err != nil {
+		return fmt.Errorf("data size attribute set error: %v", err)
+	}
+
+	return nil
+}
+
+// Load converts the rows to direct path streams then loads them. Each row must have a value for each loader column.
+// The rows are not visible until Finish is called.
+func (loader *DirectPathLoader) Load(rows [][]interface{}) error {
+	if loader.done {
+		return errors.New("direct path load is finished")
+	}
+
+	for start := 0; start < len(rows); start += loader.maxRows {
+		end := start + loader.maxRows
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		err := loader.loadRows(rows[start:end])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadRows sets the column array entries for up to maxRows rows then loads the column array
+func (loader *DirectPathLoader) loadRows(rows [][]interface{}) error {
+	conn := loader.conn
+	var buffers []unsafe.Pointer
+	defer func() {
+		for _, buffer := range buffers {
+			C.free(buffer)
+		}
+		C.OCIDirPathColArrayReset(loader.colArray, conn.errHandle)
+	}()
+
+	for i, row := range rows {
+		if len(row) != loader.columnCount {
+			return fmt.Errorf("row has %v values, expected %v", len(row), loader.columnCount)
+		}
+		for j, value := range row {
+			data, isNull, err := dirPathValue(conn.normalizeValue(value))
+			if err != nil {
+				return fmt.Errorf("row value %v error: %v", j, err)
+			}
+
+			var result C.sword
+			if isNull {
+				result = C.OCIDirPathColArrayEntrySet(loader.colArray, conn.errHandle, C.ub4(i), C.ub2(j), nil, 0, C.OCI_DIRPATH_COL_NULL)
+			} else {
+				var buffer unsafe.Pointer
+				if len(data) > 0 {
+					buffer = C.CBytes(data)
+					buffers = append(buffers, buffer)
+				}
+				result = C.OCIDirPathColArrayEntrySet(loader.colArray, conn.errHandle, C.ub4(i), C.ub2(j), (*C.ub1)(buffer), C.ub4(len(data)), C.OCI_DIRPATH_COL_COMPLETE)
+			}
+			if result != C.OCI_SUCCESS {
+				return conn.getError(result)
+			}
+		}
+	}
+
+	rowOffset := C.ub4(0)
+	for {
+		result := C.OCIDirPathColArrayToStream(loader.colArray, loader.dpctx, loader.stream, conn.errHandle, C.ub4(len(rows)), rowOffset)
+		if result != C.OCI_SUCCESS && result != C.OCI_CONTINUE {
+			return conn.getError(result)
+		}
+
+		loadResult := C.OCIDirPathLoadStream(loader.dpctx, loader.stream, conn.errHandle)
+		C.OCIDirPathStreamReset(loader.stream, conn.errHandle)
+		if loadResult != C.OCI_SUCCESS {
+			return conn.getError(loadResult)
+		}
+
+		if result == C.OCI_SUCCESS {
+			return nil
+		}
+
+		// OCI_CONTINUE: the stream is full, continue from the rows converted so far
+		var rowCount C.ub4
+		result = C.OCIAttrGet(unsafe.Pointer(loader.colArray), C.OCI_HTYPE_DIRPATH_COLUMN_ARRAY, unsafe.Pointer(&rowCount), nil, C.OCI_ATTR_ROW_COUNT, conn.errHandle)
+		if result != C.OCI_SUCCESS {
+			return conn.getError(result)
+		}
+		rowOffset += rowCount
+	}
+}
+
+// Finish commits the loaded rows with OCIDirPathFinish then frees the loader
+func (loader *DirectPathLoader) Finish() error {
+	if loader.done {
+		return errors.New("direct path load is finished")
+	}
+	result := C.OCIDirPathFinish(loader.dpctx, loader.conn.errHandle)
+	err := loader.conn.getError(result)
+	loader.free()
+	return err
+}
+
+// Abort discards the loaded rows with OCIDirPathAbort then frees the loader
+func (loader *DirectPathLoader) Abort() error {
+	if loader.done {
+		return errors.New("direct path load is finished")
+	}
+	result := C.OCIDirPathAbort(loader.dpctx, loader.conn.errHandle)
+	err := loader.conn.getError(result)
+	loader.free()
+	return err
+}
+
+// free frees the stream, column array, and direct path context handles
+func (loader *DirectPathLoader) free() {
+	loader.done = true
+	if loader.stream != nil {
+		C.OCIHandleFree(unsafe.Pointer(loader.stream), C.OCI_HTYPE_DIRPATH_STREAM)
+		loader.stream = nil
+	}
+	if loader.colArray != nil {
+		C.OCIHandleFree(unsafe.Pointer(loader.colArray), C.OCI_HTYPE_DIRPATH_COLUMN_ARRAY)
+		loader.colArray = nil
+	}
+	if loader.dpctx != nil {
+		C.OCIHandleFree(unsafe.Pointer(loader.dpctx), C.OCI_HTYPE_DIRPATH_CTX)
+		loader.dpctx = nil
+	}
+}
+
+// dirPathValue converts a value to the text sent to a direct path load, the returned bool is true for NULL
+func dirPathValue(value interface{}) ([]byte, bool, error) {
+	switch value := value.(type) {
+	case nil:
+		return nil, true, nil
+	case string:
+		return []byte(value), false, nil
+	case []byte:
+		if value == nil {
+			return nil, true, nil
+		}
+		return []byte(hex.EncodeToString(value)), false, nil
+	case int:
+		return []byte(strconv.FormatInt(int64(value), 10)), false, nil
+	case int64:
+		return []byte(strconv.FormatInt(value, 10)), false, nil
+	case float64:
+		return []byte(strconv.FormatFloat(value, 'f', -1, 64)), false, nil
+	case bool:
+		if value {
+			return []byte("1"), false, nil
+		}
+		return []byte("0"), false, nil
+	case time.Time:
+		return []byte(value.Format("2006-01-02 15:04:05")), false, nil
+	}
+	return nil, false, fmt.Errorf("invalid direct path value type: %T", value)
+}
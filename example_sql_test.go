@@ -487,15 +487,12 @@ func Example_sqlRowid() {
 		return
 	}
 
-	// get rowid from LastInsertId
-	var rowid2 string // rowid will be put into here
-	var id int64
-	id, err = result.LastInsertId()
-	if err != nil {
-		fmt.Println("LastInsertId error is not nil:", err)
+	// LastInsertId is not supported
+	_, err = result.LastInsertId()
+	if err != oci8.ErrLastInsertIdDeprecated {
+		fmt.Println("LastInsertId error is not ErrLastInsertIdDeprecated:", err)
 		return
 	}
-	rowid2 = oci8.GetLastInsertId(id)
 
 	// select rowid
 	var rowid3 string // rowid will be put into here
@@ -545,8 +542,8 @@ func Example_sqlRowid() {
 		return
 	}
 
-	if len(rowid1) != len(rowid2) || len(rowid2) != len(rowid3) {
-		fmt.Println("rowid len is not equal", rowid1, rowid2, rowid3)
+	if len(rowid1) != len(rowid3) {
+		fmt.Println("rowid len is not equal", rowid1, rowid3)
 		return
 	}
 
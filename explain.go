@@ -0,0 +1,62 @@
+package oci8
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Explain runs EXPLAIN PLAN for the query then returns the plan formatted by DBMS_XPLAN.DISPLAY with the format level,
+// such as BASIC, TYPICAL, ALL, or ADAPTIVE. An empty level is TYPICAL.
+// The plan is explained and its PLAN_TABLE rows are deleted afterwards in autonomous transactions,
+// so Explain does not leave uncommitted rows in the transaction of the connection.
+func (conn *OCI8Conn) Explain(ctx context.Context, query string, level string) (string, error) {
+	if level == "" {
+		level = "TYPICAL"
+	}
+	statementID := "GO_OCI8_" + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	err := conn.execArgs(ctx, "declare pragma autonomous_transaction; begin execute immediate :1; commit; end;",
+		"explain plan set statement_id = '"+statementID+"' for "+query)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		err := conn.execArgs(context.Background(), "declare pragma autonomous_transaction; begin delete from PLAN_TABLE where statement_id = :1; commit; end;", statementID)
+		if err != nil {
+			conn.logger.Print("delete from PLAN_TABLE error: ", err)
+		}
+	}()
+
+	stmt, err := conn.PrepareContext(ctx, "select plan_table_output from table(DBMS_XPLAN.DISPLAY(null, :1, :2))")
+	if err != nil {
+		return "", err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.(*OCI8Stmt).QueryContext(ctx, []driver.NamedValue{{Ordinal: 1, Value: statementID}, {Ordinal: 2, Value: level}})
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var buffer bytes.Buffer
+	dest := make([]driver.Value, 1)
+	for {
+		err = rows.Next(dest)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		line, _ := dest[0].(string)
+		buffer.WriteString(line)
+		buffer.WriteByte('\n')
+	}
+
+	return buffer.String(), nil
+}
@@ -0,0 +1,139 @@
+package oci8
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// FetchAllRows runs the query with the args as positional binds then returns all the rows.
+// If the query has more rows than the max_rows DSN parameter, which defaults to 10000, an error is returned.
+// The context is checked before each row fetch.
+func (conn *OCI8Conn) FetchAllRows(ctx context.Context, query string, args ...interface{}) ([][]interface{}, error) {
+	maxRows := conn.maxRows
+	if maxRows < 1 {
+		maxRows = defaultMaxRows
+	}
+
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	namedValues := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		namedValues[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+
+	rows, err := stmt.(*OCI8Stmt).QueryContext(ctx, namedValues)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columnCount := len(rows.Columns())
+	dest := make([]driver.Value, columnCount)
+	var results [][]interface{}
+	for {
+		err = rows.Next(dest)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(results) == maxRows {
+			return nil, fmt.Errorf("query has more than max rows: %v", maxRows)
+		}
+
+		row := make([]interface{}, columnCount)
+		for i := range dest {
+			row[i] = dest[i]
+		}
+		results = append(results, row)
+	}
+
+	return results, nil
+}
+
+// FetchOneRow runs the query and scans the first row into dest, then closes the cursor. Any other rows are not fetched.
+// Returns sql.ErrNoRows if the query has no rows. OCI errors are returned by FetchOneRow, not by a later Scan.
+// Dest values are a sql.Scanner, like sql.NullInt64, a *interface{}, or a pointer to the exact Go type of the fetched value,
+// like *float64 for NUMBER, *string for VARCHAR2, or *time.Time for DATE. Values are not converted to other types,
+// use a sql.Scanner for that. NULL values set pointer and slice destinations to nil.
+func (conn *OCI8Conn) FetchOneRow(ctx context.Context, query string, dest ...interface{}) error {
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.(*OCI8Stmt).QueryContext(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns := rows.Columns()
+	if len(dest) != len(columns) {
+		return fmt.Errorf("expected %v destination arguments, not %v", len(columns), len(dest))
+	}
+
+	values := make([]driver.Value, len(columns))
+	err = rows.Next(values)
+	if err == io.EOF {
+		return sql.ErrNoRows
+	}
+	if err != nil {
+		return err
+	}
+
+	for i := range values {
+		err = scanValue(dest[i], values[i])
+		if err != nil {
+			return fmt.Errorf("scan column %v %v error: %v", i, columns[i], err)
+		}
+	}
+
+	return nil
+}
+
+// scanValue stores the fetched value in the dest pointer, see FetchOneRow
+func scanValue(dest interface{}, value driver.Value) error {
+	switch d := dest.(type) {
+	case sql.Scanner:
+		return d.Scan(value)
+	case *interface{}:
+		*d = value
+		return nil
+	}
+
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.IsNil() {
+		return fmt.Errorf("destination is not a pointer: %T", dest)
+	}
+	elem := destValue.Elem()
+
+	if value == nil {
+		switch elem.Kind() {
+		case reflect.Ptr, reflect.Slice:
+			elem.Set(reflect.Zero(elem.Type()))
+			return nil
+		}
+		return fmt.Errorf("converting NULL to %v is unsupported, use a sql.Scanner", elem.Type())
+	}
+
+	if reflect.TypeOf(value) != elem.Type() {
+		return fmt.Errorf("converting %T to %v is unsupported, use a *%T or a sql.Scanner", value, elem.Type(), value)
+	}
+	if b, ok := value.([]byte); ok {
+		// fetched []byte can use memory that is reused by the next fetch
+		value = append([]byte(nil), b...)
+	}
+	elem.Set(reflect.ValueOf(value))
+	return nil
+}
@@ -0,0 +1,60 @@
+package oci8
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+)
+
+// FlashbackQuery runs the query as of the SCN with Oracle Flashback Query, returning the rows, which are the data as of the SCN.
+// DBMS_FLASHBACK.ENABLE_AT_SYSTEM_CHANGE_NUMBER is called before the query is executed and DBMS_FLASHBACK.DISABLE after,
+// so the query can be any query, with the args as positional binds. Closing the rows closes the statement.
+// This must be called outside of a transaction and needs execute on DBMS_FLASHBACK.
+func (conn *OCI8Conn) FlashbackQuery(ctx context.Context, scn int64, query string, args ...interface{}) (driver.Rows, error) {
+	err := conn.execArgs(ctx, "begin DBMS_FLASHBACK.ENABLE_AT_SYSTEM_CHANGE_NUMBER(:1); end;", scn)
+	if err != nil {
+		return nil, err
+	}
+	return conn.flashbackQuery(ctx, query, args)
+}
+
+// FlashbackQueryAt runs the query as of the time with Oracle Flashback Query, returning the rows, which are the data as of the time.
+// The time is mapped to an SCN by the database with a granularity of about 3 seconds.
+// DBMS_FLASHBACK.ENABLE_AT_TIME is called before the query is executed and DBMS_FLASHBACK.DISABLE after,
+// so the query can be any query, with the args as positional binds. Closing the rows closes the statement.
+// This must be called outside of a transaction and needs execute on DBMS_FLASHBACK.
+func (conn *OCI8Conn) FlashbackQueryAt(ctx context.Context, ts time.Time, query string, args ...interface{}) (driver.Rows, error) {
+	err := conn.execArgs(ctx, "begin DBMS_FLASHBACK.ENABLE_AT_TIME(:1); end;", ts)
+	if err != nil {
+		return nil, err
+	}
+	return conn.flashbackQuery(ctx, query, args)
+}
+
+// flashbackQuery executes the query then disables flashback mode. Cursors opened in flashback mode keep returning the flashback data.
+func (conn *OCI8Conn) flashbackQuery(ctx context.Context, query string, args []interface{}) (driver.Rows, error) {
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		conn.execArgs(context.Background(), "begin DBMS_FLASHBACK.DISABLE; end;")
+		return nil, err
+	}
+
+	namedValues := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		namedValues[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+
+	rows, err := stmt.(*OCI8Stmt).QueryContext(ctx, namedValues)
+	disableErr := conn.execArgs(context.Background(), "begin DBMS_FLASHBACK.DISABLE; end;")
+	if err != nil {
+		stmt.Close()
+		return nil, err
+	}
+	rows.(*OCI8Rows).closeStmt = true
+	if disableErr != nil {
+		rows.Close()
+		return nil, disableErr
+	}
+
+	return rows, nil
+}
@@ -11,27 +11,376 @@ import "C"
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
+	"io"
 	"io/ioutil"
 	"log"
 	"reflect"
-	"regexp"
 	"strconv"
 	"sync"
 	"time"
 	"unsafe"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 const (
 	lobBufferSize      = 4000
+	maxStringBindSize  = 32767
 	useOCISessionBegin = true
 	sizeOfNilPointer   = unsafe.Sizeof(unsafe.Pointer(nil))
+	maxLockTimeout     = 1000000 * time.Second
+	defaultMaxRows     = 10000
+	defaultBatchSize   = 1000
+	defaultMaxParallel = 4
+	returningRowidBind = "oci8_rowid"
+	maxRowidSize       = 4000
+	smartAllocSize     = 128
+	defaultRACRetry    = 30 * time.Second
+	longPieceSize      = 65536
+	maxPLSQLSize       = 32767
+	largePLSQLBind     = "lob_sql"
+	ociThreaded        = C.OCI_THREADED
+	ociObject          = C.OCI_OBJECT
+	ociNoMutex         = C.OCI_NO_MUTEX
+)
+
+const (
+	// ShutdownTransactional shuts down after active transactions finish, new transactions are not allowed
+	ShutdownTransactional ShutdownMode = iota + 1
+	// ShutdownImmediate shuts down after rolling back active transactions
+	ShutdownImmediate
+	// ShutdownAbort shuts down without waiting for calls to finish, instance recovery is done on the next startup
+	ShutdownAbort
+	// ShutdownFinal shuts down after the database has been closed and dismounted
+	ShutdownFinal
+)
+
+const (
+	// TxBegin is a transaction begin event
+	TxBegin TxEventType = iota
+	// TxCommit is a transaction commit event
+	TxCommit
+	// TxRollback is a transaction rollback event
+	TxRollback
+)
+
+const (
+	// LockSubShare is the DBMS_LOCK sub-shared mode, SS_MODE, and the table lock mode ROW SHARE
+	LockSubShare LockMode = 2
+	// LockSubExclusive is the DBMS_LOCK sub-exclusive mode, SX_MODE, and the table lock mode ROW EXCLUSIVE
+	LockSubExclusive LockMode = 3
+	// LockShared is the DBMS_LOCK shared mode, S_MODE, and the table lock mode SHARE
+	LockShared LockMode = 4
+	// LockSharedSubExclusive is the DBMS_LOCK shared sub-exclusive mode, SSX_MODE, and the table lock mode SHARE ROW EXCLUSIVE
+	LockSharedSubExclusive LockMode = 5
+	// LockExclusive is the DBMS_LOCK exclusive mode, X_MODE, and the table lock mode EXCLUSIVE
+	LockExclusive LockMode = 6
+)
+
+const (
+	// CryptoAES128 is DBMS_CRYPTO AES 128-bit key encryption with CBC chaining and PKCS5 padding
+	CryptoAES128 CryptoAlgorithm = 6 + 256 + 4096
+	// CryptoAES192 is DBMS_CRYPTO AES 192-bit key encryption with CBC chaining and PKCS5 padding
+	CryptoAES192 CryptoAlgorithm = 7 + 256 + 4096
+	// CryptoAES256 is DBMS_CRYPTO AES 256-bit key encryption with CBC chaining and PKCS5 padding
+	CryptoAES256 CryptoAlgorithm = 8 + 256 + 4096
+	// Crypto3DES is DBMS_CRYPTO three key triple DES encryption with CBC chaining and PKCS5 padding
+	Crypto3DES CryptoAlgorithm = 3 + 256 + 4096
+)
+
+const (
+	// ParameterIn is an input bind or argument
+	ParameterIn ParameterDirection = iota
+	// ParameterOut is an output bind or argument
+	ParameterOut
+	// ParameterInOut is an input and output bind or argument
+	ParameterInOut
+)
+
+const (
+	// RACRoundRobin opens connections to the RAC nodes in turn
+	RACRoundRobin RACBalance = iota
+	// RACLatency opens connections to the RAC node with the lowest average connect time
+	RACLatency
+)
+
+const (
+	// ScopeTransaction is a global temporary table with rows deleted on commit
+	ScopeTransaction TempTableScope = iota
+	// ScopeSession is a global temporary table with rows preserved on commit until the session ends
+	ScopeSession
+)
+
+const (
+	// StartupDefault starts the instance
+	StartupDefault StartupMode = iota
+	// StartupForce shuts down with abort then starts the instance
+	StartupForce
+	// StartupRestrict starts the instance in restricted mode, only users with the RESTRICTED SESSION privilege can connect
+	StartupRestrict
 )
 
 type (
+	// ShutdownMode is the database shutdown mode used by OCI8Conn ShutdownDatabase
+	ShutdownMode int
+
+	// StartupMode is the instance startup mode used by OCI8Conn StartupDatabase
+	StartupMode int
+
+	// TxEventType is the type of a transaction event
+	TxEventType int
+
+	// TxEvent is a transaction lifecycle event, passed to the OCI8Conn WatchTransaction listener
+	TxEvent struct {
+		// Type is TxBegin, TxCommit, or TxRollback
+		Type TxEventType
+		// TransactionID is assigned by the driver when the transaction begins and is unique in the process
+		TransactionID string
+		// Timestamp is when the event happened
+		Timestamp time.Time
+	}
+
+	// CompileError is an error or warning from compiling a stored object, returned by OCI8Conn CompileStoredObject
+	CompileError struct {
+		// Line is the line number of the error
+		Line int
+		// Col is the column position in the line of the error
+		Col int
+		// Text is the error text
+		Text string
+		// Attribute is ERROR or WARNING
+		Attribute string
+	}
+
+	// ParameterDirection is the direction of a bind variable, returned in ParameterInfo by OCI8Stmt Parameters
+	ParameterDirection int
+
+	// ParameterInfo describes a bind variable of a statement, returned by OCI8Stmt Parameters
+	ParameterInfo struct {
+		// Name is the bind name without the colon
+		Name string
+		// Direction is ParameterIn, ParameterOut, or ParameterInOut
+		Direction ParameterDirection
+		// DataType is the Oracle internal data type code, like SQLT_CHR 1 or SQLT_NUM 2, 0 if unknown
+		DataType int
+		// MaxLength is the max size of the data in bytes, 0 if unknown
+		MaxLength int
+	}
+
+	// RecycleBinEntry is a dropped object in the recycle bin, returned by OCI8Conn RecycleBinContents
+	RecycleBinEntry struct {
+		// ObjectName is the recycle bin name of the object, like BIN$...
+		ObjectName string
+		// OriginalName is the name of the object before it was dropped
+		OriginalName string
+		// Type is the object type, like TABLE or INDEX
+		Type string
+		// DropTime is when the object was dropped
+		DropTime time.Time
+		// CanUndrop is true if the object can be restored with FLASHBACK TABLE TO BEFORE DROP
+		CanUndrop bool
+		// CanPurge is true if the object can be purged
+		CanPurge bool
+	}
+
+	// CountResult is an approximate row count, returned by OCI8Conn ApproxCount
+	CountResult struct {
+		// Count is the approximate number of rows
+		Count int64
+		// Stale is true if the table statistics were never gathered or are stale, so the count was estimated from the table
+		Stale bool
+	}
+
+	// TableInfo is a table from ALL_TABLES, returned by OCI8Conn ListTables
+	TableInfo struct {
+		// Owner is the table owner
+		Owner string
+		// Name is the table name
+		Name string
+		// NumRows is the number of rows from the last statistics gathering, 0 if never gathered
+		NumRows int64
+		// LastAnalyzed is when the statistics were last gathered, zero if never gathered
+		LastAnalyzed time.Time
+		// Status is VALID or UNUSABLE
+		Status string
+	}
+
+	// ColumnInfo is a table column from ALL_TAB_COLUMNS, returned by OCI8Conn ListColumns
+	ColumnInfo struct {
+		// Name is the column name
+		Name string
+		// ID is the column position in the table, starting from 1
+		ID int
+		// DataType is the column data type name, like NUMBER, VARCHAR2, or TIMESTAMP(6)
+		DataType string
+		// DataLength is the column length in bytes
+		DataLength int64
+		// Precision is the NUMBER precision, 0 if not set
+		Precision int64
+		// Scale is the NUMBER scale, 0 if not set
+		Scale int64
+		// Nullable is true if the column can be null
+		Nullable bool
+	}
+
+	// AQMessage is an Oracle Advanced Queuing message with a RAW payload, used by OCI8Conn EnqueueMessage and DequeueMessage
+	AQMessage struct {
+		// ID is the message id, set by EnqueueMessage and DequeueMessage
+		ID []byte
+		// Payload is the RAW message payload, up to 32767 bytes
+		Payload []byte
+		// Correlation is the message identifier used to dequeue a message by correlation
+		Correlation string
+		// Priority is the message priority, lower numbers are dequeued first when the queue table sorts by priority
+		Priority int
+		// Delay is how long after enqueue the message can be dequeued, in whole seconds rounded up
+		Delay time.Duration
+	}
+
+	// DequeueOptions is the DBMS_AQ dequeue options of OCI8Conn DequeueMessage
+	DequeueOptions struct {
+		// ConsumerName is the subscriber of a multiple consumer queue, empty for a single consumer queue
+		ConsumerName string
+		// Correlation only dequeues messages with the correlation, which can have the LIKE wildcards % and _
+		Correlation string
+		// Wait is how long to wait for a message, in whole seconds rounded up. 0 does not wait and a negative wait is forever.
+		Wait time.Duration
+		// Browse reads the message without removing it from the queue
+		Browse bool
+	}
+
+	// BatchError is the error of a row of an OCI8Stmt ExecBatch, returned by OCI8Stmt BatchErrors
+	BatchError struct {
+		// RowIndex is the zero based index of the row in the batch
+		RowIndex int
+		// ErrorCode is the Oracle error code, like 1 for ORA-00001
+		ErrorCode int
+		// ErrorMessage is the Oracle error message
+		ErrorMessage string
+	}
+
+	// LargePLSQL is PL/SQL text that is always bound as a CLOB, for use as the bind of EXECUTE IMMEDIATE
+	LargePLSQL struct {
+		// Text is the PL/SQL text
+		Text string
+	}
+
+	// lobOut is an out bind of a *string or *[]byte that is always bound as a temporary CLOB or BLOB,
+	// so the out value is not limited to the VARCHAR2 or RAW max size
+	lobOut struct {
+		dest interface{}
+	}
+
+	// BFileRef is a BFILE, a reference to a file outside the database in an Oracle DIRECTORY, returned for BFILE columns
+	// and bound as a BFILE with BFILENAME(Directory, FileName)
+	BFileRef struct {
+		// Directory is the DIRECTORY object name
+		Directory string
+		// FileName is the name of the file in the directory
+		FileName string
+	}
+
+	// SchedulerJob is a DBMS_SCHEDULER job created by OCI8Conn CreateSchedulerJob
+	SchedulerJob struct {
+		// JobName is the job name, can be schema.name
+		JobName string
+		// JobType is PLSQL_BLOCK, STORED_PROCEDURE, EXECUTABLE, or another DBMS_SCHEDULER job type. Defaults to PLSQL_BLOCK
+		JobType string
+		// JobAction is the PL/SQL block, procedure name, or executable the job runs
+		JobAction string
+		// StartDate is when the job first runs, the zero time starts the job when it is enabled
+		StartDate time.Time
+		// RepeatInterval is the calendar or PL/SQL repeat interval, like FREQ=DAILY;BYHOUR=2. Empty runs the job once
+		RepeatInterval string
+		// Enabled enables the job when it is created
+		Enabled bool
+	}
+
+	// ComparisonScan is the source and dest objects compared by OCI8Conn CompareObjects
+	ComparisonScan struct {
+		// ComparisonName is the DBMS_COMPARISON comparison name, the comparison is dropped after the compare
+		ComparisonName string
+		// SchemaName is the schema of the source object, empty is the current schema
+		SchemaName string
+		// ObjectName is the source table or view
+		ObjectName string
+		// DBLink is the database link to the dest database, empty compares objects in the local database
+		DBLink string
+		// RemoteSchemaName is the schema of the dest object, empty is SchemaName
+		RemoteSchemaName string
+		// RemoteObjectName is the dest table or view, empty is ObjectName
+		RemoteObjectName string
+	}
+
+	// ComparisonResult is the row differences found by OCI8Conn CompareObjects
+	ComparisonResult struct {
+		// ScanID is the DBMS_COMPARISON root scan id
+		ScanID int64
+		// DifferentCount is the number of rows in both objects with different values
+		DifferentCount int
+		// MissingInSource is the number of rows in the dest object that are not in the source object
+		MissingInSource int
+		// MissingInDest is the number of rows in the source object that are not in the dest object
+		MissingInDest int
+	}
+
+	// RACBalance is how OCI8RACPool picks the RAC node of a new connection
+	RACBalance int
+
+	// OCI8RACPool is a sql driver that load balances new connections over Oracle RAC nodes, returned by NewRACPool
+	OCI8RACPool struct {
+		mutex         sync.Mutex
+		nodes         []racNode
+		next          int
+		balance       RACBalance
+		retryInterval time.Duration
+		open          func(dsn string) (driver.Conn, error)
+		now           func() time.Time
+	}
+
+	// racNode is a RAC node DSN of an OCI8RACPool
+	racNode struct {
+		dsn     string
+		removed time.Time
+		latency time.Duration
+	}
+
+	// LockMode is a DBMS_LOCK lock mode used by OCI8Conn AcquireLock, and a table lock mode used by OCI8Conn LockTable
+	LockMode int
+
+	// CryptoAlgorithm is the DBMS_CRYPTO encryption type, the sum of the block cipher, chaining, and padding, used by OCI8Conn EncryptRAW
+	CryptoAlgorithm int
+
+	// DirectPathLoader loads rows into a table with OCI direct path loading, which writes data blocks
+	// bypassing the buffer cache and SQL processing, created by OCI8Conn NewDirectPathLoader
+	DirectPathLoader struct {
+		conn        *OCI8Conn
+		dpctx       *C.OCIDirPathCtx
+		colArray    *C.OCIDirPathColArray
+		stream      *C.OCIDirPathStream
+		columnCount int
+		maxRows     int
+		done        bool
+	}
+
+	// TempTableScope is how long the rows of a global temporary table persist, used by OCI8Conn EnsureGlobalTempTable
+	TempTableScope int
+
+	// ColumnDef is a table column definition, used by OCI8Conn EnsureGlobalTempTable
+	ColumnDef struct {
+		// Name is the column name
+		Name string
+		// Type is the column data type, like NUMBER(10) or VARCHAR2(100)
+		Type string
+	}
+
 	// DSN is Oracle Data Source Name
 	DSN struct {
 		Connect              string
+		serverType           string
 		Username             string
 		Password             string
 		prefetchRows         C.ub4
@@ -40,6 +389,37 @@ type (
 		transactionMode      C.ub4
 		enableQMPlaceholders bool
 		operationMode        C.ub4
+		keepAlive            time.Duration
+		shardingKey          *OCI8ShardingKey
+		superShardingKey     *OCI8ShardingKey
+		lobInlineThreshold   C.sb4
+		lockTimeout          time.Duration
+		sessionTimeZone      string
+		networkCompression   string
+		prelimAuth           bool
+		passwordStoreWallet  bool
+		maxRows              int
+		autoReturnRowid      bool
+		normalizeUnicode     bool
+		unicodeNormalization norm.Form
+		defaultEdition       string
+		smartAlloc           bool
+		noMutex              bool
+		lobPrefetchSize      C.ub4
+		objectAsJSON         bool
+		xmlAsCLOB            bool
+		retryOnErrors        []int
+		retryCount           int
+		retryDelay           time.Duration
+		batchSize            int
+		maxParallel          int
+		dbmsOutputBuffer     int
+		newPassword          string
+	}
+
+	// OCI8ShardingKey is an Oracle sharding key, used to route a connection to the shard that has the key data
+	OCI8ShardingKey struct {
+		components []interface{}
 	}
 
 	// OCI8DriverStruct is Oracle driver struct
@@ -47,6 +427,24 @@ type (
 		// Logger is used to log connection ping errors, defaults to discard
 		// To log set it to something like: log.New(os.Stderr, "oci8 ", log.Ldate|log.Ltime|log.LUTC|log.Llongfile)
 		Logger *log.Logger
+
+		typeMappingsMutex sync.RWMutex
+		typeMappings      map[reflect.Type]oci8TypeMapping
+
+		dsnPreprocessorsMutex sync.RWMutex
+		dsnPreprocessors      []func(string) string
+	}
+
+	// oci8TypeMapping converts a custom Go type to and from a driver value
+	oci8TypeMapping struct {
+		toDriver   func(interface{}) (driver.Value, error)
+		fromDriver func(driver.Value) (interface{}, error)
+	}
+
+	// oci8MappedScanner scans a driver value into dest using a type mapping
+	oci8MappedScanner struct {
+		mapping oci8TypeMapping
+		dest    reflect.Value
 	}
 
 	// OCI8Connector is the sql driver connector
@@ -57,32 +455,92 @@ type (
 
 	// OCI8Conn is Oracle connection
 	OCI8Conn struct {
-		svc                  *C.OCISvcCtx
-		srv                  *C.OCIServer
-		env                  *C.OCIEnv
-		errHandle            *C.OCIError
-		usrSession           *C.OCISession
-		prefetchRows         C.ub4
-		prefetchMemory       C.ub4
-		transactionMode      C.ub4
-		operationMode        C.ub4
-		inTransaction        bool
-		enableQMPlaceholders bool
-		closed               bool
-		timeLocation         *time.Location
-		logger               *log.Logger
+		svc                     *C.OCISvcCtx
+		srv                     *C.OCIServer
+		env                     *C.OCIEnv
+		errHandle               *C.OCIError
+		usrSession              *C.OCISession
+		prefetchRows            C.ub4
+		prefetchMemory          C.ub4
+		lobInlineThreshold      C.sb4
+		transactionMode         C.ub4
+		operationMode           C.ub4
+		inTransaction           bool
+		transactionID           string
+		enableQMPlaceholders    bool
+		warnedMixedPlaceholders bool
+		closed                  bool
+		sessionGet              bool
+		collectCallTime         bool
+		timeLocation            *time.Location
+		logger                  *log.Logger
+		oci8Driver              *OCI8DriverStruct
+		statsMutex              sync.Mutex
+		stats                   ConnStats
+		tempTables              map[string]bool
+		txListener              func(TxEvent)
+		cachedStmts             map[string]*OCI8Stmt
+		maxRows                 int
+		autoReturnRowid         bool
+		normalizeUnicode        bool
+		unicodeNormalization    norm.Form
+		sessionID               int
+		smartAlloc              bool
+		xmlContext              unsafe.Pointer
+		objectAsJSON            bool
+		xmlAsCLOB               bool
+		retryOnErrors           []int
+		retryCount              int
+		retryDelay              time.Duration
+		batchSize               int
+		maxParallel             int
+		dsn                     DSN
+		dbmsOutput              bool
+	}
+
+	// ConnStats is the statistics of a connection, returned by OCI8Conn Stats
+	ConnStats struct {
+		// PrepareCount is the number of statements prepared
+		PrepareCount int64
+		// ExecCount is the number of statement executes, for both queries and execs
+		ExecCount int64
+		// FetchCount is the number of rows fetched
+		FetchCount int64
+		// ErrorCount is the number of prepares, executes, and fetches that returned an error
+		ErrorCount int64
+		// BytesSent is the sum of the bind buffer sizes of non-null in binds, not the bytes sent over the network.
+		// LOB data written to locators and the bind name and statement text are not counted.
+		BytesSent int64
+		// BytesReceived is the number of bytes of fetched column values, not including LOB data read from locators
+		BytesReceived int64
 	}
 
 	// OCI8Tx is Oracle transaction
 	OCI8Tx struct {
-		conn *OCI8Conn
+		conn     *OCI8Conn
+		trans    unsafe.Pointer
+		twoPhase bool
+		id       string
 	}
 
 	// OCI8Stmt is Oracle statement
 	OCI8Stmt struct {
-		conn   *OCI8Conn
-		stmt   *C.OCIStmt
-		closed bool
+		conn        *OCI8Conn
+		stmt        *C.OCIStmt
+		closed      bool
+		rebinds     []oci8Bind
+		rebound     bool
+		returnRowid bool
+		batchErrors []BatchError
+		cursor      bool
+		largePLSQL  string
+	}
+
+	// OCICursorBind is a bind value for a REF CURSOR opened by a PL/SQL block, like: begin open :1 for select ...; end;
+	// After the execute, the cursor rows are returned by Rows.
+	OCICursorBind struct {
+		conn *OCI8Conn
+		stmt *C.OCIStmt
 	}
 
 	// OCI8Result is Oracle result
@@ -91,6 +549,10 @@ type (
 		rowsAffectedErr error
 		rowid           string
 		rowidErr        error
+		callTime        time.Duration
+		callTimeErr     error
+		returnRowid     string
+		returnRowidErr  error
 		stmt            *OCI8Stmt
 	}
 
@@ -102,6 +564,11 @@ type (
 		length       *C.ub2
 		indicator    *C.sb2
 		defineHandle *C.OCIDefine
+		fsPrecision  C.ub1
+		objectType   *oci8ObjectType
+		fullSize     C.sb4
+		pieceOffset  C.ub4
+		json         bool
 	}
 
 	oci8Bind struct {
@@ -114,14 +581,93 @@ type (
 		out        sql.Out
 	}
 
+	// oci8LobReader reads a CLOB in chunks, returned by OCI8Conn ExportObjectDDL
+	oci8LobReader struct {
+		conn       *OCI8Conn
+		lobLocator *C.OCILobLocator
+		length     C.oraub8
+		offset     C.oraub8
+		buffer     []byte
+		pending    []byte
+		closed     bool
+	}
+
+	// OCI8AnyType describes the type of a SYS.ANYDATA value
+	OCI8AnyType struct {
+		typeName string
+		typeCode C.OCITypeCode
+		attrs    []oci8TypeAttr
+	}
+
+	// TypeDescriptor describes a user-defined object or collection type, returned by OCI8Conn DescribeType
+	TypeDescriptor struct {
+		// Name is the type name that was described
+		Name string
+		// TypeCode is the OCI type code, like OCI_TYPECODE_OBJECT or OCI_TYPECODE_NAMEDCOLLECTION
+		TypeCode int
+		// Attributes are the attributes of an object type
+		Attributes []AttributeInfo
+		// Element is the element of a collection type, nil for object types
+		Element *AttributeInfo
+	}
+
+	// AttributeInfo describes an object type attribute or a collection element
+	AttributeInfo struct {
+		// Name is the attribute name, empty for a collection element
+		Name string
+		// TypeCode is the OCI type code, like OCI_TYPECODE_NUMBER or OCI_TYPECODE_VARCHAR2
+		TypeCode int
+		// TypeName is the built-in type name or the name of an object or collection type
+		TypeName string
+		// Precision is the precision of numeric types
+		Precision int
+		// Scale is the scale of numeric types
+		Scale int
+	}
+
+	oci8TypeAttr struct {
+		name     string
+		typeCode C.OCITypeCode
+	}
+
+	// oci8ObjectType describes an object type, collection type, or scalar attribute used to read object values.
+	// For object types attrs are the object attributes, for collection types elem is the element type.
+	oci8ObjectType struct {
+		name     string
+		typeCode C.OCITypeCode
+		integer  bool
+		tdo      *C.OCIType
+		attrs    []oci8ObjectAttr
+		elem     *oci8ObjectType
+	}
+
+	oci8ObjectAttr struct {
+		name     string
+		attrType *oci8ObjectType
+	}
+
+	// prefetchRowsKey is the context key of the prefetch rows set by WithPrefetchRows
+	prefetchRowsKey struct{}
+
+	// hintKey is the context key of the optimizer hint set by WithHint
+	hintKey struct{}
+
 	// OCI8Rows is Oracle rows
 	OCI8Rows struct {
-		stmt    *OCI8Stmt
-		defines []oci8Define
-		e       bool
-		closed  bool
-		ctx     context.Context
-		done    chan struct{}
+		stmt          *OCI8Stmt
+		defines       []oci8Define
+		columnNames   []string
+		columnNameMap map[string]int
+		e             bool
+		closed        bool
+		closeStmt     bool
+		ctx           context.Context
+		done          chan struct{}
+		dynamicFetch  bool
+		longWriters   map[int]io.Writer
+		longLengths   map[int]int64
+		pieceBuffer   unsafe.Pointer
+		pieceLength   *C.ub4
 	}
 )
 
@@ -141,8 +687,31 @@ var (
 
 	// ErrNoRowid is result has no rowid
 	ErrNoRowid = errors.New("result has no rowid")
+	// ErrNoCallTime is result has no call time, call time stats are not enabled on the connection
+	ErrNoCallTime = errors.New("result has no call time, call time stats are not enabled")
+	// ErrLastInsertIdDeprecated is returned by LastInsertId, use LastInsertRowid or returning rowid into instead
+	ErrLastInsertIdDeprecated = errors.New("LastInsertId is deprecated, use LastInsertRowid or returning rowid into")
+	// ErrLockTimeout is DBMS_LOCK.REQUEST return code 1, the lock was not acquired before the timeout
+	ErrLockTimeout = errors.New("lock request timed out")
+	// ErrLockDeadlock is DBMS_LOCK.REQUEST return code 2, deadlock
+	ErrLockDeadlock = errors.New("lock request deadlock")
+	// ErrLockAlreadyOwned is DBMS_LOCK.REQUEST return code 4, the session already owns the lock
+	ErrLockAlreadyOwned = errors.New("lock already owned")
+	// ErrLockNotOwned is DBMS_LOCK.RELEASE return code 4, the session does not own the lock
+	ErrLockNotOwned = errors.New("lock not owned")
+	// ErrNoRACNodes is returned by OCI8RACPool Open when all the RAC nodes have been removed from the rotation and none is due for a retry
+	ErrNoRACNodes = errors.New("no RAC nodes available")
+	// ErrXMLNative is returned for XMLTYPE object attributes and collection elements when not built with the xml_native build tag.
+	// XMLTYPE columns are also returned as ErrXMLNative, unless the xml_as_clob DSN parameter converts them to CLOB with XMLSERIALIZE.
+	ErrXMLNative = errors.New("XMLTYPE attributes need the xml_native build tag, or select them as a CLOB with XMLSERIALIZE(CONTENT value AS CLOB)")
+	// ErrPipeTimeout is DBMS_PIPE return code 1, the message was not sent or received before the timeout
+	ErrPipeTimeout = errors.New("pipe timed out")
+	// ErrAQTimeout is ORA-25228, DBMS_AQ.DEQUEUE had no message before the wait ended
+	ErrAQTimeout = errors.New("dequeue timed out")
+	// ErrSnapshotTooOld is ORA-01555: snapshot too old, check for it with errors.Is.
+	// The cursor is invalidated so the entire query must be executed again, a retry may succeed.
+	ErrSnapshotTooOld = errors.New("ORA-01555: snapshot too old")
 
-	phre           = regexp.MustCompile(`\?`)
 	defaultCharset = C.ub2(0)
 
 	typeNil       = reflect.TypeOf(nil)
@@ -151,6 +720,8 @@ var (
 	typeInt64     = reflect.TypeOf(int64(1))
 	typeFloat64   = reflect.TypeOf(float64(1))
 	typeTime      = reflect.TypeOf(time.Time{})
+	typeBFileRef  = reflect.TypeOf(BFileRef{})
+	typeJSON      = reflect.TypeOf(json.RawMessage{})
 
 	// OCI8Driver is the sql driver
 	OCI8Driver = &OCI8DriverStruct{
@@ -159,6 +730,12 @@ var (
 
 	timeLocations []*time.Location
 
+	transactionIDCounter uint64
+
+	// tnsAdmin is the TNS_ADMIN directory set by SetSQLNetOra
+	tnsAdmin      string
+	tnsAdminMutex sync.Mutex
+
 	byteBufferPool = sync.Pool{
 		New: func() interface{} {
 			return make([]byte, lobBufferSize)
@@ -1,3 +1,5 @@
 module github.com/mattn/go-oci8
 
 go 1.13
+
+require golang.org/x/text v0.3.7
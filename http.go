@@ -0,0 +1,88 @@
+package oci8
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// httpRequestQuery is the PL/SQL block that sends a UTL_HTTP request then reads the status code and response body.
+// Binds are url, method, content type, request body, status code, and response body.
+// The request body is only written when the content type is not null.
+// The response is ended on any error reading it, so failed requests do not use up the open requests of the session.
+const httpRequestQuery = `declare
+	req UTL_HTTP.req;
+	resp UTL_HTTP.resp;
+	buffer varchar2(32767);
+	body clob;
+begin
+	req := UTL_HTTP.BEGIN_REQUEST(:1, :2);
+	if :3 is not null then
+		UTL_HTTP.SET_HEADER(req, 'Content-Type', :3);
+		UTL_HTTP.SET_HEADER(req, 'Content-Length', nvl(lengthb(:4), 0));
+		if :4 is not null then
+			UTL_HTTP.WRITE_TEXT(req, :4);
+		end if;
+	end if;
+	resp := UTL_HTTP.GET_RESPONSE(req);
+	begin
+		:5 := resp.status_code;
+		DBMS_LOB.CREATETEMPORARY(body, true);
+		loop
+			UTL_HTTP.READ_TEXT(resp, buffer, 32767);
+			DBMS_LOB.WRITEAPPEND(body, length(buffer), buffer);
+		end loop;
+	exception
+		when UTL_HTTP.END_OF_BODY then
+			UTL_HTTP.END_RESPONSE(resp);
+		when others then
+			UTL_HTTP.END_RESPONSE(resp);
+			if DBMS_LOB.ISTEMPORARY(body) = 1 then
+				DBMS_LOB.FREETEMPORARY(body);
+			end if;
+			raise;
+	end;
+	:6 := body;
+end;`
+
+// HTTPGet sends a GET request to the url with UTL_HTTP from the database, returning the status code and response body.
+// If the context is done while waiting, OCIBreak is called and the context error is returned.
+// Needs execute on UTL_HTTP and a network ACL allowing the user to connect to the host.
+func (conn *OCI8Conn) HTTPGet(ctx context.Context, url string) (int, string, error) {
+	return conn.httpRequest(ctx, "GET", url, "", "")
+}
+
+// HTTPPost sends a POST request with the content type and body, up to 32767 bytes, to the url with UTL_HTTP from the database,
+// returning the status code and response body.
+// If the context is done while waiting, OCIBreak is called and the context error is returned.
+// Needs execute on UTL_HTTP and a network ACL allowing the user to connect to the host.
+func (conn *OCI8Conn) HTTPPost(ctx context.Context, url string, contentType string, body string) (int, string, error) {
+	if contentType == "" {
+		return 0, "", errors.New("content type is empty")
+	}
+	return conn.httpRequest(ctx, "POST", url, contentType, body)
+}
+
+// httpRequest runs httpRequestQuery with the method
+func (conn *OCI8Conn) httpRequest(ctx context.Context, method string, url string, contentType string, body string) (int, string, error) {
+	var statusCode int64
+	var responseBody string
+	var contentTypeArg, bodyArg interface{}
+	if contentType != "" {
+		contentTypeArg = contentType
+		if body != "" {
+			bodyArg = body
+		}
+	}
+
+	err := conn.execArgs(ctx, httpRequestQuery, url, method, contentTypeArg, bodyArg,
+		sql.Out{Dest: &statusCode}, lobOut{dest: &responseBody})
+	if err != nil {
+		if ctx.Err() != nil {
+			return 0, "", ctx.Err()
+		}
+		return 0, "", err
+	}
+
+	return int(statusCode), responseBody, nil
+}
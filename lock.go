@@ -0,0 +1,143 @@
+package oci8
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxLockWait is DBMS_LOCK.MAXWAIT in seconds
+const maxLockWait = 32767
+
+// AcquireLock requests the named DBMS_LOCK lock in the lock mode, waiting up to the timeout, whole seconds rounded up.
+// A negative timeout waits forever. Returns ErrLockTimeout, ErrLockDeadlock, or ErrLockAlreadyOwned for those return codes.
+// If the context is done while waiting, OCIBreak is called and the context error is returned.
+// The lock is kept until ReleaseLock or the session ends. DBMS_LOCK.ALLOCATE_UNIQUE_AUTONOMOUS is used to get the lock handle,
+// so the transaction of the connection is not committed. Needs execute on DBMS_LOCK.
+func (conn *OCI8Conn) AcquireLock(ctx context.Context, lockName string, timeout time.Duration, lockMode LockMode) error {
+	switch lockMode {
+	case LockSubShare, LockSubExclusive, LockShared, LockSharedSubExclusive, LockExclusive:
+	default:
+		return fmt.Errorf("invalid lock mode: %v", lockMode)
+	}
+
+	seconds := int64(maxLockWait)
+	if timeout >= 0 {
+		seconds = int64((timeout + time.Second - 1) / time.Second)
+		if seconds > maxLockWait {
+			seconds = maxLockWait
+		}
+	}
+
+	var returnCode int64
+	err := conn.execArgs(ctx, "declare lock_handle varchar2(128); begin DBMS_LOCK.ALLOCATE_UNIQUE_AUTONOMOUS(:1, lock_handle); :2 := DBMS_LOCK.REQUEST(lock_handle, :3, :4, false); end;",
+		lockName, sql.Out{Dest: &returnCode}, int64(lockMode), seconds)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+
+	return lockRequestError(returnCode)
+}
+
+// ReleaseLock releases the named DBMS_LOCK lock, returns ErrLockNotOwned if the session does not own the lock
+func (conn *OCI8Conn) ReleaseLock(lockName string) error {
+	var returnCode int64
+	err := conn.execArgs(context.Background(), "declare lock_handle varchar2(128); begin DBMS_LOCK.ALLOCATE_UNIQUE_AUTONOMOUS(:1, lock_handle); :2 := DBMS_LOCK.RELEASE(lock_handle); end;",
+		lockName, sql.Out{Dest: &returnCode})
+	if err != nil {
+		return err
+	}
+
+	return lockReleaseError(returnCode)
+}
+
+// LockTable locks the table in the lock mode with LOCK TABLE, which is kept until the transaction commits or rolls back,
+// so the connection must be in a transaction. If wait is false, NOWAIT is used and a lock held by another session
+// is the error ORA-00054: resource busy. If the context is done while waiting, OCIBreak is called and the context error is returned.
+// The table must be an unquoted identifier with an optional schema.
+func (conn *OCI8Conn) LockTable(ctx context.Context, table string, mode LockMode, wait bool) error {
+	query, err := lockTableQuery(table, mode, wait)
+	if err != nil {
+		return err
+	}
+	if !conn.inTransaction {
+		return errors.New("lock table needs a transaction")
+	}
+
+	err = conn.execArgs(ctx, query)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	return nil
+}
+
+// lockTableQuery returns the LOCK TABLE statement of LockTable
+func lockTableQuery(table string, mode LockMode, wait bool) (string, error) {
+	if !isTableName(table) {
+		return "", fmt.Errorf("invalid table name: %v", table)
+	}
+
+	var modeName string
+	switch mode {
+	case LockSubShare:
+		modeName = "row share"
+	case LockSubExclusive:
+		modeName = "row exclusive"
+	case LockShared:
+		modeName = "share"
+	case LockSharedSubExclusive:
+		modeName = "share row exclusive"
+	case LockExclusive:
+		modeName = "exclusive"
+	default:
+		return "", fmt.Errorf("invalid lock mode: %v", mode)
+	}
+
+	query := "lock table " + table + " in " + modeName + " mode"
+	if !wait {
+		query += " nowait"
+	}
+	return query, nil
+}
+
+// lockRequestError returns the error for a DBMS_LOCK.REQUEST return code
+func lockRequestError(returnCode int64) error {
+	switch returnCode {
+	case 0:
+		return nil
+	case 1:
+		return ErrLockTimeout
+	case 2:
+		return ErrLockDeadlock
+	case 3:
+		return errors.New("lock request parameter error")
+	case 4:
+		return ErrLockAlreadyOwned
+	case 5:
+		return errors.New("lock request illegal lock handle")
+	}
+	return fmt.Errorf("lock request unknown return code: %v", returnCode)
+}
+
+// lockReleaseError returns the error for a DBMS_LOCK.RELEASE return code
+func lockReleaseError(returnCode int64) error {
+	switch returnCode {
+	case 0:
+		return nil
+	case 3:
+		return errors.New("lock release parameter error")
+	case 4:
+		return ErrLockNotOwned
+	case 5:
+		return errors.New("lock release illegal lock handle")
+	}
+	return fmt.Errorf("lock release unknown return code: %v", returnCode)
+}
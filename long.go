@@ -0,0 +1,161 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"unsafe"
+)
+
+// ScanLONG streams the LONG column of the rows fetched by the following calls to Next to the writer, in pieces of 64 KB,
+// so LONG values do not need to fit in memory. The column is redefined with OCI_DYNAMIC_FETCH and fetched piecewise.
+// For rows fetched after ScanLONG, Next sets the column dest to the int64 number of bytes written, or nil for a null LONG.
+// Call ScanLONG again to change the writer, for example to a new file for each row. The column index is zero based.
+func (rows *OCI8Rows) ScanLONG(col int, w io.Writer) error {
+	if col < 0 || col >= len(rows.defines) {
+		return fmt.Errorf("invalid column index: %v", col)
+	}
+	if rows.defines[col].dataType != C.SQLT_LNG {
+		return fmt.Errorf("column %v is not a LONG", col)
+	}
+	if w == nil {
+		return errors.New("writer is nil")
+	}
+
+	if _, ok := rows.longWriters[col]; !ok {
+		define := &rows.defines[col]
+		C.free(define.pbuf)
+		define.pbuf = nil
+
+		result := C.OCIDefineByPos(
+			rows.stmt.stmt,           // statement handle
+			&define.defineHandle,     // define handle of the column, which is redefined
+			rows.stmt.conn.errHandle, // error handle
+			C.ub4(col+1),             // position of this value in the select list
+			nil,                      // buffer, set for each piece by OCIStmtSetPieceInfo
+			C.sb4(math.MaxInt32),     // max size of the data, LONG values are up to 2 GB
+			C.SQLT_LNG,               // datatype
+			nil,                      // indicator, set for each piece by OCIStmtSetPieceInfo
+			nil,                      // length, set for each piece by OCIStmtSetPieceInfo
+			nil,                      // column-level return codes
+			C.OCI_DYNAMIC_FETCH,      // mode - OCI_DYNAMIC_FETCH, the data is fetched in pieces
+		)
+		if result != C.OCI_SUCCESS {
+			return rows.stmt.conn.getError(result)
+		}
+
+		if rows.longWriters == nil {
+			rows.longWriters = make(map[int]io.Writer)
+			rows.longLengths = make(map[int]int64)
+		}
+		rows.dynamicFetch = true
+	}
+	rows.longWriters[col] = w
+
+	return nil
+}
+
+// fetchPieces fetches the next row, with the OCI_DYNAMIC_FETCH columns fetched piecewise: ScanLONG columns and smart alloc columns.
+// While the fetch returns OCI_NEED_DATA, the previous piece is finished, then the buffer of the next piece is set with OCIStmtSetPieceInfo.
+// A ScanLONG piece is written to the column writer. A smart alloc piece is read into the define buffer after the previous pieces
+// of the value, and the buffer is grown to the column max size when it is full. Returns the fetch result and any write error.
+func (rows *OCI8Rows) fetchPieces() (C.sword, error) {
+	if rows.pieceLength == nil {
+		rows.pieceLength = (*C.ub4)(C.malloc(C.sizeof_ub4))
+	}
+	if rows.pieceBuffer == nil && len(rows.longWriters) > 0 {
+		rows.pieceBuffer = C.malloc(longPieceSize)
+	}
+	for col := range rows.longWriters {
+		rows.longLengths[col] = 0
+		*rows.defines[col].indicator = 0
+	}
+	for i := range rows.defines {
+		if rows.defines[i].fullSize > 0 {
+			rows.defines[i].pieceOffset = 0
+			*rows.defines[i].indicator = 0
+		}
+	}
+
+	var writeErr error
+	pending := -1
+	finishPiece := func() {
+		if pending < 0 {
+			return
+		}
+		length := *rows.pieceLength
+		if _, ok := rows.longWriters[pending]; !ok {
+			rows.defines[pending].pieceOffset += length
+		} else if length > 0 && *rows.defines[pending].indicator != -1 {
+			rows.longLengths[pending] += int64(length)
+			if writeErr == nil {
+				// after a write error the remaining pieces are still fetched, so the statement can be used
+				_, writeErr = rows.longWriters[pending].Write(C.GoBytes(rows.pieceBuffer, C.int(length)))
+			}
+		}
+		pending = -1
+	}
+
+	result := C.OCIStmtFetch2(rows.stmt.stmt, rows.stmt.conn.errHandle, 1, C.OCI_FETCH_NEXT, 0, C.OCI_DEFAULT)
+	for result == C.OCI_NEED_DATA {
+		finishPiece()
+
+		var handle unsafe.Pointer // define handle of the piece
+		var handleType C.ub4      // OCI_HTYPE_DEFINE
+		var inOut C.ub1           // OCI_PARAM_OUT
+		var iteration, index C.ub4
+		var piece C.ub1 // OCI_FIRST_PIECE or OCI_NEXT_PIECE
+		result = C.OCIStmtGetPieceInfo(rows.stmt.stmt, rows.stmt.conn.errHandle, &handle, &handleType, &inOut, &iteration, &index, &piece)
+		if result != C.OCI_SUCCESS {
+			return result, nil
+		}
+
+		for i := range rows.defines {
+			if unsafe.Pointer(rows.defines[i].defineHandle) == handle {
+				pending = i
+				break
+			}
+		}
+		if pending < 0 {
+			return result, errors.New("piece define handle is not a dynamic fetch column")
+		}
+
+		define := &rows.defines[pending]
+		buffer := rows.pieceBuffer
+		*rows.pieceLength = longPieceSize
+		if _, ok := rows.longWriters[pending]; !ok {
+			if define.pieceOffset >= C.ub4(define.maxSize) && define.maxSize < define.fullSize {
+				// grow the buffer to the column max size, keeping the pieces already fetched
+				define.pbuf = C.realloc(define.pbuf, C.size_t(define.fullSize))
+				define.maxSize = define.fullSize
+			}
+			if define.pieceOffset >= C.ub4(define.maxSize) {
+				return result, fmt.Errorf("column %v value is longer than the max size %v", define.name, define.maxSize)
+			}
+			buffer = unsafe.Pointer(&(*[1 << 30]byte)(define.pbuf)[define.pieceOffset])
+			*rows.pieceLength = C.ub4(define.maxSize) - define.pieceOffset
+		}
+
+		result = C.OCIStmtSetPieceInfo(handle, handleType, rows.stmt.conn.errHandle, buffer, rows.pieceLength, piece,
+			unsafe.Pointer(define.indicator), nil)
+		if result != C.OCI_SUCCESS {
+			return result, nil
+		}
+
+		result = C.OCIStmtFetch2(rows.stmt.stmt, rows.stmt.conn.errHandle, 1, C.OCI_FETCH_NEXT, 0, C.OCI_DEFAULT)
+	}
+	if result == C.OCI_SUCCESS || result == C.OCI_SUCCESS_WITH_INFO {
+		finishPiece()
+		for i := range rows.defines {
+			if rows.defines[i].fullSize > 0 {
+				*rows.defines[i].length = C.ub2(rows.defines[i].pieceOffset)
+			}
+		}
+	}
+
+	return result, writeErr
+}
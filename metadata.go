@@ -0,0 +1,304 @@
+package oci8
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// GetDDL returns the DDL of a schema object using DBMS_METADATA.GET_DDL.
+// The objectType is a DBMS_METADATA object type like TABLE, INDEX, VIEW, or PACKAGE.
+// An empty owner is the current schema.
+func (conn *OCI8Conn) GetDDL(objectType string, owner string, name string) (string, error) {
+	var ownerValue interface{}
+	if owner != "" {
+		ownerValue = owner
+	}
+
+	values, err := conn.queryRowArgs(context.Background(), "select DBMS_METADATA.GET_DDL(:1, :2, :3) from dual", objectType, name, ownerValue)
+	if err != nil {
+		return "", fmt.Errorf("get DDL error: %v", err)
+	}
+
+	ddl, _ := values[0].(string)
+	return ddl, nil
+}
+
+// CompileStoredObject compiles the stored object with ALTER COMPILE then returns the compile errors and warnings from ALL_ERRORS.
+// The kind is PROCEDURE, FUNCTION, PACKAGE, PACKAGE BODY, TRIGGER, VIEW, TYPE, or TYPE BODY.
+// An empty owner is the current schema. The owner and name must be unquoted identifiers.
+// A successful compile returns no compile errors, the returned error is for failures to compile or read the errors.
+func (conn *OCI8Conn) CompileStoredObject(ctx context.Context, kind string, owner string, name string) ([]CompileError, error) {
+	query, err := compileQuery(kind, owner, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// compile errors are OCI_SUCCESS_WITH_INFO, ORA-24344: success with compilation error, which exec does not return
+	_, err = conn.execScriptStatement(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("compile error: %v", err)
+	}
+
+	var ownerValue interface{}
+	if owner != "" {
+		ownerValue = strings.ToUpper(owner)
+	}
+
+	stmt, err := conn.PrepareContext(ctx, "select LINE, POSITION, TEXT, ATTRIBUTE from ALL_ERRORS where OWNER = nvl(:1, user) and NAME = :2 and TYPE = :3 order by SEQUENCE")
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.(*OCI8Stmt).QueryContext(ctx, []driver.NamedValue{{Ordinal: 1, Value: ownerValue}, {Ordinal: 2, Value: strings.ToUpper(name)}, {Ordinal: 3, Value: strings.ToUpper(kind)}})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var compileErrors []CompileError
+	dest := make([]driver.Value, 4)
+	for {
+		err = rows.Next(dest)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := dest[0].(float64)
+		col, _ := dest[1].(float64)
+		text, _ := dest[2].(string)
+		attribute, _ := dest[3].(string)
+		compileErrors = append(compileErrors, CompileError{Line: int(line), Col: int(col), Text: text, Attribute: attribute})
+	}
+
+	return compileErrors, nil
+}
+
+// compileQuery returns the ALTER COMPILE statement for the stored object
+func compileQuery(kind string, owner string, name string) (string, error) {
+	if owner != "" && !isIdentifier(owner) {
+		return "", fmt.Errorf("invalid owner: %v", owner)
+	}
+	if !isIdentifier(name) {
+		return "", fmt.Errorf("invalid name: %v", name)
+	}
+	if owner != "" {
+		name = owner + "." + name
+	}
+
+	switch strings.ToUpper(kind) {
+	case "PROCEDURE", "FUNCTION", "PACKAGE", "TRIGGER", "VIEW", "TYPE":
+		return "alter " + strings.ToLower(kind) + " " + name + " compile", nil
+	case "PACKAGE BODY":
+		return "alter package " + name + " compile body", nil
+	case "TYPE BODY":
+		return "alter type " + name + " compile body", nil
+	}
+	return "", fmt.Errorf("invalid kind: %v", kind)
+}
+
+// CompileSchema compiles the stored objects of the schema with DBMS_UTILITY.COMPILE_SCHEMA.
+// If compileAll is false, only the invalid objects are compiled. If reuseSettings is true,
+// the objects are compiled with their current compiler settings instead of the session settings.
+// An empty schema is the current schema. The schema is upper cased, so must be an unquoted identifier.
+// Objects with compile errors stay invalid and are not an error, use CompileStoredObject to get their errors.
+func (conn *OCI8Conn) CompileSchema(ctx context.Context, schema string, compileAll bool, reuseSettings bool) error {
+	var schemaValue interface{}
+	if schema != "" {
+		if !isIdentifier(schema) {
+			return fmt.Errorf("invalid schema: %v", schema)
+		}
+		schemaValue = strings.ToUpper(schema)
+	}
+
+	// PL/SQL BOOLEAN can not be bound, so the flags are bound as numbers and compared
+	err := conn.execArgs(ctx, "begin DBMS_UTILITY.COMPILE_SCHEMA(schema => nvl(:1, user), compile_all => :2 = 1, reuse_settings => :3 = 1); end;",
+		schemaValue, compileAll, reuseSettings)
+	if err != nil {
+		return fmt.Errorf("compile schema error: %v", err)
+	}
+
+	return nil
+}
+
+// objectExistsQuery counts the ALL_OBJECTS objects of the owner, name, and type
+const objectExistsQuery = "select count(1) from ALL_OBJECTS where OWNER = nvl(:1, user) and OBJECT_NAME = :2 and OBJECT_TYPE = :3"
+
+// TableExists returns true if the table exists and the user can access it. It is ObjectExists with kind TABLE.
+func (conn *OCI8Conn) TableExists(ctx context.Context, owner string, table string) (bool, error) {
+	return conn.ObjectExists(ctx, "TABLE", owner, table)
+}
+
+// ObjectExists returns true if ALL_OBJECTS has the object, so it exists and the user can access it.
+// The kind is an ALL_OBJECTS OBJECT_TYPE like TABLE, VIEW, or SYNONYM. Public synonyms have owner PUBLIC.
+// An empty owner is the current schema. The owner and name are upper cased, so must be unquoted identifiers.
+// The statement is prepared once per connection.
+func (conn *OCI8Conn) ObjectExists(ctx context.Context, kind string, owner string, name string) (bool, error) {
+	stmt, err := conn.cachedStmt(ctx, objectExistsQuery)
+	if err != nil {
+		return false, err
+	}
+
+	var ownerValue interface{}
+	if owner != "" {
+		ownerValue = strings.ToUpper(owner)
+	}
+
+	rows, err := stmt.QueryContext(ctx, []driver.NamedValue{{Ordinal: 1, Value: ownerValue}, {Ordinal: 2, Value: strings.ToUpper(name)}, {Ordinal: 3, Value: strings.ToUpper(kind)}})
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	dest := make([]driver.Value, 1)
+	err = rows.Next(dest)
+	if err != nil {
+		return false, err
+	}
+
+	count, _ := dest[0].(float64)
+	return count > 0, nil
+}
+
+// ApproxCount returns an approximate row count of the table without a COUNT(*).
+// If the table statistics are current, Count is NUM_ROWS from ALL_TAB_STATISTICS.
+// If the statistics were never gathered or are stale, Stale is true and Count is estimated with APPROX_COUNT_DISTINCT(ROWID),
+// which scans the table but does not sort. Gather statistics with DBMS_STATS.GATHER_TABLE_STATS for cheaper counts.
+// An empty owner is the current schema. The owner and table are upper cased, so must be unquoted identifiers.
+func (conn *OCI8Conn) ApproxCount(ctx context.Context, table string, owner string) (CountResult, error) {
+	tableName := strings.ToUpper(table)
+	if !isIdentifier(tableName) {
+		return CountResult{}, fmt.Errorf("invalid table name: %v", table)
+	}
+	var ownerValue interface{}
+	if owner != "" {
+		if !isIdentifier(owner) {
+			return CountResult{}, fmt.Errorf("invalid owner: %v", owner)
+		}
+		ownerValue = strings.ToUpper(owner)
+	}
+
+	values, err := conn.queryRowArgs(ctx, "select OWNER, NUM_ROWS, STALE_STATS from ALL_TAB_STATISTICS where OWNER = nvl(:1, user) and TABLE_NAME = :2 and OBJECT_TYPE = 'TABLE'",
+		ownerValue, tableName)
+	if err != nil {
+		if err == io.EOF {
+			return CountResult{}, fmt.Errorf("table not found: %v", table)
+		}
+		return CountResult{}, err
+	}
+
+	if numRows, ok := values[1].(float64); ok && values[2] != "YES" {
+		return CountResult{Count: int64(numRows)}, nil
+	}
+
+	tableOwner, _ := values[0].(string)
+	values, err = conn.queryRowArgs(ctx, "select APPROX_COUNT_DISTINCT(ROWID) from "+tableOwner+"."+tableName)
+	if err != nil {
+		return CountResult{}, err
+	}
+
+	count, _ := values[0].(float64)
+	return CountResult{Count: int64(count), Stale: true}, nil
+}
+
+// ListTables returns the tables of the owner from ALL_TABLES that the user can access, ordered by name.
+// An empty owner is the current schema. The owner is upper cased, so must be an unquoted identifier.
+func (conn *OCI8Conn) ListTables(ctx context.Context, owner string) ([]TableInfo, error) {
+	var ownerValue interface{}
+	if owner != "" {
+		if !isIdentifier(owner) {
+			return nil, fmt.Errorf("invalid owner: %v", owner)
+		}
+		ownerValue = strings.ToUpper(owner)
+	}
+
+	stmt, err := conn.PrepareContext(ctx, "select OWNER, TABLE_NAME, NUM_ROWS, LAST_ANALYZED, STATUS from ALL_TABLES where OWNER = nvl(:1, user) order by TABLE_NAME")
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.(*OCI8Stmt).QueryContext(ctx, []driver.NamedValue{{Ordinal: 1, Value: ownerValue}})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []TableInfo
+	dest := make([]driver.Value, 5)
+	for {
+		err = rows.Next(dest)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		tableOwner, _ := dest[0].(string)
+		name, _ := dest[1].(string)
+		numRows, _ := dest[2].(float64)
+		lastAnalyzed, _ := dest[3].(time.Time)
+		status, _ := dest[4].(string)
+		tables = append(tables, TableInfo{Owner: tableOwner, Name: name, NumRows: int64(numRows), LastAnalyzed: lastAnalyzed, Status: status})
+	}
+
+	return tables, nil
+}
+
+// ListColumns returns the columns of the table from ALL_TAB_COLUMNS, ordered by column position.
+// An empty owner is the current schema. The owner and table are upper cased, so must be unquoted identifiers.
+// A table that does not exist or the user can not access has no columns.
+func (conn *OCI8Conn) ListColumns(ctx context.Context, owner string, table string) ([]ColumnInfo, error) {
+	var ownerValue interface{}
+	if owner != "" {
+		if !isIdentifier(owner) {
+			return nil, fmt.Errorf("invalid owner: %v", owner)
+		}
+		ownerValue = strings.ToUpper(owner)
+	}
+	if !isIdentifier(table) {
+		return nil, fmt.Errorf("invalid table name: %v", table)
+	}
+
+	stmt, err := conn.PrepareContext(ctx, "select COLUMN_NAME, COLUMN_ID, DATA_TYPE, DATA_LENGTH, DATA_PRECISION, DATA_SCALE, NULLABLE from ALL_TAB_COLUMNS "+
+		"where OWNER = nvl(:1, user) and TABLE_NAME = :2 order by COLUMN_ID")
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.(*OCI8Stmt).QueryContext(ctx, []driver.NamedValue{{Ordinal: 1, Value: ownerValue}, {Ordinal: 2, Value: strings.ToUpper(table)}})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []ColumnInfo
+	dest := make([]driver.Value, 7)
+	for {
+		err = rows.Next(dest)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name, _ := dest[0].(string)
+		id, _ := dest[1].(float64)
+		dataType, _ := dest[2].(string)
+		dataLength, _ := dest[3].(float64)
+		precision, _ := dest[4].(float64)
+		scale, _ := dest[5].(float64)
+		nullable, _ := dest[6].(string)
+		columns = append(columns, ColumnInfo{Name: name, ID: int(id), DataType: dataType, DataLength: int64(dataLength),
+			Precision: int64(precision), Scale: int64(scale), Nullable: nullable == "Y"})
+	}
+
+	return columns, nil
+}
@@ -0,0 +1,576 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// describeObjectColumn describes the object or collection type of a SQLT_NTY select-list column
+func (conn *OCI8Conn) describeObjectColumn(param *C.OCIParam) (*oci8ObjectType, error) {
+	schemaName, typeName, err := conn.objectTypeNames(param)
+	if err != nil {
+		return nil, err
+	}
+	return conn.describeObjectType(schemaName, typeName)
+}
+
+// objectTypeNames returns the schema name and type name of a column, type attribute, or collection element parameter
+func (conn *OCI8Conn) objectTypeNames(param *C.OCIParam) (string, string, error) {
+	var name *C.OraText
+	size, err := conn.ociAttrGet(param, unsafe.Pointer(&name), C.OCI_ATTR_SCHEMA_NAME)
+	if err != nil {
+		return "", "", err
+	}
+	schemaName := cGoStringN(name, int(size))
+
+	size, err = conn.ociAttrGet(param, unsafe.Pointer(&name), C.OCI_ATTR_TYPE_NAME)
+	if err != nil {
+		return "", "", err
+	}
+	typeName := cGoStringN(name, int(size))
+
+	return schemaName, typeName, nil
+}
+
+// describeObjectType describes an object or collection type, including the types of its attributes or elements
+func (conn *OCI8Conn) describeObjectType(schemaName string, typeName string) (*oci8ObjectType, error) {
+	name := typeName
+	if schemaName != "" {
+		name = schemaName + "." + typeName
+	}
+
+	describe, param, err := conn.ociDescribeAny(name, C.OCI_PTYPE_TYPE)
+	if err != nil {
+		return nil, fmt.Errorf("describe type %v error: %v", name, err)
+	}
+	defer C.OCIHandleFree(unsafe.Pointer(describe), C.OCI_HTYPE_DESCRIBE)
+
+	objectType := &oci8ObjectType{name: name}
+
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&objectType.typeCode), C.OCI_ATTR_TYPECODE)
+	if err != nil {
+		return nil, err
+	}
+
+	objectType.tdo, err = conn.ociTypeByName(schemaName, typeName)
+	if err != nil {
+		return nil, fmt.Errorf("type by name %v error: %v", name, err)
+	}
+
+	switch objectType.typeCode {
+	case C.OCI_TYPECODE_OBJECT:
+		var attrCount C.ub2 // number of type attributes
+		_, err = conn.ociAttrGet(param, unsafe.Pointer(&attrCount), C.OCI_ATTR_NUM_TYPE_ATTRS)
+		if err != nil {
+			return nil, err
+		}
+		if attrCount < 1 {
+			return objectType, nil
+		}
+
+		var attrList *C.OCIParam // parameter list of the type attributes
+		_, err = conn.ociAttrGet(param, unsafe.Pointer(&attrList), C.OCI_ATTR_LIST_TYPE_ATTRS)
+		if err != nil {
+			return nil, err
+		}
+
+		objectType.attrs = make([]oci8ObjectAttr, attrCount)
+		for i := range objectType.attrs {
+			var attr *C.OCIParam
+			attr, err = conn.ociParamGet(attrList, C.ub4(i+1))
+			if err != nil {
+				return nil, err
+			}
+
+			var attrName *C.OraText // name of the attribute
+			var size C.ub4
+			size, err = conn.ociAttrGet(attr, unsafe.Pointer(&attrName), C.OCI_ATTR_NAME)
+			if err != nil {
+				return nil, err
+			}
+			objectType.attrs[i].name = cGoStringN(attrName, int(size))
+
+			objectType.attrs[i].attrType, err = conn.describeObjectParam(attr)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+	case C.OCI_TYPECODE_NAMEDCOLLECTION:
+		var elem *C.OCIParam // parameter of the collection element
+		_, err = conn.ociAttrGet(param, unsafe.Pointer(&elem), C.OCI_ATTR_COLLECTION_ELEMENT)
+		if err != nil {
+			return nil, err
+		}
+
+		objectType.elem, err = conn.describeObjectParam(elem)
+		if err != nil {
+			return nil, err
+		}
+
+	case C.OCI_TYPECODE_OPAQUE:
+		if name != "SYS.XMLTYPE" {
+			return nil, fmt.Errorf("opaque type %v is not supported", name)
+		}
+		if !xmlNative {
+			return nil, ErrXMLNative
+		}
+
+	default:
+		return nil, fmt.Errorf("type %v with type code %v is not an object or collection type", name, objectType.typeCode)
+	}
+
+	return objectType, nil
+}
+
+// describeObjectParam describes the type of a type attribute or collection element parameter
+func (conn *OCI8Conn) describeObjectParam(param *C.OCIParam) (*oci8ObjectType, error) {
+	var typeCode C.OCITypeCode
+	_, err := conn.ociAttrGet(param, unsafe.Pointer(&typeCode), C.OCI_ATTR_TYPECODE)
+	if err != nil {
+		return nil, err
+	}
+
+	switch typeCode {
+	case C.OCI_TYPECODE_OBJECT, C.OCI_TYPECODE_NAMEDCOLLECTION:
+		var schemaName, typeName string
+		schemaName, typeName, err = conn.objectTypeNames(param)
+		if err != nil {
+			return nil, err
+		}
+		return conn.describeObjectType(schemaName, typeName)
+
+	case C.OCI_TYPECODE_NUMBER, C.OCI_TYPECODE_DECIMAL:
+		var precision C.ub1 // the precision
+		_, err = conn.ociAttrGet(param, unsafe.Pointer(&precision), C.OCI_ATTR_PRECISION)
+		if err != nil {
+			return nil, err
+		}
+
+		var scale C.sb1 // the scale (number of digits to the right of the decimal point)
+		_, err = conn.ociAttrGet(param, unsafe.Pointer(&scale), C.OCI_ATTR_SCALE)
+		if err != nil {
+			return nil, err
+		}
+
+		// same as select-list columns, NUMBER(precision, 0) is an integer, everything else is a float64
+		return &oci8ObjectType{
+			typeCode: typeCode,
+			integer:  !((precision == 0 && scale == 0) || scale > 0 || scale == -127),
+		}, nil
+
+	case C.OCI_TYPECODE_INTEGER, C.OCI_TYPECODE_SMALLINT:
+		return &oci8ObjectType{typeCode: typeCode, integer: true}, nil
+	}
+
+	return &oci8ObjectType{typeCode: typeCode}, nil
+}
+
+// ociTypeByName calls OCITypeByName then returns the type descriptor object
+func (conn *OCI8Conn) ociTypeByName(schemaName string, typeName string) (*C.OCIType, error) {
+	var schemaNameP *C.OraText
+	if schemaName != "" {
+		schemaNameP = cString(schemaName)
+		defer C.free(unsafe.Pointer(schemaNameP))
+	}
+	typeNameP := cString(typeName)
+	defer C.free(unsafe.Pointer(typeNameP))
+
+	var tdo *C.OCIType
+	result := C.OCITypeByName(
+		conn.env,               // environment handle
+		conn.errHandle,         // error handle
+		conn.svc,               // service context handle
+		schemaNameP,            // schema name, NULL for the current schema
+		C.ub4(len(schemaName)), // length of the schema name
+		typeNameP,              // type name
+		C.ub4(len(typeName)),   // length of the type name
+		nil,                    // user readable version of the type, NULL for the latest version
+		0,                      // length of the version name
+		C.OCI_DURATION_SESSION, // pin duration
+		C.OCI_TYPEGET_ALL,      // load the type and the attribute descriptors
+		&tdo,                   // returns the type descriptor object
+	)
+	if result != C.OCI_SUCCESS {
+		return nil, conn.getError(result)
+	}
+
+	return tdo, nil
+}
+
+// ociDefineObject calls OCIDefineObject for a SQLT_NTY define.
+// The define pbuf holds the object instance pointer followed by the null indicator structure pointer.
+func (conn *OCI8Conn) ociDefineObject(define *oci8Define) error {
+	instanceP := (*unsafe.Pointer)(define.pbuf)
+	indicatorP := (*unsafe.Pointer)(unsafe.Pointer(uintptr(define.pbuf) + sizeOfNilPointer))
+	*instanceP = nil
+	*indicatorP = nil
+
+	result := C.OCIDefineObject(
+		define.defineHandle,   // define handle
+		conn.errHandle,        // error handle
+		define.objectType.tdo, // type descriptor object
+		instanceP,             // object instance pointer, when NULL the object is allocated in the object cache
+		nil,                   // object instance size
+		indicatorP,            // null indicator structure pointer, when NULL the structure is allocated in the object cache
+		nil,                   // null indicator structure size
+	)
+
+	return conn.getError(result)
+}
+
+// defineObjectValue returns the fetched value of a SQLT_NTY define
+func (conn *OCI8Conn) defineObjectValue(define *oci8Define) (interface{}, error) {
+	instanceP := (*unsafe.Pointer)(define.pbuf)
+	indicator := *(*unsafe.Pointer)(unsafe.Pointer(uintptr(define.pbuf) + sizeOfNilPointer))
+	if *instanceP == nil {
+		return nil, nil
+	}
+
+	if define.objectType.typeCode == C.OCI_TYPECODE_OPAQUE {
+		// XMLTYPE, the null indicator structure is an OCIInd
+		if indicator != nil && *(*C.OCIInd)(indicator) == C.OCI_IND_NULL {
+			return nil, nil
+		}
+		return conn.xmlTypeValue(*instanceP)
+	}
+	if define.objectType.typeCode == C.OCI_TYPECODE_OBJECT {
+		return conn.objectValue(define.objectType, *instanceP, indicator)
+	}
+	// collections are read from a pointer to the collection, the same as collection attributes
+	return conn.objectValue(define.objectType, unsafe.Pointer(instanceP), indicator)
+}
+
+// freeDefineObjects frees the object instances of SQLT_NTY defines from the object cache
+func (conn *OCI8Conn) freeDefineObjects(defines []oci8Define) {
+	for i := range defines {
+		if defines[i].dataType != C.SQLT_NTY || defines[i].pbuf == nil {
+			continue
+		}
+		instanceP := (*unsafe.Pointer)(defines[i].pbuf)
+		if *instanceP == nil {
+			continue
+		}
+		result := C.OCIObjectFree(conn.env, conn.errHandle, *instanceP, C.OCI_OBJECTFREE_FORCE)
+		if result != C.OCI_SUCCESS {
+			conn.logger.Print("OCIObjectFree error: ", conn.getError(result))
+		}
+		*instanceP = nil
+	}
+}
+
+// objectValue converts an object attribute, collection element, or object instance to a Go value.
+// Objects are returned as map[string]interface{} keyed by attribute name, collections as []interface{}.
+// For objects value is the object instance, for collections value is a pointer to the collection,
+// for scalars value is a pointer to the OCI representation, such as *OCINumber or **OCIString.
+// indicator is the null indicator or null indicator structure, which can be nil.
+func (conn *OCI8Conn) objectValue(objectType *oci8ObjectType, value unsafe.Pointer, indicator unsafe.Pointer) (interface{}, error) {
+	if value == nil || (indicator != nil && *(*C.OCIInd)(indicator) == C.OCI_IND_NULL) {
+		return nil, nil
+	}
+
+	switch objectType.typeCode {
+
+	case C.OCI_TYPECODE_OBJECT:
+		values := make(map[string]interface{}, len(objectType.attrs))
+		for i := range objectType.attrs {
+			attr := &objectType.attrs[i]
+			name := cString(attr.name)
+			nameLength := C.ub4(len(attr.name))
+			var attrIndicator C.OCIInd
+			var attrNullStruct unsafe.Pointer
+			var attrValue unsafe.Pointer
+			var attrTDO *C.OCIType
+			result := C.OCIObjectGetAttr(
+				conn.env,        // environment handle
+				conn.errHandle,  // error handle
+				value,           // object instance
+				indicator,       // null indicator structure of the object instance
+				objectType.tdo,  // type descriptor object of the object instance
+				&name,           // attribute names
+				&nameLength,     // attribute name lengths
+				1,               // number of attribute names
+				nil,             // array indexes, not used
+				0,               // number of array indexes
+				&attrIndicator,  // returns the null status of the attribute
+				&attrNullStruct, // returns the null indicator structure of attribute objects
+				&attrValue,      // returns a pointer to the attribute value
+				&attrTDO,        // returns the attribute type descriptor object
+			)
+			C.free(unsafe.Pointer(name))
+			if result != C.OCI_SUCCESS {
+				return nil, fmt.Errorf("get attribute %v of type %v error: %v", attr.name, objectType.name, conn.getError(result))
+			}
+
+			if attrIndicator == C.OCI_IND_NULL {
+				values[attr.name] = nil
+				continue
+			}
+
+			var err error
+			values[attr.name], err = conn.objectValue(attr.attrType, attrValue, attrNullStruct)
+			if err != nil {
+				return nil, fmt.Errorf("attribute %v of type %v error: %v", attr.name, objectType.name, err)
+			}
+		}
+		return values, nil
+
+	case C.OCI_TYPECODE_NAMEDCOLLECTION, C.OCI_TYPECODE_VARRAY, C.OCI_TYPECODE_TABLE:
+		collection := *(**C.OCIColl)(value)
+		var size C.sb4
+		result := C.OCICollSize(conn.env, conn.errHandle, collection, &size)
+		if result != C.OCI_SUCCESS {
+			return nil, conn.getError(result)
+		}
+
+		values := make([]interface{}, 0, int(size))
+		for index := C.sb4(0); index < size; index++ {
+			var exists C.boolean
+			var elem unsafe.Pointer
+			var elemIndicator unsafe.Pointer
+			result = C.OCICollGetElem(conn.env, conn.errHandle, collection, index, &exists, &elem, &elemIndicator)
+			if result != C.OCI_SUCCESS {
+				return nil, conn.getError(result)
+			}
+			if exists == C.FALSE {
+				// deleted nested table element
+				continue
+			}
+
+			elemValue, err := conn.objectValue(objectType.elem, elem, elemIndicator)
+			if err != nil {
+				return nil, fmt.Errorf("element %v of type %v error: %v", index+1, objectType.name, err)
+			}
+			values = append(values, elemValue)
+		}
+		return values, nil
+
+	case C.OCI_TYPECODE_NUMBER, C.OCI_TYPECODE_DECIMAL, C.OCI_TYPECODE_INTEGER, C.OCI_TYPECODE_SMALLINT,
+		C.OCI_TYPECODE_FLOAT, C.OCI_TYPECODE_REAL, C.OCI_TYPECODE_DOUBLE:
+		number := (*C.OCINumber)(value)
+		if objectType.integer {
+			var data C.sb8
+			result := C.OCINumberToInt(conn.errHandle, number, C.uint(unsafe.Sizeof(data)), C.OCI_NUMBER_SIGNED, unsafe.Pointer(&data))
+			if result != C.OCI_SUCCESS {
+				return nil, conn.getError(result)
+			}
+			return int64(data), nil
+		}
+		var data C.double
+		result := C.OCINumberToReal(conn.errHandle, number, C.uint(unsafe.Sizeof(data)), unsafe.Pointer(&data))
+		if result != C.OCI_SUCCESS {
+			return nil, conn.getError(result)
+		}
+		return float64(data), nil
+
+	case C.OCI_TYPECODE_BDOUBLE:
+		return float64(*(*C.double)(value)), nil
+
+	case C.OCI_TYPECODE_BFLOAT:
+		return float64(*(*C.float)(value)), nil
+
+	case C.OCI_TYPECODE_VARCHAR2, C.OCI_TYPECODE_VARCHAR, C.OCI_TYPECODE_CHAR, C.OCI_TYPECODE_NCHAR, C.OCI_TYPECODE_NVARCHAR2:
+		ociString := *(**C.OCIString)(value)
+		return C.GoStringN((*C.char)(unsafe.Pointer(C.OCIStringPtr(conn.env, ociString))), C.int(C.OCIStringSize(conn.env, ociString))), nil
+
+	case C.OCI_TYPECODE_RAW:
+		ociRaw := *(**C.OCIRaw)(value)
+		return C.GoBytes(unsafe.Pointer(C.OCIRawPtr(conn.env, ociRaw)), C.int(C.OCIRawSize(conn.env, ociRaw))), nil
+
+	case C.OCI_TYPECODE_DATE:
+		date := (*C.OCIDate)(value)
+		return time.Date(
+			int(date.OCIDateYYYY),
+			time.Month(date.OCIDateMM),
+			int(date.OCIDateDD),
+			int(date.OCIDateTime.OCITimeHH),
+			int(date.OCIDateTime.OCITimeMI),
+			int(date.OCIDateTime.OCITimeSS),
+			0,
+			conn.timeLocation), nil
+
+	case C.OCI_TYPECODE_TIMESTAMP, C.OCI_TYPECODE_TIMESTAMP_TZ, C.OCI_TYPECODE_TIMESTAMP_LTZ:
+		aTime, err := conn.ociDateTimeToTime(*(**C.OCIDateTime)(value), objectType.typeCode != C.OCI_TYPECODE_TIMESTAMP)
+		if err != nil {
+			return nil, err
+		}
+		return *aTime, nil
+
+	case C.OCI_TYPECODE_CLOB, C.OCI_TYPECODE_BLOB:
+		buffer, err := conn.ociLobRead(*(**C.OCILobLocator)(value), C.SQLCS_IMPLICIT)
+		if err != nil {
+			return nil, err
+		}
+		if objectType.typeCode == C.OCI_TYPECODE_BLOB {
+			return buffer, nil
+		}
+		return string(buffer), nil
+
+	}
+
+	return nil, fmt.Errorf("unsupported type code %v", objectType.typeCode)
+}
+
+// selectListQuery describes the select-list of the prepared query, then if it has columns to convert, XMLTYPE columns
+// with the xml_as_clob DSN parameter and OBJECT type columns with the object_as_json DSN parameter, returns the query wrapped
+// in a select that converts them, otherwise returns the query unchanged. The query is not run by the describe.
+// If the context is done while describing, OCIBreak is called and the context error is returned.
+// A FOR UPDATE query, which gets ORA-02014 as an inline view, and a query with duplicate column names, which gets ORA-00918,
+// are returned unchanged, so their XMLTYPE columns return ErrXMLNative and their OBJECT columns are returned as a map.
+func (stmt *OCI8Stmt) selectListQuery(ctx context.Context, query string) (string, error) {
+	var stmtType C.ub2
+	_, err := stmt.ociAttrGet(unsafe.Pointer(&stmtType), C.OCI_ATTR_STMT_TYPE)
+	if err != nil {
+		return "", err
+	}
+	if stmtType != C.OCI_STMT_SELECT {
+		return query, nil
+	}
+	words, _ := sqlWords(query)
+	for i := 1; i < len(words); i++ {
+		if words[i-1] == "FOR" && words[i] == "UPDATE" {
+			return query, nil
+		}
+	}
+
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	// OCI_DESCRIBE_ONLY describes the select-list without running the query
+	done := make(chan struct{})
+	go stmt.conn.ociBreakDone(ctx, done)
+	err = stmt.ociStmtExecute(0, C.OCI_DESCRIBE_ONLY)
+	close(done)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", err
+	}
+
+	var paramCount C.ub4 // number of columns in the select-list
+	_, err = stmt.ociAttrGet(unsafe.Pointer(&paramCount), C.OCI_ATTR_PARAM_COUNT)
+	if err != nil {
+		return "", err
+	}
+
+	columns := make([]string, paramCount)
+	conversions := make([]string, paramCount)
+	converted := false
+	for i := range columns {
+		var param *C.OCIParam
+		param, err = stmt.ociParamGet(C.ub4(i + 1))
+		if err != nil {
+			return "", err
+		}
+		defer C.OCIDescriptorFree(unsafe.Pointer(param), C.OCI_DTYPE_PARAM)
+
+		var columnName *C.OraText // name of the column
+		var size C.ub4
+		size, err = stmt.conn.ociAttrGet(param, unsafe.Pointer(&columnName), C.OCI_ATTR_NAME)
+		if err != nil {
+			return "", err
+		}
+		columns[i] = cGoStringN(columnName, int(size))
+
+		var dataType C.ub2
+		_, err = stmt.conn.ociAttrGet(param, unsafe.Pointer(&dataType), C.OCI_ATTR_DATA_TYPE)
+		if err != nil {
+			return "", err
+		}
+		if dataType != C.SQLT_NTY {
+			continue
+		}
+
+		var schemaName, typeName string
+		schemaName, typeName, err = stmt.conn.objectTypeNames(param)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case !xmlNative && stmt.conn.xmlAsCLOB && schemaName == "SYS" && typeName == "XMLTYPE":
+			// CONTENT also serializes XML fragments, and works for binary XML, which getClobVal does not for schema-based values
+			conversions[i] = "XMLSERIALIZE(CONTENT %v AS CLOB)"
+		case stmt.conn.objectAsJSON:
+			var typeCode C.OCITypeCode
+			typeCode, err = stmt.conn.objectTypeCode(schemaName, typeName)
+			if err != nil {
+				return "", err
+			}
+			if typeCode == C.OCI_TYPECODE_OBJECT {
+				conversions[i] = "JSON_OBJECT(%v RETURNING CLOB)"
+			}
+		}
+		if conversions[i] != "" {
+			converted = true
+		}
+	}
+
+	if !converted || hasDuplicateName(columns) {
+		return query, nil
+	}
+	return selectListConvert(query, columns, conversions), nil
+}
+
+// objectTypeCode returns the type code of the named object, collection, or opaque type
+func (conn *OCI8Conn) objectTypeCode(schemaName string, typeName string) (C.OCITypeCode, error) {
+	name := typeName
+	if schemaName != "" {
+		name = schemaName + "." + typeName
+	}
+
+	describe, typeParam, err := conn.ociDescribeAny(name, C.OCI_PTYPE_TYPE)
+	if err != nil {
+		return 0, fmt.Errorf("describe type %v error: %v", name, err)
+	}
+	defer C.OCIHandleFree(unsafe.Pointer(describe), C.OCI_HTYPE_DESCRIBE)
+
+	var typeCode C.OCITypeCode
+	_, err = conn.ociAttrGet(typeParam, unsafe.Pointer(&typeCode), C.OCI_ATTR_TYPECODE)
+	if err != nil {
+		return 0, err
+	}
+	return typeCode, nil
+}
+
+// hasDuplicateName returns true if a column name is in the columns more than once
+func hasDuplicateName(columns []string) bool {
+	names := make(map[string]struct{}, len(columns))
+	for _, column := range columns {
+		if _, ok := names[column]; ok {
+			return true
+		}
+		names[column] = struct{}{}
+	}
+	return false
+}
+
+// selectListConvert wraps the query in a select of its columns, with the columns that have a conversion, a format with %v
+// for the column, converted by it. The column names are kept. An ORDER BY in the query is inside the inline view,
+// which Oracle does not merge away in practice.
+func selectListConvert(query string, columns []string, conversions []string) string {
+	var builder strings.Builder
+	builder.WriteString("select ")
+	for i, column := range columns {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		quoted := `"` + column + `"`
+		if conversions[i] != "" {
+			builder.WriteString(fmt.Sprintf(conversions[i], "oci8_select."+quoted) + " " + quoted)
+		} else {
+			builder.WriteString("oci8_select." + quoted)
+		}
+	}
+	builder.WriteString(" from ( ")
+	builder.WriteString(strings.TrimRight(strings.TrimSpace(query), ";"))
+	builder.WriteString(" ) oci8_select")
+	return builder.String()
+}
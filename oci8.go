@@ -4,16 +4,22 @@ package oci8
 import "C"
 
 import (
+	"bytes"
+	"context"
 	"database/sql/driver"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 	"unsafe"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // ParseDSN parses a DSN used to connect to Oracle
@@ -22,6 +28,10 @@ import (
 //
 // [username/[password]@]host[:port][/service_name][?param1=value1&...&paramN=valueN]
 //
+// The host part can also be an Easy Connect Plus string, [//]host[:port]/service_name[:server][/instance_name],
+// where server is DEDICATED, SHARED, or POOLED, a connect descriptor, or a tnsnames.ora alias.
+// It is passed to Oracle unchanged.
+//
 // Connection timeout can be set in the Oracle files: sqlnet.ora as SQLNET.OUTBOUND_CONNECT_TIMEOUT or tnsnames.ora as CONNECT_TIMEOUT
 //
 // Supported parameters are:
@@ -32,10 +42,98 @@ import (
 // isolation - the isolation level that can be set to: READONLY, SERIALIZABLE, or DEFAULT
 //
 // prefetch_rows - the number of top level rows to be prefetched. Defaults to 0. A 0 means unlimited rows.
+// WithPrefetchRows overrides it for queries run with the returned context.
 //
 // prefetch_memory - the max memory for top level rows to be prefetched. Defaults to 4096. A 0 means unlimited memory.
 //
 // questionph - when true, enables question mark placeholders. Defaults to false. (uses strconv.ParseBool to check for true)
+// SQL that already has Oracle binds, like :name, is not converted. SQL that has both, mixed mode, is not converted and a warning is logged once.
+//
+// keepalive - the interval for dead connection detection probes, like 60s, which keep long idle connections from being dropped by firewalls.
+// Uses Oracle Net EXPIRE_TIME, which has a granularity of minutes and needs an Oracle 19c or higher client.
+// Only works with an Easy Connect string or a connect descriptor, a tnsnames.ora alias is a DSN Validate error.
+//
+// sharding_key - a sharding key column value used to route the connection to a shard of a sharded database.
+// Repeat the parameter for each column of a compound sharding key. Cannot be used with as.
+//
+// super_sharding_key - a super sharding key column value, for composite sharding. Needs sharding_key.
+//
+// max_rows - the max number of rows returned by OCI8Conn FetchAllRows. Defaults to 10000.
+//
+// lob_inline_threshold - when more than 0, CLOB and BLOB columns are fetched inline with a buffer of this many bytes, up to 32767,
+// instead of with a LOB locator then a LOB read. Defaults to 0. This saves round trips for small LOBs,
+// but the buffer is allocated for every LOB column and fetching a LOB larger than the threshold returns an error.
+//
+// lock_timeout - the session DDL_LOCK_TIMEOUT, like 10s, the time DDL statements wait for DML locks before failing. Whole seconds, rounded up.
+//
+// network_compression - on or off, Oracle Net data compression, which reduces the bandwidth used on slow networks.
+// Adds COMPRESSION to the connect string, so only works with an Easy Connect string or a connect descriptor,
+// a tnsnames.ora alias is a DSN Validate error.
+// Needs an Oracle 12c or higher client and database, the database sqlnet.ora SQLNET.COMPRESSION must be on to use compression.
+//
+// prelim_auth - when true, uses a preliminary connection, which can connect to an idle instance to start it with StartupDatabase.
+// Needs as sysdba or sysoper. Only OCI8Conn StartupDatabase and ShutdownDatabase can be used with a preliminary connection.
+//
+// autoreturn_rowid - when true, INSERT ... VALUES statements without a RETURNING clause have RETURNING ROWID INTO :oci8_rowid added,
+// and the ROWID is returned by OCI8Result InsertedRowID. Defaults to false.
+//
+// unicode_normalization - NFC, NFD, NFKC, or NFKD, the Unicode normalization form string bind values are converted to,
+// so strings compared or sorted with NLS_SORT or a linguistic collation match however the client composed them. Defaults to none.
+//
+// password_store - wallet, uses the Oracle Wallet secure external password store for the username and password,
+// which are looked up by the connect string, so no password is kept in the DSN. The DSN password must be empty
+// and the DSN username is not used. Setup steps:
+// create the wallet with: mkstore -wrl /path/to/wallet -create
+// add the credentials with: mkstore -wrl /path/to/wallet -createCredential connect_string username password
+// then in sqlnet.ora set WALLET_LOCATION = (SOURCE = (METHOD = FILE) (METHOD_DATA = (DIRECTORY = /path/to/wallet)))
+// and SQLNET.WALLET_OVERRIDE = TRUE. The connect string must match the wallet entry, for example a tnsnames.ora alias.
+//
+// session_timezone - the session TIME_ZONE set on the server, like +07:00 or America/Phoenix.
+// TIMESTAMP WITH LOCAL TIME ZONE values are returned in the session time zone. Unlike loc, it changes what Oracle returns.
+//
+// smart_alloc - when true, string columns are first fetched into small buffers, and when a value is truncated the buffer
+// is grown to the column max size for the rest of the value. Saves memory for wide VARCHAR2 columns with short values.
+// The columns are fetched piecewise with OCI_DYNAMIC_FETCH. Defaults to false.
+//
+// oci_thread_mode - threaded or no_mutex, the OCIEnvCreate mode of the connection environment. Defaults to threaded, OCI_THREADED.
+// no_mutex adds OCI_NO_MUTEX, so OCI does not lock the environment handle for each call, which is faster for single threaded programs.
+// A connection is only used by one goroutine at a time, but when a context is done OCIBreak is called from another goroutine,
+// so no_mutex should only be used without context timeouts or cancels.
+//
+// default_edition - the edition the session uses for editioned objects with Edition-Based Redefinition, like ORA$BASE.
+// Set after connecting with OCI8Conn SetEdition. Must be an unquoted identifier. Defaults to the database default edition.
+//
+// lob_prefetch_size - when more than 0, the session OCI_ATTR_DEFAULT_LOBPREFETCH_SIZE, the number of bytes of CLOB and BLOB data
+// prefetched with the LOB locator when rows are fetched, so reading a LOB up to this size needs no extra round trip.
+// Unlike lob_inline_threshold, LOBs larger than the size are still read with the locator. Needs an Oracle 11g or higher client and database.
+//
+// xml_as_clob - when true and not built with the xml_native build tag, queries with XMLTYPE columns are wrapped in a select
+// that converts the columns with XMLSERIALIZE(CONTENT column AS CLOB), so they are returned as a string instead of ErrXMLNative.
+// Each query is described when prepared, which is an extra round trip. Queries with duplicate column names or FOR UPDATE are not converted.
+// Defaults to false.
+//
+// object_as_json - when true, queries with OBJECT type columns are wrapped in a select that converts the columns with
+// JSON_OBJECT(column RETURNING CLOB), so they are returned as a JSON string instead of a map. Needs an Oracle 19c or higher database.
+// Each query is described when prepared, which is an extra round trip. Queries with duplicate column names or FOR UPDATE are not converted.
+//
+// retry_on_errors - comma separated ORA error codes, like 60,8177, that are retried when a statement Exec fails with them.
+// Only the failed statement is executed again, on the same session, so the codes should be transient statement errors where that is safe.
+// Errors that make the session unusable, like ORA-00028 or ORA-03113, and Oracle Net connect errors, ORA-12150 to ORA-12699,
+// like ORA-12519, can not be retried and are invalid.
+//
+// retry_count - the max number of Exec retries for retry_on_errors errors. Defaults to 1.
+//
+// retry_delay - the time to wait before each retry, like 100ms. Defaults to 0.
+//
+// batch_size - the number of rows in each array insert of OCI8Conn StreamInsert. Defaults to 1000.
+//
+// max_parallel - the max number of connections OCI8Conn ExecParallel opens to run statements at the same time. Defaults to 4.
+//
+// dbms_output_buffer - when set, DBMS_OUTPUT is enabled with the buffer size in bytes, from 2000 to 1000000, like OCI8Conn EnableDBMSOutput.
+//
+// new_password - when the password is expired, ORA-28001, it is changed to the new password with OCIPasswordChange at logon,
+// which also begins the session. If the password is not expired, the new password is not used. Needs a username and password.
+// After the change the DSN password is no longer valid, so the DSN of new connections must be updated with the new password.
 func ParseDSN(dsnString string) (dsn *DSN, err error) {
 
 	if dsnString == "" {
@@ -70,6 +168,10 @@ func ParseDSN(dsnString string) (dsn *DSN, err error) {
 	}
 
 	dsn.Connect = host
+	dsn.serverType, err = easyConnectServerType(host)
+	if err != nil {
+		return nil, err
+	}
 
 	qp, err := ParseQuery(params)
 	for k, v := range qp {
@@ -108,6 +210,163 @@ func ParseDSN(dsnString string) (dsn *DSN, err error) {
 				return nil, fmt.Errorf("invalid prefetch_memory: %v", v[0])
 			}
 			dsn.prefetchMemory = C.ub4(z)
+		case "max_rows":
+			z, err := strconv.ParseUint(v[0], 10, 31)
+			if err != nil || z == 0 {
+				return nil, fmt.Errorf("invalid max_rows: %v", v[0])
+			}
+			dsn.maxRows = int(z)
+		case "lob_inline_threshold":
+			z, err := strconv.ParseUint(v[0], 10, 16)
+			if err != nil || z > 32767 {
+				return nil, fmt.Errorf("invalid lob_inline_threshold: %v", v[0])
+			}
+			dsn.lobInlineThreshold = C.sb4(z)
+		case "lock_timeout":
+			dsn.lockTimeout, err = time.ParseDuration(v[0])
+			if err != nil || dsn.lockTimeout < 0 || dsn.lockTimeout > maxLockTimeout {
+				return nil, fmt.Errorf("invalid lock_timeout: %v", v[0])
+			}
+		case "session_timezone":
+			if !isSessionTimeZone(v[0]) {
+				return nil, fmt.Errorf("invalid session_timezone: %v", v[0])
+			}
+			dsn.sessionTimeZone = v[0]
+		case "smart_alloc":
+			dsn.smartAlloc, err = strconv.ParseBool(v[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid smart_alloc: %v", v[0])
+			}
+		case "oci_thread_mode":
+			switch v[0] {
+			case "threaded":
+				dsn.noMutex = false
+			case "no_mutex":
+				dsn.noMutex = true
+			default:
+				return nil, fmt.Errorf("invalid oci_thread_mode: %v", v[0])
+			}
+		case "default_edition":
+			if !isIdentifier(v[0]) {
+				return nil, fmt.Errorf("invalid default_edition: %v", v[0])
+			}
+			dsn.defaultEdition = strings.ToUpper(v[0])
+		case "lob_prefetch_size":
+			z, err := strconv.ParseUint(v[0], 10, 32)
+			if err != nil || z == 0 {
+				return nil, fmt.Errorf("invalid lob_prefetch_size: %v", v[0])
+			}
+			dsn.lobPrefetchSize = C.ub4(z)
+		case "retry_on_errors":
+			dsn.retryOnErrors = nil
+			for _, code := range strings.Split(v[0], ",") {
+				z, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(code), "ORA-"), 10, 31)
+				if err != nil || z == 0 {
+					return nil, fmt.Errorf("invalid retry_on_errors: %v", v[0])
+				}
+				if isBadConnError(int(z)) || isNetError(int(z)) {
+					return nil, fmt.Errorf("invalid retry_on_errors: ORA-%05d is a session or connect error, only statement errors can be retried", z)
+				}
+				dsn.retryOnErrors = append(dsn.retryOnErrors, int(z))
+			}
+		case "retry_count":
+			z, err := strconv.ParseUint(v[0], 10, 31)
+			if err != nil || z == 0 {
+				return nil, fmt.Errorf("invalid retry_count: %v", v[0])
+			}
+			dsn.retryCount = int(z)
+		case "retry_delay":
+			dsn.retryDelay, err = time.ParseDuration(v[0])
+			if err != nil || dsn.retryDelay < 0 {
+				return nil, fmt.Errorf("invalid retry_delay: %v", v[0])
+			}
+		case "batch_size":
+			z, err := strconv.ParseUint(v[0], 10, 31)
+			if err != nil || z == 0 {
+				return nil, fmt.Errorf("invalid batch_size: %v", v[0])
+			}
+			dsn.batchSize = int(z)
+		case "max_parallel":
+			z, err := strconv.ParseUint(v[0], 10, 31)
+			if err != nil || z == 0 {
+				return nil, fmt.Errorf("invalid max_parallel: %v", v[0])
+			}
+			dsn.maxParallel = int(z)
+		case "dbms_output_buffer":
+			z, err := strconv.ParseUint(v[0], 10, 31)
+			if err != nil || z < minDBMSOutputBuffer || z > maxDBMSOutputBuffer {
+				return nil, fmt.Errorf("invalid dbms_output_buffer: %v", v[0])
+			}
+			dsn.dbmsOutputBuffer = int(z)
+		case "new_password":
+			if v[0] == "" {
+				return nil, fmt.Errorf("invalid new_password: %v", v[0])
+			}
+			dsn.newPassword = v[0]
+		case "xml_as_clob":
+			dsn.xmlAsCLOB, err = strconv.ParseBool(v[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid xml_as_clob: %v", v[0])
+			}
+		case "object_as_json":
+			dsn.objectAsJSON, err = strconv.ParseBool(v[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid object_as_json: %v", v[0])
+			}
+		case "network_compression":
+			switch v[0] {
+			case "on", "off":
+				dsn.networkCompression = v[0]
+			default:
+				return nil, fmt.Errorf("invalid network_compression: %v", v[0])
+			}
+		case "prelim_auth":
+			dsn.prelimAuth, err = strconv.ParseBool(v[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid prelim_auth: %v", v[0])
+			}
+		case "autoreturn_rowid":
+			dsn.autoReturnRowid, err = strconv.ParseBool(v[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid autoreturn_rowid: %v", v[0])
+			}
+		case "unicode_normalization":
+			switch v[0] {
+			case "NFC":
+				dsn.unicodeNormalization = norm.NFC
+			case "NFD":
+				dsn.unicodeNormalization = norm.NFD
+			case "NFKC":
+				dsn.unicodeNormalization = norm.NFKC
+			case "NFKD":
+				dsn.unicodeNormalization = norm.NFKD
+			default:
+				return nil, fmt.Errorf("invalid unicode_normalization: %v", v[0])
+			}
+			dsn.normalizeUnicode = true
+		case "password_store":
+			if v[0] != "wallet" {
+				return nil, fmt.Errorf("invalid password_store: %v", v[0])
+			}
+			dsn.passwordStoreWallet = true
+		case "keepalive":
+			dsn.keepAlive, err = time.ParseDuration(v[0])
+			if err != nil || dsn.keepAlive <= 0 {
+				return nil, fmt.Errorf("invalid keepalive: %v", v[0])
+			}
+		case "sharding_key", "super_sharding_key":
+			shardingKey := &OCI8ShardingKey{}
+			for _, value := range v {
+				err = shardingKey.AddComponent(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %v: %v", k, err)
+				}
+			}
+			if k == "sharding_key" {
+				dsn.shardingKey = shardingKey
+			} else {
+				dsn.superShardingKey = shardingKey
+			}
 		case "as":
 			switch v[0] {
 			case "SYSDBA", "sysdba":
@@ -123,25 +382,174 @@ func ParseDSN(dsnString string) (dsn *DSN, err error) {
 		}
 	}
 
+	err = dsn.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if dsn.prelimAuth {
+		dsn.operationMode |= C.OCI_PRELIM_AUTH
+	}
+
 	return dsn, nil
 }
 
+// envMode returns the OCIEnvNlsCreate mode of the DSN, OCI_THREADED and OCI_OBJECT, with OCI_NO_MUTEX for oci_thread_mode no_mutex
+func (dsn *DSN) envMode() C.ub4 {
+	envMode := C.ub4(ociThreaded | ociObject)
+	if dsn.noMutex {
+		envMode |= ociNoMutex
+	}
+	return envMode
+}
+
+// Validate checks the DSN for parameters that cannot be used together, so the errors are returned before connecting.
+// ParseDSN calls it, so it only needs to be called for a DSN that was changed after parsing.
+func (dsn *DSN) Validate() error {
+	if dsn.keepAlive > 0 && dsn.Connect == "" {
+		return errors.New("keepalive needs a connect string")
+	}
+	if dsn.keepAlive > 0 && isTNSAlias(dsn.Connect) {
+		return errors.New("keepalive cannot be used with a tnsnames.ora alias, add EXPIRE_TIME to the alias descriptor instead")
+	}
+	if dsn.networkCompression != "" && dsn.Connect == "" {
+		return errors.New("network_compression needs a connect string")
+	}
+	if dsn.networkCompression != "" && isTNSAlias(dsn.Connect) {
+		return errors.New("network_compression cannot be used with a tnsnames.ora alias, add COMPRESSION to the alias descriptor instead")
+	}
+	if dsn.superShardingKey != nil && dsn.shardingKey == nil {
+		return errors.New("super_sharding_key needs sharding_key")
+	}
+	if dsn.shardingKey != nil && dsn.operationMode != 0 {
+		return errors.New("sharding_key cannot be used with as")
+	}
+	if dsn.passwordStoreWallet && dsn.Password != "" {
+		return errors.New("password_store wallet needs an empty password")
+	}
+	if dsn.passwordStoreWallet && dsn.Connect == "" {
+		return errors.New("password_store wallet needs a connect string")
+	}
+	if dsn.Password != "" && dsn.Username == "" && !dsn.passwordStoreWallet {
+		return errors.New("password needs a username")
+	}
+	if dsn.prelimAuth {
+		operationMode := dsn.operationMode &^ C.OCI_PRELIM_AUTH
+		if operationMode != C.OCI_SYSDBA && operationMode != C.OCI_SYSOPER {
+			return errors.New("prelim_auth needs as sysdba or sysoper")
+		}
+		// a preliminary connection cannot run the statements that set these
+		switch {
+		case dsn.lockTimeout > 0:
+			return errors.New("prelim_auth cannot be used with lock_timeout")
+		case dsn.sessionTimeZone != "":
+			return errors.New("prelim_auth cannot be used with session_timezone")
+		case dsn.defaultEdition != "":
+			return errors.New("prelim_auth cannot be used with default_edition")
+		case dsn.lobPrefetchSize > 0:
+			return errors.New("prelim_auth cannot be used with lob_prefetch_size")
+		case dsn.dbmsOutputBuffer > 0:
+			return errors.New("prelim_auth cannot be used with dbms_output_buffer")
+		}
+	}
+	if dsn.newPassword != "" {
+		// the password is changed on the user session of OCISessionBegin
+		switch {
+		case dsn.Username == "" || dsn.Password == "":
+			return errors.New("new_password needs a username and password")
+		case dsn.passwordStoreWallet:
+			return errors.New("new_password cannot be used with password_store")
+		case dsn.shardingKey != nil:
+			return errors.New("new_password cannot be used with sharding_key")
+		case dsn.operationMode != 0:
+			return errors.New("new_password cannot be used with as")
+		}
+	}
+	if len(dsn.retryOnErrors) == 0 && (dsn.retryCount > 0 || dsn.retryDelay > 0) {
+		return errors.New("retry_count and retry_delay need retry_on_errors")
+	}
+
+	return nil
+}
+
+// RegisterDSNPreprocessor adds a function that transforms DSN strings before they are parsed, for example to expand
+// environment variables or to get a password from a vault. Open of the driver applies its functions in the order registered,
+// before the DSN is parsed. ParseDSN does not apply them.
+func (oci8Driver *OCI8DriverStruct) RegisterDSNPreprocessor(preprocessor func(string) string) {
+	oci8Driver.dsnPreprocessorsMutex.Lock()
+	oci8Driver.dsnPreprocessors = append(oci8Driver.dsnPreprocessors, preprocessor)
+	oci8Driver.dsnPreprocessorsMutex.Unlock()
+}
+
+// preprocessDSN applies the registered DSN preprocessors to the DSN string
+func (oci8Driver *OCI8DriverStruct) preprocessDSN(dsnString string) string {
+	if oci8Driver == nil {
+		return dsnString
+	}
+	oci8Driver.dsnPreprocessorsMutex.RLock()
+	defer oci8Driver.dsnPreprocessorsMutex.RUnlock()
+	for _, preprocessor := range oci8Driver.dsnPreprocessors {
+		dsnString = preprocessor(dsnString)
+	}
+	return dsnString
+}
+
+// SetSQLNetOra sets the path of a sqlnet.ora file, or of the directory that has it, used to set Oracle Net options like
+// network encryption with SQLNET.ENCRYPTION_CLIENT and SQLNET.ENCRYPTION_TYPES_CLIENT, or data integrity with
+// SQLNET.CRYPTO_CHECKSUM_CLIENT and SQLNET.CRYPTO_CHECKSUM_TYPES_CLIENT. OCI has no call to set these per connection,
+// so the process wide TNS_ADMIN environment variable is set to the directory, and the Oracle client reads sqlnet.ora once.
+// Call it before opening any connections. It can only be set once, setting a different path returns an error.
+func SetSQLNetOra(path string) error {
+	if path == "" {
+		return errors.New("sqlnet.ora path is empty")
+	}
+	dir := path
+	if strings.EqualFold(filepath.Base(path), "sqlnet.ora") {
+		dir = filepath.Dir(path)
+	}
+
+	tnsAdminMutex.Lock()
+	defer tnsAdminMutex.Unlock()
+	if tnsAdmin != "" {
+		if tnsAdmin != dir {
+			return fmt.Errorf("TNS_ADMIN already set to %v", tnsAdmin)
+		}
+		return nil
+	}
+
+	err := os.Setenv("TNS_ADMIN", dir)
+	if err != nil {
+		return fmt.Errorf("set TNS_ADMIN error: %v", err)
+	}
+	tnsAdmin = dir
+	return nil
+}
+
 // Commit transaction commit
 func (tx *OCI8Tx) Commit() error {
+	defer tx.freeTransaction()
 	tx.conn.inTransaction = false
+	tx.conn.transactionID = ""
+	flags := C.ub4(C.OCI_DEFAULT)
+	if tx.twoPhase {
+		flags = C.OCI_TRANS_TWOPHASE
+	}
 	if rv := C.OCITransCommit(
 		tx.conn.svc,
 		tx.conn.errHandle,
-		0,
+		flags,
 	); rv != C.OCI_SUCCESS {
 		return tx.conn.getError(rv)
 	}
+	tx.sendEvent(TxCommit)
 	return nil
 }
 
 // Rollback transaction rollback
 func (tx *OCI8Tx) Rollback() error {
+	defer tx.freeTransaction()
 	tx.conn.inTransaction = false
+	tx.conn.transactionID = ""
 	if rv := C.OCITransRollback(
 		tx.conn.svc,
 		tx.conn.errHandle,
@@ -149,20 +557,130 @@ func (tx *OCI8Tx) Rollback() error {
 	); rv != C.OCI_SUCCESS {
 		return tx.conn.getError(rv)
 	}
+	tx.sendEvent(TxRollback)
+	return nil
+}
+
+// freeTransaction detaches the transaction handle of the transaction from the service context then frees it,
+// so later transactions of the connection do not use it
+func (tx *OCI8Tx) freeTransaction() {
+	if tx.trans == nil {
+		return
+	}
+	err := tx.conn.ociAttrSet(unsafe.Pointer(tx.conn.svc), C.OCI_HTYPE_SVCCTX, nil, 0, C.OCI_ATTR_TRANS)
+	if err != nil {
+		tx.conn.logger.Print("detach transaction handle error: ", err)
+	}
+	C.OCIHandleFree(tx.trans, C.OCI_HTYPE_TRANS)
+	tx.trans = nil
+}
+
+// SetEndToEndInfo sets the session end-to-end tracing attributes of the transaction connection, see OCI8Conn SetEndToEndInfo
+func (tx *OCI8Tx) SetEndToEndInfo(module string, action string, clientInfo string) error {
+	return tx.conn.SetEndToEndInfo(module, action, clientInfo)
+}
+
+// sendEvent calls the connection transaction listener with the event
+func (tx *OCI8Tx) sendEvent(eventType TxEventType) {
+	if tx.conn.txListener != nil {
+		tx.conn.txListener(TxEvent{Type: eventType, TransactionID: tx.id, Timestamp: time.Now()})
+	}
+}
+
+// transactionBranchTimeout is the seconds a transaction branch started by SetTransactionName can be inactive
+// before it is automatically terminated by the server
+const transactionBranchTimeout = 60
+
+// SetTransactionName sets the global transaction id (XID) for Oracle XA distributed transactions
+// then starts the transaction branch. It must be called before any statements are run in the transaction
+// and the connection isolation must be DEFAULT.
+// The gtrid is the global transaction id and bqual is the branch qualifier, both up to 64 bytes.
+// The transaction branch is terminated by the server if inactive for more than transactionBranchTimeout, 60 seconds.
+func (tx *OCI8Tx) SetTransactionName(gtrid string, bqual string, fmtID int) error {
+	if len(gtrid) < 1 || len(gtrid) > C.MAXGTRIDSIZE {
+		return fmt.Errorf("invalid gtrid length: %v", len(gtrid))
+	}
+	if len(bqual) > C.MAXBQUALSIZE {
+		return fmt.Errorf("invalid bqual length: %v", len(bqual))
+	}
+
+	if tx.trans == nil {
+		// transaction handle
+		trans, _, err := tx.conn.ociHandleAlloc(C.OCI_HTYPE_TRANS, 0)
+		if err != nil {
+			return fmt.Errorf("allocate transaction handle error: %v", err)
+		}
+
+		// sets the transaction context attribute of the service context
+		err = tx.conn.ociAttrSet(unsafe.Pointer(tx.conn.svc), C.OCI_HTYPE_SVCCTX, *trans, 0, C.OCI_ATTR_TRANS)
+		if err != nil {
+			C.OCIHandleFree(*trans, C.OCI_HTYPE_TRANS)
+			return err
+		}
+		tx.trans = *trans
+	}
+
+	var xid C.XID
+	xid.formatID = C.long(fmtID)
+	xid.gtrid_length = C.long(len(gtrid))
+	xid.bqual_length = C.long(len(bqual))
+	data := (*[C.XIDDATASIZE]byte)(unsafe.Pointer(&xid.data[0]))
+	copy(data[:], gtrid)
+	copy(data[len(gtrid):], bqual)
+
+	// sets the global transaction id attribute of the transaction handle
+	err := tx.conn.ociAttrSet(tx.trans, C.OCI_HTYPE_TRANS, unsafe.Pointer(&xid), C.ub4(unsafe.Sizeof(xid)), C.OCI_ATTR_XID)
+	if err != nil {
+		return err
+	}
+
+	if rv := C.OCITransStart(
+		tx.conn.svc,
+		tx.conn.errHandle,
+		transactionBranchTimeout, // seconds the transaction can be inactive before it is automatically terminated by the server
+		C.OCI_TRANS_NEW,          // start a new transaction branch
+	); rv != C.OCI_SUCCESS {
+		return tx.conn.getError(rv)
+	}
+
+	return nil
+}
+
+// Prepare prepares the transaction for a two-phase commit.
+// A read-only transaction branch is completed by prepare and the following Commit does nothing.
+func (tx *OCI8Tx) Prepare() error {
+	rv := C.OCITransPrepare(
+		tx.conn.svc,
+		tx.conn.errHandle,
+		0,
+	)
+	if rv == C.OCI_SUCCESS_WITH_INFO {
+		// ORA-24767: transaction branch prepare returns read-only
+		return nil
+	}
+	if rv != C.OCI_SUCCESS {
+		return tx.conn.getError(rv)
+	}
+	tx.twoPhase = true
 	return nil
 }
 
 // Open opens a new database connection
 func (oci8Driver *OCI8DriverStruct) Open(dsnString string) (driver.Conn, error) {
-	var err error
-	var dsn *DSN
-	if dsn, err = ParseDSN(dsnString); err != nil {
+	dsn, err := ParseDSN(oci8Driver.preprocessDSN(dsnString))
+	if err != nil {
 		return nil, err
 	}
+	return oci8Driver.open(dsn)
+}
 
+// open opens a new database connection with the parsed DSN
+func (oci8Driver *OCI8DriverStruct) open(dsn *DSN) (driver.Conn, error) {
+	var err error
 	conn := OCI8Conn{
 		operationMode: dsn.operationMode,
 		logger:        oci8Driver.Logger,
+		oci8Driver:    oci8Driver,
 	}
 	if conn.logger == nil {
 		conn.logger = log.New(ioutil.Discard, "", 0)
@@ -178,17 +696,19 @@ func (oci8Driver *OCI8DriverStruct) Open(dsnString string) (driver.Conn, error)
 		charset = defaultCharset
 	}
 
+	envMode := dsn.envMode()
+
 	result = C.OCIEnvNlsCreate(
-		envPP,          // pointer to a handle to the environment
-		C.OCI_THREADED, // environment mode: https://docs.oracle.com/cd/B28359_01/appdev.111/b28395/oci16rel001.htm#LNOCI87683
-		nil,            // Specifies the user-defined context for the memory callback routines.
-		nil,            // Specifies the user-defined memory allocation function. If mode is OCI_THREADED, this memory allocation routine must be thread-safe.
-		nil,            // Specifies the user-defined memory re-allocation function. If the mode is OCI_THREADED, this memory allocation routine must be thread safe.
-		nil,            // Specifies the user-defined memory free function. If mode is OCI_THREADED, this memory free routine must be thread-safe.
-		0,              // Specifies the amount of user memory to be allocated for the duration of the environment.
-		nil,            // Returns a pointer to the user memory of size xtramemsz allocated by the call for the user.
-		charset,        // The client-side character set for the current environment handle. If it is 0, the NLS_LANG setting is used.
-		charset,        // The client-side national character set for the current environment handle. If it is 0, NLS_NCHAR setting is used.
+		envPP,   // pointer to a handle to the environment
+		envMode, // environment mode: https://docs.oracle.com/cd/B28359_01/appdev.111/b28395/oci16rel001.htm#LNOCI87683. OCI_OBJECT is needed to read object types.
+		nil,     // Specifies the user-defined context for the memory callback routines.
+		nil,     // Specifies the user-defined memory allocation function. If mode is OCI_THREADED, this memory allocation routine must be thread-safe.
+		nil,     // Specifies the user-defined memory re-allocation function. If the mode is OCI_THREADED, this memory allocation routine must be thread safe.
+		nil,     // Specifies the user-defined memory free function. If mode is OCI_THREADED, this memory free routine must be thread-safe.
+		0,       // Specifies the amount of user memory to be allocated for the duration of the environment.
+		nil,     // Returns a pointer to the user memory of size xtramemsz allocated by the call for the user.
+		charset, // The client-side character set for the current environment handle. If it is 0, the NLS_LANG setting is used.
+		charset, // The client-side national character set for the current environment handle. If it is 0, NLS_NCHAR setting is used.
 	)
 	if result != C.OCI_SUCCESS {
 		return nil, errors.New("OCIEnvNlsCreate error")
@@ -201,6 +721,16 @@ func (oci8Driver *OCI8DriverStruct) Open(dsnString string) (driver.Conn, error)
 	var doneLogon bool
 	defer func(errP *error) {
 		if *errP != nil {
+			if conn.sessionGet {
+				C.OCISessionRelease(
+					conn.svc,
+					conn.errHandle,
+					nil,
+					0,
+					C.OCI_DEFAULT,
+				)
+				conn.svc = nil
+			}
 			if doneSessionBegin {
 				C.OCISessionEnd(
 					conn.svc,
@@ -259,14 +789,27 @@ func (oci8Driver *OCI8DriverStruct) Open(dsnString string) (driver.Conn, error)
 	}
 	conn.errHandle = (*C.OCIError)(*handle)
 
-	connectString := cString(dsn.Connect)
+	connect := dsn.Connect
+	if dsn.keepAlive > 0 {
+		connect = keepAliveConnect(connect, dsn.keepAlive)
+	}
+	if dsn.networkCompression != "" {
+		connect = addConnectParameter(connect, "COMPRESSION", dsn.networkCompression)
+	}
+	connectString := cString(connect)
 	defer C.free(unsafe.Pointer(connectString))
 	username := cString(dsn.Username)
 	defer C.free(unsafe.Pointer(username))
 	password := cString(dsn.Password)
 	defer C.free(unsafe.Pointer(password))
 
-	if useOCISessionBegin {
+	if dsn.shardingKey != nil {
+		err = conn.shardingSessionGet(dsn, connect)
+		if err != nil {
+			return nil, err
+		}
+
+	} else if useOCISessionBegin {
 		// server handle
 		handle, _, err = conn.ociHandleAlloc(C.OCI_HTYPE_SERVER, 0)
 		if err != nil {
@@ -274,7 +817,7 @@ func (oci8Driver *OCI8DriverStruct) Open(dsnString string) (driver.Conn, error)
 		}
 		conn.srv = (*C.OCIServer)(*handle)
 
-		if len(dsn.Connect) < 1 {
+		if len(connect) < 1 {
 			result = C.OCIServerAttach(
 				conn.srv,       // uninitialized server handle, which gets initialized by this call. Passing in an initialized server handle causes an error.
 				conn.errHandle, // error handle
@@ -284,11 +827,11 @@ func (oci8Driver *OCI8DriverStruct) Open(dsnString string) (driver.Conn, error)
 			)
 		} else {
 			result = C.OCIServerAttach(
-				conn.srv,                // uninitialized server handle, which gets initialized by this call. Passing in an initialized server handle causes an error.
-				conn.errHandle,          // error handle
-				connectString,           // connect string or a service point
-				C.sb4(len(dsn.Connect)), // length of the database server
-				C.OCI_DEFAULT,           // mode of operation: OCI_DEFAULT or OCI_CPOOL
+				conn.srv,            // uninitialized server handle, which gets initialized by this call. Passing in an initialized server handle causes an error.
+				conn.errHandle,      // error handle
+				connectString,       // connect string or a service point
+				C.sb4(len(connect)), // length of the database server
+				C.OCI_DEFAULT,       // mode of operation: OCI_DEFAULT or OCI_CPOOL
 			)
 		}
 		if result != C.OCI_SUCCESS {
@@ -317,8 +860,9 @@ func (oci8Driver *OCI8DriverStruct) Open(dsnString string) (driver.Conn, error)
 		}
 		conn.usrSession = (*C.OCISession)(*handle)
 
+		// OCI_CRED_EXT uses operating system authentication, or the wallet credentials of the connect string with password_store wallet
 		credentialType := C.ub4(C.OCI_CRED_EXT)
-		if len(dsn.Username) > 0 {
+		if len(dsn.Username) > 0 && !dsn.passwordStoreWallet {
 			// specifies a username to use for authentication
 			err = conn.ociAttrSet(unsafe.Pointer(conn.usrSession), C.OCI_HTYPE_SESSION, unsafe.Pointer(username), C.ub4(len(dsn.Username)), C.OCI_ATTR_USERNAME)
 			if err != nil {
@@ -343,7 +887,15 @@ func (oci8Driver *OCI8DriverStruct) Open(dsnString string) (driver.Conn, error)
 		)
 		if result != C.OCI_SUCCESS && result != C.OCI_SUCCESS_WITH_INFO {
 			err = conn.getError(result)
-			return nil, err
+			if dsn.newPassword == "" || !isPasswordExpiredError(err) {
+				return nil, err
+			}
+			err = conn.changeExpiredPassword(dsn.Username, dsn.Password, dsn.newPassword)
+			if err != nil {
+				return nil, err
+			}
+			dsn.Password = dsn.newPassword
+			dsn.newPassword = ""
 		}
 		doneSessionBegin = true
 
@@ -366,7 +918,7 @@ func (oci8Driver *OCI8DriverStruct) Open(dsnString string) (driver.Conn, error)
 			password,                 // user's password. Must be in the encoding specified by the charset parameter of a previous call to OCIEnvNlsCreate().
 			C.ub4(len(dsn.Password)), // length of password, in number of bytes, regardless of the encoding.
 			connectString,            // name of the database to connect to. Must be in the encoding specified by the charset parameter of a previous call to OCIEnvNlsCreate().
-			C.ub4(len(dsn.Connect)),  // length of dbname, in number of bytes, regardless of the encoding.
+			C.ub4(len(connect)),      // length of dbname, in number of bytes, regardless of the encoding.
 		)
 		if result != C.OCI_SUCCESS && result != C.OCI_SUCCESS_WITH_INFO {
 			err = conn.getError(result)
@@ -377,23 +929,190 @@ func (oci8Driver *OCI8DriverStruct) Open(dsnString string) (driver.Conn, error)
 
 	}
 
+	conn.dsn = *dsn
 	conn.transactionMode = dsn.transactionMode
 	conn.prefetchRows = dsn.prefetchRows
 	conn.prefetchMemory = dsn.prefetchMemory
+	conn.lobInlineThreshold = dsn.lobInlineThreshold
 	conn.timeLocation = dsn.timeLocation
 	conn.enableQMPlaceholders = dsn.enableQMPlaceholders
+	conn.maxRows = dsn.maxRows
+	conn.autoReturnRowid = dsn.autoReturnRowid
+	conn.normalizeUnicode = dsn.normalizeUnicode
+	conn.unicodeNormalization = dsn.unicodeNormalization
+	conn.smartAlloc = dsn.smartAlloc
+	conn.objectAsJSON = dsn.objectAsJSON
+	conn.xmlAsCLOB = dsn.xmlAsCLOB
+	conn.retryOnErrors = dsn.retryOnErrors
+	conn.retryCount = dsn.retryCount
+	if conn.retryCount == 0 {
+		conn.retryCount = 1
+	}
+	conn.retryDelay = dsn.retryDelay
+	conn.batchSize = dsn.batchSize
+	conn.maxParallel = dsn.maxParallel
+
+	if dsn.lockTimeout > 0 {
+		err = conn.SetLockTimeout(context.Background(), dsn.lockTimeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if dsn.sessionTimeZone != "" {
+		_, err = conn.execScriptStatement(context.Background(), "alter session set TIME_ZONE = '"+dsn.sessionTimeZone+"'")
+		if err != nil {
+			return nil, fmt.Errorf("set session time zone error: %v", err)
+		}
+	}
+
+	if dsn.defaultEdition != "" {
+		err = conn.SetEdition(dsn.defaultEdition)
+		if err != nil {
+			return nil, fmt.Errorf("set edition error: %v", err)
+		}
+	}
+
+	if dsn.lobPrefetchSize > 0 {
+		err = conn.setDefaultLOBPrefetchSize(dsn.lobPrefetchSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if dsn.dbmsOutputBuffer > 0 {
+		err = conn.EnableDBMSOutput(dsn.dbmsOutputBuffer)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return &conn, nil
 }
 
-// GetLastInsertId returns rowid from LastInsertId
-func GetLastInsertId(id int64) string {
-	return *(*string)(unsafe.Pointer(uintptr(id)))
+// isSessionTimeZone returns true if the time zone only has the characters allowed in a time zone offset or region name
+func isSessionTimeZone(timeZone string) bool {
+	if timeZone == "" {
+		return false
+	}
+	for _, r := range timeZone {
+		if !(r == '+' || r == '-' || r == ':' || r == '/' || r == '_' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// lockTimeoutQuery returns the alter session statement that sets DDL_LOCK_TIMEOUT, rounded up to whole seconds
+func lockTimeoutQuery(lockTimeout time.Duration) string {
+	seconds := int64((lockTimeout + time.Second - 1) / time.Second)
+	return "alter session set DDL_LOCK_TIMEOUT = " + strconv.FormatInt(seconds, 10)
 }
 
-// LastInsertId returns last inserted ID
+// easyConnectServerType returns the upper cased server of an Easy Connect string, [//]host[:port]/service_name[:server][/instance_name].
+// Returns empty if the connect string has no server or is a connect descriptor or tnsnames.ora alias.
+func easyConnectServerType(connect string) (string, error) {
+	if strings.HasPrefix(connect, "(") {
+		return "", nil
+	}
+
+	if i := strings.Index(connect, "?"); i >= 0 {
+		connect = connect[:i]
+	}
+	if i := strings.Index(connect, "://"); i >= 0 {
+		// protocol, like tcps://
+		connect = connect[i+len("://"):]
+	}
+	connect = strings.TrimPrefix(connect, "//")
+
+	// skip IPv6 addresses in brackets, which have colons
+	hostEnd := 0
+	if i := strings.LastIndex(connect, "]"); i >= 0 {
+		hostEnd = i
+	}
+	i := strings.Index(connect[hostEnd:], "/")
+	if i < 0 {
+		return "", nil
+	}
+	service := connect[hostEnd+i+1:]
+	if i = strings.Index(service, "/"); i >= 0 {
+		// instance name
+		service = service[:i]
+	}
+	i = strings.Index(service, ":")
+	if i < 0 {
+		return "", nil
+	}
+
+	serverType := strings.ToUpper(service[i+1:])
+	switch serverType {
+	case "DEDICATED", "SHARED", "POOLED":
+		return serverType, nil
+	}
+	return "", fmt.Errorf("invalid server type: %v", service[i+1:])
+}
+
+// keepAliveConnect adds Oracle Net EXPIRE_TIME to the connect string.
+// A connect descriptor gets an EXPIRE_TIME parameter, otherwise Easy Connect Plus expire_time is used.
+func keepAliveConnect(connect string, keepAlive time.Duration) string {
+	minutes := int64((keepAlive + time.Minute - 1) / time.Minute)
+	return addConnectParameter(connect, "EXPIRE_TIME", strconv.FormatInt(minutes, 10))
+}
+
+// descriptionRegexp matches the start of a connect descriptor DESCRIPTION, which can have whitespace around the equal sign
+var descriptionRegexp = regexp.MustCompile(`(?i)\(\s*DESCRIPTION\s*=`)
+
+// addConnectParameter adds an Oracle Net DESCRIPTION parameter to the connect string.
+// A connect descriptor gets a (NAME=value) parameter, an Easy Connect string gets an Easy Connect Plus name=value parameter.
+// A connect descriptor without a DESCRIPTION and a tnsnames.ora alias are returned unchanged.
+func addConnectParameter(connect string, name string, value string) string {
+	if strings.HasPrefix(strings.TrimLeft(connect, " \t\r\n"), "(") {
+		if loc := descriptionRegexp.FindStringIndex(connect); loc != nil {
+			i := loc[1]
+			return connect[:i] + "(" + name + "=" + value + ")" + connect[i:]
+		}
+		return connect
+	}
+	if isTNSAlias(connect) {
+		return connect
+	}
+
+	if strings.Contains(connect, "?") {
+		return connect + "&" + strings.ToLower(name) + "=" + value
+	}
+	return connect + "?" + strings.ToLower(name) + "=" + value
+}
+
+// isTNSAlias returns true if the connect string is a tnsnames.ora alias, not a connect descriptor or an Easy Connect string,
+// which has a / or : like host/service or host:port
+func isTNSAlias(connect string) bool {
+	if strings.HasPrefix(strings.TrimLeft(connect, " \t\r\n"), "(") {
+		return false
+	}
+	return !strings.ContainsAny(connect, "/:")
+}
+
+// LastInsertId is not supported because Oracle does not have auto increment ids, it returns -1 and ErrLastInsertIdDeprecated.
+// Use LastInsertRowid or returning rowid into instead.
 func (result *OCI8Result) LastInsertId() (int64, error) {
-	return int64(uintptr(unsafe.Pointer(&result.rowid))), result.rowidErr
+	return -1, ErrLastInsertIdDeprecated
+}
+
+// LastInsertRowid returns the rowid of the last row inserted, updated, or deleted by the statement
+func (result *OCI8Result) LastInsertRowid() (string, error) {
+	return result.rowid, result.rowidErr
+}
+
+// InsertedRowID returns the ROWID of the row inserted by an INSERT statement with RETURNING ROWID INTO :oci8_rowid
+// added by the autoreturn_rowid DSN parameter, otherwise returns ErrNoRowid
+func (result *OCI8Result) InsertedRowID() (string, error) {
+	return result.returnRowid, result.returnRowidErr
+}
+
+// CallTime returns the server time of the statement execute.
+// Call time stats need to be enabled on the connection with EnableCallTimeStats.
+func (result *OCI8Result) CallTime() (time.Duration, error) {
+	return result.callTime, result.callTimeErr
 }
 
 // RowsAffected returns rows affected
@@ -402,34 +1121,148 @@ func (result *OCI8Result) RowsAffected() (int64, error) {
 }
 
 // converts "?" characters to  :1, :2, ... :n
-func placeholders(sql string) string {
+// "?" characters in quoted strings, quoted identifiers, and comments are not converted.
+// SQL that already has Oracle binds, like :name or :1, is returned unchanged. If it also has "?" characters,
+// which is mixed mode, the returned bool is true.
+func placeholders(sql string) (string, bool) {
+	var buffer bytes.Buffer
 	n := 0
-	return phre.ReplaceAllStringFunc(sql, func(string) string {
-		n++
-		return ":" + strconv.Itoa(n)
-	})
+	named := false
+	for i := 0; i < len(sql); i++ {
+		end := -1
+		switch {
+		case sql[i] == '?':
+			n++
+			buffer.WriteString(":" + strconv.Itoa(n))
+			continue
+		case sql[i] == '\'' || sql[i] == '"':
+			if j := strings.IndexByte(sql[i+1:], sql[i]); j >= 0 {
+				end = i + j + 2
+			}
+		case strings.HasPrefix(sql[i:], "--"):
+			if j := strings.IndexByte(sql[i:], '\n'); j >= 0 {
+				end = i + j
+			}
+		case strings.HasPrefix(sql[i:], "/*"):
+			if j := strings.Index(sql[i+2:], "*/"); j >= 0 {
+				end = i + j + 4
+			}
+		case sql[i] == ':' && i+1 < len(sql) && isBindNameByte(sql[i+1]):
+			// Oracle bind, like :name or :1, but not PL/SQL assignment :=
+			named = true
+			buffer.WriteByte(sql[i])
+			continue
+		default:
+			buffer.WriteByte(sql[i])
+			continue
+		}
+		if end < 0 {
+			// unterminated quote or comment
+			end = len(sql)
+		}
+		buffer.WriteString(sql[i:end])
+		i = end - 1
+	}
+	if named {
+		return sql, n > 0
+	}
+	return buffer.String(), false
 }
 
-func timezoneToLocation(hour int64, minute int64) *time.Location {
-	if minute != 0 || hour > 14 || hour < -12 {
-		// create location with FixedZone
-		var name string
-		if hour < 0 {
-			name = strconv.FormatInt(hour, 10) + ":"
-		} else {
-			name = "+" + strconv.FormatInt(hour, 10) + ":"
-		}
-		if minute == 0 {
-			name += "00"
-		} else {
-			if minute < 10 {
-				name += "0"
+// sqlWords returns the upper case words of the SQL that are not in quoted strings, quoted identifiers, and comments,
+// which are skipped like placeholders does. Also returns the end of the SQL without trailing comments, white space, and semicolons.
+func sqlWords(sql string) ([]string, int) {
+	var words []string
+	end := 0
+	for i := 0; i < len(sql); i++ {
+		switch {
+		case sql[i] == '\'' || sql[i] == '"':
+			j := strings.IndexByte(sql[i+1:], sql[i])
+			if j < 0 {
+				// unterminated quote
+				return words, len(sql)
+			}
+			i += j + 1
+			end = i + 1
+		case strings.HasPrefix(sql[i:], "--"):
+			j := strings.IndexByte(sql[i:], '\n')
+			if j < 0 {
+				return words, end
+			}
+			i += j
+		case strings.HasPrefix(sql[i:], "/*"):
+			j := strings.Index(sql[i+2:], "*/")
+			if j < 0 {
+				return words, end
+			}
+			i += j + 3
+		case isWordByte(sql[i]):
+			j := i + 1
+			for j < len(sql) && isWordByte(sql[j]) {
+				j++
 			}
-			name += strconv.FormatInt(minute, 10)
+			words = append(words, strings.ToUpper(sql[i:j]))
+			i = j - 1
+			end = j
+		case sql[i] == ' ' || sql[i] == '\t' || sql[i] == '\r' || sql[i] == '\n' || sql[i] == ';':
+		default:
+			end = i + 1
 		}
-		return time.FixedZone(name, (3600*int(hour))+(60*int(minute)))
+	}
+	return words, end
+}
+
+// isWordByte returns true if the byte can be in an unquoted SQL keyword or identifier
+func isWordByte(c byte) bool {
+	return c == '_' || c == '$' || c == '#' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// isBindNameByte returns true if the byte can start an Oracle bind name or number
+func isBindNameByte(c byte) bool {
+	return c == '_' || c == '"' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// truncateFractionalSeconds truncates the time to the fractional seconds precision, the number of decimal digits of the seconds
+func truncateFractionalSeconds(aTime time.Time, precision int) time.Time {
+	if precision >= 9 {
+		return aTime
+	}
+	duration := time.Nanosecond
+	for i := precision; i < 9; i++ {
+		duration *= 10
+	}
+	return aTime.Truncate(duration)
+}
+
+func timezoneToLocation(hour int64, minute int64) *time.Location {
+	if minute != 0 || hour > 14 || hour < -12 {
+		return timezoneFixedZone(hour, minute)
 	}
 
 	// use location from timeLocations cache
 	return timeLocations[12+hour]
 }
+
+// timezoneFixedZone returns a FixedZone location of the time zone offset, named like +5:45 or -3:30.
+// For negative offsets, both the hour and minute are negative, or the hour is 0.
+func timezoneFixedZone(hour int64, minute int64) *time.Location {
+	var name string
+	if hour < 0 || minute < 0 {
+		name = "-" + strconv.FormatInt(abs64(hour), 10) + ":"
+	} else {
+		name = "+" + strconv.FormatInt(hour, 10) + ":"
+	}
+	if abs64(minute) < 10 {
+		name += "0"
+	}
+	name += strconv.FormatInt(abs64(minute), 10)
+	return time.FixedZone(name, (3600*int(hour))+(60*int(minute)))
+}
+
+// abs64 returns the absolute value of the int64
+func abs64(value int64) int64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
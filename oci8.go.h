@@ -1,2 +1,7 @@
 #include <oci.h>
 #include <stdlib.h>
+
+// SQLT_JSON is the JSON data type of Oracle 21c, not defined in the headers of older Oracle clients
+#ifndef SQLT_JSON
+#define SQLT_JSON 119
+#endif
@@ -3775,3 +3775,48 @@ func TestDestructiveNumberColumnTypes(t *testing.T) {
 	}
 
 }
+
+// TestDestructiveNumberUnconstrained tests scanning an unconstrained NUMBER column, precision 0 and scale -127, into float64 and string
+func TestDestructiveNumberUnconstrained(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "NUMBER_UNCONSTR_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A NUMBER )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+
+	defer testDropTable(t, tableName)
+
+	err = testExecRows(t, "insert into "+tableName+" ( A ) values (:1)",
+		[][]interface{}{
+			{123.456},
+		})
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+
+	var aFloat float64
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	err = TestDB.QueryRowContext(ctx, "select A from "+tableName).Scan(&aFloat)
+	cancel()
+	if err != nil {
+		t.Fatal("scan float64 error:", err)
+	}
+	if aFloat != 123.456 {
+		t.Errorf("float64 - received: %v - expected: %v", aFloat, 123.456)
+	}
+
+	var aString string
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	err = TestDB.QueryRowContext(ctx, "select A from "+tableName).Scan(&aString)
+	cancel()
+	if err != nil {
+		t.Fatal("scan string error:", err)
+	}
+	if aString != "123.456" {
+		t.Errorf("string - received: %v - expected: %v", aString, "123.456")
+	}
+}
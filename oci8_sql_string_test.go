@@ -3400,3 +3400,90 @@ func TestDestructiveStringColumnTypes(t *testing.T) {
 	}
 
 }
+
+// TestDestructiveStringExecuteImmediate checks CLOB binds with EXECUTE IMMEDIATE
+func TestDestructiveStringExecuteImmediate(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "EXEC_IMMEDIATE_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER, B CLOB )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+
+	defer testDropTable(t, tableName)
+
+	query := "begin execute immediate :stmt using :arg1, :arg2; end;"
+	insert := "insert into " + tableName + " ( A, B ) values ( :1, :2 )"
+	err = testExecRows(t, query, [][]interface{}{
+		{insert, 1, "a"},
+		{insert, 2, strings.Repeat("b", 4001)},
+		{insert, 3, strings.Repeat("c", 32767)},
+		{insert, 4, strings.Repeat("d", 70000)},
+	})
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+
+	// direct insert uses the same CLOB bind
+	err = testExec(t, insert, []interface{}{5, strings.Repeat("e", 10000)})
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+
+	queryResults := testQueryResults{
+		query: "select A, B from " + tableName + " order by A",
+		queryResults: []testQueryResult{
+			{
+				results: [][]interface{}{
+					{int64(1), "a"},
+					{int64(2), strings.Repeat("b", 4001)},
+					{int64(3), strings.Repeat("c", 32767)},
+					{int64(4), strings.Repeat("d", 70000)},
+					{int64(5), strings.Repeat("e", 10000)},
+				},
+			},
+		},
+	}
+	testRunQueryResults(t, queryResults)
+}
+
+// TestStringBindType checks strings up to 32767 bytes are bound as VARCHAR2 and larger strings as CLOB
+func TestStringBindType(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	query := `
+declare
+	procedure bind_type(value in varchar2, data_type out varchar2) is begin data_type := 'VARCHAR2'; end;
+	procedure bind_type(value in clob, data_type out varchar2) is begin data_type := 'CLOB'; end;
+begin
+	bind_type(:1, :2);
+end;`
+
+	tests := []struct {
+		value    string
+		dataType string
+	}{
+		{strings.Repeat("a", 4000), "VARCHAR2"},
+		{strings.Repeat("b", 4001), "VARCHAR2"},
+		{strings.Repeat("c", 32767), "VARCHAR2"},
+		{strings.Repeat("d", 32768), "CLOB"},
+	}
+
+	for _, test := range tests {
+		var dataType string
+		ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+		_, err := TestDB.ExecContext(ctx, query, test.value, sql.Out{Dest: &dataType})
+		cancel()
+		if err != nil {
+			t.Fatal("exec error:", err)
+		}
+		if dataType != test.dataType {
+			t.Errorf("bind type of %v bytes - received: %v - expected: %v", len(test.value), dataType, test.dataType)
+		}
+	}
+}
@@ -1,10 +1,18 @@
 package oci8
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"reflect"
 	"strconv"
@@ -12,12 +20,11 @@ import (
 	"sync"
 	"testing"
 	"time"
+	"unsafe"
 )
 
-// testGetDB connects to the test database and returns the database connection
-func testGetDB(params string) *sql.DB {
-	OCI8Driver.Logger = log.New(os.Stderr, "oci8 ", log.Ldate|log.Ltime|log.LUTC|log.Llongfile)
-
+// testGetDSN returns the test database DSN with params added
+func testGetDSN(params string) string {
 	var openString string
 	// [username/[password]@]host[:port][/service_name][?param1=value1&...&paramN=valueN]
 	if len(TestUsername) > 0 {
@@ -27,9 +34,14 @@ func testGetDB(params string) *sql.DB {
 			openString = TestUsername + "@"
 		}
 	}
-	openString += TestHostValid + params
+	return openString + TestHostValid + params
+}
+
+// testGetDB connects to the test database and returns the database connection
+func testGetDB(params string) *sql.DB {
+	OCI8Driver.Logger = log.New(os.Stderr, "oci8 ", log.Ldate|log.Ltime|log.LUTC|log.Llongfile)
 
-	db, err := sql.Open("oci8", openString)
+	db, err := sql.Open("oci8", testGetDSN(params))
 	if err != nil {
 		fmt.Println("Open error:", err)
 		return nil
@@ -51,6 +63,15 @@ func testGetDB(params string) *sql.DB {
 	return db
 }
 
+// testGetConn opens a driver connection to the test database, caller must close it
+func testGetConn(t *testing.T, params string) *OCI8Conn {
+	conn, err := OCI8Driver.Open(testGetDSN(params))
+	if err != nil {
+		t.Fatal("open error:", err)
+	}
+	return conn.(*OCI8Conn)
+}
+
 func testDropTable(t *testing.T, tableName string) {
 	err := testExec(t, "drop table "+tableName, nil)
 	if err != nil {
@@ -494,6 +515,42 @@ end;
 	}
 }
 
+// TestCancelExec checks that CancelExec interrupts a running statement
+func TestCancelExec(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	stmt, err := conn.PrepareContext(ctx, "begin SYS.DBMS_LOCK.SLEEP(10); end;")
+	if err != nil {
+		t.Fatal("prepare error:", err)
+	}
+	defer stmt.Close()
+
+	errChan := make(chan error, 1)
+	go func() {
+		_, err := stmt.(*OCI8Stmt).ExecContext(ctx, nil)
+		errChan <- err
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+	err = conn.CancelExec()
+	if err != nil {
+		t.Fatal("cancel exec error:", err)
+	}
+
+	err = <-errChan
+	expected := "ORA-01013"
+	if err == nil || len(err.Error()) < len(expected) || err.Error()[:len(expected)] != expected {
+		t.Fatalf("stmt exec - expected: %v - received: %v", expected, err)
+	}
+}
+
 // TestDestructiveTransaction tests a transaction
 func TestDestructiveTransaction(t *testing.T) {
 	if TestDisableDatabase || TestDisableDestructive {
@@ -847,34 +904,44 @@ func TestInsertRowid(t *testing.T) {
 	}()
 
 	// insert into table
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
 	query = "insert into " + tableName + " ( A ) values (:1) returning rowid into :rowid2"
 	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
-	stmt, err = TestDB.PrepareContext(ctx, query)
+	driverStmt, err := conn.PrepareContext(ctx, query)
 	cancel()
 	if err != nil {
 		t.Fatal("prepare error:", err)
 	}
 
 	rowids := make([]string, 3)
-	var result sql.Result
+	var result driver.Result
 	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
-	result, err = stmt.ExecContext(ctx, 1, sql.Named("rowid2", sql.Out{Dest: &rowids[0]}))
+	result, err = driverStmt.(*OCI8Stmt).ExecContext(ctx, []driver.NamedValue{
+		{Ordinal: 1, Value: 1},
+		{Name: "rowid2", Ordinal: 2, Value: sql.Out{Dest: &rowids[0]}},
+	})
 	cancel()
 	if err != nil {
-		stmt.Close()
+		driverStmt.Close()
 		t.Fatal("exec error:", err)
 	}
 
 	var id int64
 	id, err = result.LastInsertId()
-	if err != nil {
-		stmt.Close()
-		t.Fatal("exec error:", err)
+	if id != -1 || err != ErrLastInsertIdDeprecated {
+		driverStmt.Close()
+		t.Fatalf("last insert id - received: %v, %v - expected: %v, %v", id, err, -1, ErrLastInsertIdDeprecated)
 	}
 
-	rowids[1] = GetLastInsertId(id)
+	rowids[1], err = result.(*OCI8Result).LastInsertRowid()
+	if err != nil {
+		driverStmt.Close()
+		t.Fatal("last insert rowid error:", err)
+	}
 
-	err = stmt.Close()
+	err = driverStmt.Close()
 	if err != nil {
 		t.Fatal("stmt close error", err)
 	}
@@ -1139,6 +1206,4599 @@ func TestQuestionMark(t *testing.T) {
 	}
 }
 
+// TestDestructiveMultiset tests MULTISET EXCEPT and MULTISET INTERSECT with question mark placeholders
+func TestDestructiveMultiset(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	typeName := "MULTISET_" + TestTimeString
+	testExecQuery(t, "create type "+typeName+" as table of number", nil)
+	defer testExecQuery(t, "drop type "+typeName, nil)
+
+	db := testGetDB("?questionph=true")
+	if db == nil {
+		t.Fatal("db is null")
+	}
+	defer db.Close()
+
+	queries := []struct {
+		query    string
+		expected int64
+	}{
+		{query: "select cardinality(" + typeName + "(1, 2, 2, 3) multiset except distinct " + typeName + "(?)) from dual", expected: 2},
+		{query: "select cardinality(" + typeName + "(1, 2, 2, 3) multiset intersect " + typeName + "(?, 3)) from dual", expected: 2},
+	}
+
+	for _, query := range queries {
+		var count int64
+		ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+		err := db.QueryRowContext(ctx, query.query, 2).Scan(&count)
+		cancel()
+		if err != nil {
+			t.Fatalf("query %v error: %v", query.query, err)
+		}
+		if count != query.expected {
+			t.Errorf("query %v - received: %v - expected: %v", query.query, count, query.expected)
+		}
+	}
+}
+
+// TestDestructiveAnyType tests describing the type of an ANYDATA value
+func TestDestructiveAnyType(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	typeName := "ANYTYPE_" + TestTimeString
+	testExecQuery(t, "create type "+typeName+" as object (ID number(10), NAME varchar2(30))", nil)
+	defer testExecQuery(t, "drop type "+typeName, nil)
+
+	var fullName string
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	err := TestDB.QueryRowContext(ctx, "select anydata.ConvertObject("+typeName+"(1, 'a')).GetTypeName() from dual").Scan(&fullName)
+	cancel()
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	anyType, err := conn.DescribeAnyType(ctx, fullName)
+	cancel()
+	if err != nil {
+		t.Fatal("describe error:", err)
+	}
+
+	typeCode, err := anyType.GetTypeCode()
+	if err != nil {
+		t.Fatal("type code error:", err)
+	}
+	if typeCode != 108 { // OCI_TYPECODE_OBJECT
+		t.Fatalf("type code - received: %v - expected: %v", typeCode, 108)
+	}
+	if anyType.NumAttrs() != 2 {
+		t.Fatalf("num attrs - received: %v - expected: %v", anyType.NumAttrs(), 2)
+	}
+
+	for i, expected := range []string{"ID", "NAME"} {
+		var name string
+		name, _, err = anyType.GetAttrInfo(i + 1)
+		if err != nil {
+			t.Fatal("attr info error:", err)
+		}
+		if name != expected {
+			t.Fatalf("attr name - received: %v - expected: %v", name, expected)
+		}
+	}
+
+	_, _, err = anyType.GetAttrInfo(3)
+	if err == nil {
+		t.Fatal("attr info error is nil")
+	}
+}
+
+// TestDestructiveObjectPipelined tests reading object and collection types from a pipelined table function
+func TestDestructiveObjectPipelined(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	typeName := "OBJECT_" + TestTimeString
+	tableTypeName := "OBJECT_TAB_" + TestTimeString
+	functionName := "OBJECT_FUNC_" + TestTimeString
+	testExecQuery(t, "create type "+typeName+" as object (ID number(10), NAME varchar2(30), AMOUNT number, CREATED date)", nil)
+	defer testExecQuery(t, "drop type "+typeName, nil)
+	testExecQuery(t, "create type "+tableTypeName+" as table of "+typeName, nil)
+	defer testExecQuery(t, "drop type "+tableTypeName, nil)
+	testExecQuery(t, "create function "+functionName+" return "+tableTypeName+" pipelined is begin "+
+		"pipe row("+typeName+"(1, 'a', 1.5, to_date('2006-01-02 15:04:05', 'YYYY-MM-DD HH24:MI:SS'))); "+
+		"pipe row("+typeName+"(2, null, null, null)); "+
+		"return; end;", nil)
+	defer testExecQuery(t, "drop function "+functionName, nil)
+
+	object1 := map[string]interface{}{"ID": int64(1), "NAME": "a", "AMOUNT": float64(1.5), "CREATED": time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)}
+	object2 := map[string]interface{}{"ID": int64(2), "NAME": nil, "AMOUNT": nil, "CREATED": nil}
+
+	queryResults := []testQueryResults{
+		{
+			query: "select * from table(" + functionName + "())",
+			queryResults: []testQueryResult{
+				{
+					results: [][]interface{}{
+						{int64(1), "a", float64(1.5), time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)},
+						{int64(2), nil, nil, nil},
+					},
+				},
+			},
+		},
+		{
+			query: "select value(t) from table(" + functionName + "()) t",
+			queryResults: []testQueryResult{
+				{
+					results: [][]interface{}{
+						{object1},
+						{object2},
+					},
+				},
+			},
+		},
+		{
+			query: "select " + functionName + "() from dual",
+			queryResults: []testQueryResult{
+				{
+					results: [][]interface{}{
+						{[]interface{}{object1, object2}},
+					},
+				},
+			},
+		},
+		{
+			query: "select cast(null as " + typeName + ") from dual",
+			queryResults: []testQueryResult{
+				{
+					results: [][]interface{}{
+						{nil},
+					},
+				},
+			},
+		},
+	}
+	for _, queryResult := range queryResults {
+		testRunQueryResults(t, queryResult)
+	}
+}
+
+// TestShardingKey tests connecting with a sharding key. A database that is not sharded ignores the sharding key.
+func TestShardingKey(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "?sharding_key=1&super_sharding_key=a")
+	defer conn.Close()
+
+	if !conn.sessionGet {
+		t.Fatal("session get is false")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	err := conn.Ping(ctx)
+	cancel()
+	if err != nil {
+		t.Fatal("ping error:", err)
+	}
+}
+
+// TestDestructiveLobInline tests fetching LOBs inline with lob_inline_threshold and with LOB locators
+func TestDestructiveLobInline(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "LOB_INLINE_" + TestTimeString
+	testExecQuery(t, "create table "+tableName+" ( A CLOB, B BLOB )", nil)
+	defer testDropTable(t, tableName)
+	testExecQuery(t, "insert into "+tableName+" ( A, B ) values (:1, :2)", []interface{}{"abc", []byte{1, 2, 3}})
+
+	for _, test := range []struct {
+		params   string
+		typeName string
+	}{
+		{"", "SQLT_CLOB"},
+		{"?lob_inline_threshold=1000", "SQLT_CHR"},
+	} {
+		db := testGetDB(test.params)
+		if db == nil {
+			t.Fatal("db is null")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+		rows, err := db.QueryContext(ctx, "select A, B from "+tableName)
+		if err != nil {
+			cancel()
+			db.Close()
+			t.Fatal("query error:", err)
+		}
+
+		var columnTypes []*sql.ColumnType
+		columnTypes, err = rows.ColumnTypes()
+		if err != nil {
+			rows.Close()
+			cancel()
+			db.Close()
+			t.Fatal("column types error:", err)
+		}
+		if columnTypes[0].DatabaseTypeName() != test.typeName {
+			t.Errorf("database type name - received: %v - expected: %v", columnTypes[0].DatabaseTypeName(), test.typeName)
+		}
+
+		if !rows.Next() {
+			rows.Close()
+			cancel()
+			db.Close()
+			t.Fatal("no rows:", rows.Err())
+		}
+		var aString string
+		var aBytes []byte
+		err = rows.Scan(&aString, &aBytes)
+		rows.Close()
+		cancel()
+		db.Close()
+		if err != nil {
+			t.Fatal("scan error:", err)
+		}
+
+		if aString != "abc" {
+			t.Errorf("A - received: %v - expected: %v", aString, "abc")
+		}
+		if !bytes.Equal(aBytes, []byte{1, 2, 3}) {
+			t.Errorf("B - received: %v - expected: %v", aBytes, []byte{1, 2, 3})
+		}
+	}
+}
+
+// TestLockTimeout tests the lock_timeout DSN parameter and SetLockTimeout
+func TestLockTimeout(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "?lock_timeout=7s")
+	defer conn.Close()
+
+	getLockTimeout := func() string {
+		ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+		defer cancel()
+		stmt, err := conn.PrepareContext(ctx, "select value from v$parameter where name = 'ddl_lock_timeout'")
+		if err != nil {
+			t.Fatal("prepare error:", err)
+		}
+		defer stmt.Close()
+		rows, err := stmt.(*OCI8Stmt).query(ctx, nil)
+		if err != nil {
+			if strings.Contains(err.Error(), "ORA-00942") {
+				t.Skip("no access to v$parameter")
+			}
+			t.Fatal("query error:", err)
+		}
+		defer rows.Close()
+		dest := make([]driver.Value, 1)
+		err = rows.Next(dest)
+		if err != nil {
+			t.Fatal("next error:", err)
+		}
+		value, _ := dest[0].(string)
+		return value
+	}
+
+	value := getLockTimeout()
+	if value != "7" {
+		t.Fatalf("ddl_lock_timeout - received: %v - expected: %v", value, "7")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	err := conn.SetLockTimeout(ctx, 2500*time.Millisecond)
+	cancel()
+	if err != nil {
+		t.Fatal("set lock timeout error:", err)
+	}
+
+	value = getLockTimeout()
+	if value != "3" {
+		t.Fatalf("ddl_lock_timeout - received: %v - expected: %v", value, "3")
+	}
+}
+
+// TestPrepareContextDone tests prepare with a context that is done
+func TestPrepareContextDone(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := conn.PrepareContext(ctx, "select 1 from dual")
+	if err != context.Canceled {
+		t.Fatalf("prepare error - received: %v - expected: %v", err, context.Canceled)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	stmt, err := conn.PrepareContext(ctx, "select 1 from dual")
+	if err == nil {
+		stmt.Close()
+	}
+	if time.Since(start) > 10*time.Millisecond+TestContextTimeout/2 {
+		t.Fatalf("prepare took %v", time.Since(start))
+	}
+
+	// connection is still usable after the deadline
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	err = conn.Ping(ctx)
+	cancel()
+	if err != nil {
+		t.Fatal("ping error:", err)
+	}
+}
+
+// TestAlert tests waiting for a DBMS_ALERT alert signaled from another connection
+func TestAlert(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	alertName := "ALERT_" + TestTimeString
+
+	conn1 := testGetConn(t, "")
+	defer conn1.Close()
+	conn2 := testGetConn(t, "")
+	defer conn2.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	_, err := conn1.WaitAlert(ctx, alertName)
+	cancel()
+	if err != nil && strings.Contains(err.Error(), "PLS-00201") {
+		t.Skip("no execute on DBMS_ALERT")
+	}
+	if err != context.DeadlineExceeded {
+		t.Fatalf("wait alert error - received: %v - expected: %v", err, context.DeadlineExceeded)
+	}
+
+	type waitResult struct {
+		message string
+		err     error
+	}
+	resultChan := make(chan waitResult, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+		message, err := conn1.WaitAlert(ctx, alertName)
+		cancel()
+		resultChan <- waitResult{message: message, err: err}
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	err = conn2.SignalAlert(alertName, "hello")
+	if err != nil {
+		t.Fatal("signal alert error:", err)
+	}
+
+	result := <-resultChan
+	if result.err != nil {
+		t.Fatal("wait alert error:", result.err)
+	}
+	if result.message != "hello" {
+		t.Fatalf("message - received: %v - expected: %v", result.message, "hello")
+	}
+
+	err = conn1.RemoveAlert(alertName)
+	if err != nil {
+		t.Fatal("remove alert error:", err)
+	}
+}
+
+// TestExplain tests getting the explain plan of a query, and that Explain in a transaction leaves no uncommitted PLAN_TABLE rows
+func TestExplain(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	for _, level := range []string{"", "BASIC", "ALL"} {
+		ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+		plan, err := conn.Explain(ctx, "select * from dual", level)
+		cancel()
+		if err != nil {
+			t.Fatalf("explain %v error: %v", level, err)
+		}
+		if !strings.Contains(plan, "DUAL") {
+			t.Fatalf("explain %v plan does not contain DUAL: %v", level, plan)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	tx, err := conn.BeginTx(ctx, driver.TxOptions{})
+	if err != nil {
+		t.Fatal("begin error:", err)
+	}
+	defer tx.Rollback()
+
+	_, err = conn.Explain(ctx, "select * from dual", "")
+	if err != nil {
+		t.Fatal("explain in transaction error:", err)
+	}
+	values, err := conn.queryRowArgs(ctx, "select DBMS_TRANSACTION.LOCAL_TRANSACTION_ID, (select count(*) from PLAN_TABLE where statement_id like 'GO\\_OCI8\\_%' escape '\\') from dual")
+	if err != nil {
+		t.Fatal("query transaction id error:", err)
+	}
+	if values[0] != nil {
+		t.Fatalf("transaction id - received: %v - expected: nil", values[0])
+	}
+	if values[1] != float64(0) {
+		t.Fatalf("PLAN_TABLE count - received: %v - expected: 0", values[1])
+	}
+}
+
+// TestDestructiveGetDDL tests getting the DDL of a table
+func TestDestructiveGetDDL(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "GET_DDL_" + TestTimeString
+	testExecQuery(t, "create table "+tableName+" ( A INTEGER, B VARCHAR2(30) )", nil)
+	defer testDropTable(t, tableName)
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ddl, err := conn.GetDDL("TABLE", "", tableName)
+	if err != nil {
+		t.Fatal("get DDL error:", err)
+	}
+	if !strings.Contains(ddl, "CREATE TABLE") || !strings.Contains(ddl, tableName) {
+		t.Fatalf("DDL does not contain CREATE TABLE %v: %v", tableName, ddl)
+	}
+
+	_, err = conn.GetDDL("TABLE", "", "NOT_A_TABLE_"+TestTimeString)
+	if err == nil {
+		t.Fatal("get DDL error is nil")
+	}
+}
+
+// TestNetworkCompression tests running a query with network_compression
+func TestNetworkCompression(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	db := testGetDB("?network_compression=on")
+	if db == nil {
+		t.Fatal("db is null")
+	}
+	defer db.Close()
+
+	var aString string
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	err := db.QueryRowContext(ctx, "select 'compressed' from dual").Scan(&aString)
+	cancel()
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+	if aString != "compressed" {
+		t.Fatalf("result - received: %v - expected: %v", aString, "compressed")
+	}
+}
+
+// TestDestructiveTypeMapping tests inserting and scanning a custom Go type with a registered type mapping
+func TestDestructiveTypeMapping(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	testRegisterDecimal(OCI8Driver)
+
+	tableName := "TYPE_MAPPING_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A NUMBER(10,2) )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	_, err = TestDB.ExecContext(ctx, "insert into "+tableName+" ( A ) values ( :1 )", testDecimal{value: "123.45"})
+	cancel()
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+
+	var decimal testDecimal
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	err = TestDB.QueryRowContext(ctx, "select A from "+tableName).Scan(OCI8Driver.MappedScanner(&decimal))
+	cancel()
+	if err != nil {
+		t.Fatal("select error:", err)
+	}
+	if decimal.value != "123.45" {
+		t.Fatalf("decimal - received: %v - expected: %v", decimal.value, "123.45")
+	}
+}
+
+// TestCallTime tests the server call time of an exec with and without call time stats enabled
+func TestCallTime(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	query := "declare n number := 0; begin for i in 1..100000 loop n := n + 1; end loop; end;"
+	exec := func() (time.Duration, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+		defer cancel()
+		stmt, err := conn.PrepareContext(ctx, query)
+		if err != nil {
+			t.Fatal("prepare error:", err)
+		}
+		defer stmt.Close()
+		result, err := stmt.(*OCI8Stmt).ExecContext(ctx, nil)
+		if err != nil {
+			t.Fatal("exec error:", err)
+		}
+		return result.(*OCI8Result).CallTime()
+	}
+
+	_, err := exec()
+	if err != ErrNoCallTime {
+		t.Fatalf("call time error - received: %v - expected: %v", err, ErrNoCallTime)
+	}
+
+	err = conn.EnableCallTimeStats()
+	if err != nil {
+		t.Fatal("enable call time stats error:", err)
+	}
+
+	callTime, err := exec()
+	if err != nil {
+		t.Fatal("call time error:", err)
+	}
+	if callTime <= 0 {
+		t.Fatalf("call time - received: %v - expected: greater than 0", callTime)
+	}
+}
+
+// TestConnStats tests the connection statistics counters
+func TestConnStats(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	before := conn.Stats()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	stmt, err := conn.PrepareContext(ctx, "select :1 from dual")
+	if err != nil {
+		t.Fatal("prepare error:", err)
+	}
+	rows, err := stmt.(*OCI8Stmt).QueryContext(ctx, []driver.NamedValue{{Ordinal: 1, Value: "abc"}})
+	if err != nil {
+		stmt.Close()
+		t.Fatal("query error:", err)
+	}
+	dest := make([]driver.Value, 1)
+	err = rows.Next(dest)
+	if err != nil {
+		t.Fatal("next error:", err)
+	}
+	rows.Close()
+	stmt.Close()
+
+	stmt, err = conn.PrepareContext(ctx, "select A from TABLE_DOES_NOT_EXIST_"+TestTimeString)
+	if err != nil {
+		t.Fatal("prepare error:", err)
+	}
+	_, err = stmt.(*OCI8Stmt).QueryContext(ctx, nil)
+	stmt.Close()
+	if err == nil {
+		t.Fatal("query error is nil")
+	}
+
+	after := conn.Stats()
+	counts := []struct {
+		name     string
+		received int64
+		expected int64
+	}{
+		{"PrepareCount", after.PrepareCount - before.PrepareCount, 2},
+		{"ExecCount", after.ExecCount - before.ExecCount, 2},
+		{"FetchCount", after.FetchCount - before.FetchCount, 1},
+		{"ErrorCount", after.ErrorCount - before.ErrorCount, 1},
+		{"BytesSent", after.BytesSent - before.BytesSent, 3},
+	}
+	for _, count := range counts {
+		if count.received != count.expected {
+			t.Errorf("%v - received: %v - expected: %v", count.name, count.received, count.expected)
+		}
+	}
+	if after.BytesReceived-before.BytesReceived < 3 {
+		t.Errorf("BytesReceived - received: %v - expected: at least 3", after.BytesReceived-before.BytesReceived)
+	}
+}
+
+// TestDestructiveGlobalTempTable tests EnsureGlobalTempTable only creates the table once
+func TestDestructiveGlobalTempTable(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	tableName := "GTT_" + TestTimeString
+	columns := []ColumnDef{{Name: "ID", Type: "NUMBER(10)"}, {Name: "NAME", Type: "VARCHAR2(30)"}}
+
+	err := conn.EnsureGlobalTempTable(tableName, columns, ScopeSession)
+	if err != nil {
+		t.Fatal("ensure global temp table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	before := conn.Stats()
+	err = conn.EnsureGlobalTempTable(tableName, columns, ScopeSession)
+	if err != nil {
+		t.Fatal("ensure global temp table error:", err)
+	}
+	after := conn.Stats()
+	if after.PrepareCount != before.PrepareCount {
+		t.Errorf("cached table PrepareCount - received: %v - expected: %v", after.PrepareCount, before.PrepareCount)
+	}
+
+	// a new connection checks USER_OBJECTS but does not create the table again
+	conn2 := testGetConn(t, "")
+	defer conn2.Close()
+	before = conn2.Stats()
+	err = conn2.EnsureGlobalTempTable(tableName, columns, ScopeSession)
+	if err != nil {
+		t.Fatal("ensure global temp table error:", err)
+	}
+	after = conn2.Stats()
+	if after.PrepareCount-before.PrepareCount != 1 {
+		t.Errorf("existing table PrepareCount - received: %v - expected: %v", after.PrepareCount-before.PrepareCount, 1)
+	}
+
+	var count int64
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	err = TestDB.QueryRowContext(ctx, "select count(*) from USER_TABLES where table_name = :1 and temporary = 'Y' and duration = 'SYS$SESSION'", tableName).Scan(&count)
+	cancel()
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+	if count != 1 {
+		t.Errorf("count - received: %v - expected: %v", count, 1)
+	}
+}
+
+// TestDestructiveJSONPath tests JSON_VALUE and JSON_EXISTS with a bound JSON path expression
+func TestDestructiveJSONPath(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "JSON_PATH_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( DATA VARCHAR2(4000) check (DATA is json) )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	err = testExecRows(t, "insert into "+tableName+" ( DATA ) values (:1)",
+		[][]interface{}{
+			{`{"name": "a", "size": 1}`},
+			{`{"name": "b"}`},
+		})
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+
+	db := testGetDB("?questionph=true")
+	if db == nil {
+		t.Fatal("db is null")
+	}
+	defer db.Close()
+
+	queries := []struct {
+		db    *sql.DB
+		query string
+	}{
+		{db: TestDB, query: "select JSON_VALUE(DATA, '$.name') from " + tableName + " where JSON_EXISTS(DATA, :1)"},
+		{db: db, query: "select JSON_VALUE(DATA, '$.name') from " + tableName + " where JSON_EXISTS(DATA, ?)"},
+	}
+
+	for _, query := range queries {
+		var name string
+		ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+		err = query.db.QueryRowContext(ctx, query.query, "$.size").Scan(&name)
+		cancel()
+		if err != nil {
+			if strings.HasPrefix(err.Error(), "ORA-40454") {
+				// JSON path expression bind needs a newer Oracle version
+				t.Skip("JSON path bind not supported:", err)
+			}
+			t.Fatalf("query %v error: %v", query.query, err)
+		}
+		if name != "a" {
+			t.Errorf("query %v - received: %v - expected: %v", query.query, name, "a")
+		}
+	}
+}
+
+// TestWatchTransaction tests the transaction listener gets begin, commit, and rollback events in order
+func TestWatchTransaction(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	var events []TxEvent
+	conn.WatchTransaction(func(event TxEvent) {
+		events = append(events, event)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	tx, err := conn.BeginTx(ctx, driver.TxOptions{})
+	if err != nil {
+		t.Fatal("begin error:", err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		t.Fatal("commit error:", err)
+	}
+
+	tx, err = conn.BeginTx(ctx, driver.TxOptions{})
+	if err != nil {
+		t.Fatal("begin error:", err)
+	}
+	err = tx.Rollback()
+	if err != nil {
+		t.Fatal("rollback error:", err)
+	}
+
+	expected := []TxEventType{TxBegin, TxCommit, TxBegin, TxRollback}
+	if len(events) != len(expected) {
+		t.Fatalf("len events - received: %v - expected: %v", len(events), len(expected))
+	}
+	for i := range expected {
+		if events[i].Type != expected[i] {
+			t.Errorf("event %v type - received: %v - expected: %v", i, events[i].Type, expected[i])
+		}
+		if events[i].Timestamp.IsZero() {
+			t.Errorf("event %v timestamp is zero", i)
+		}
+	}
+	if events[0].TransactionID == "" || events[0].TransactionID != events[1].TransactionID {
+		t.Errorf("commit transaction id - received: %v - expected: %v", events[1].TransactionID, events[0].TransactionID)
+	}
+	if events[2].TransactionID == events[0].TransactionID || events[2].TransactionID != events[3].TransactionID {
+		t.Errorf("rollback transaction id - received: %v - expected: %v", events[3].TransactionID, events[2].TransactionID)
+	}
+}
+
+// TestSetEndToEndInfo tests setting the module, action, and client info tracing attributes on a connection and transaction
+func TestSetEndToEndInfo(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	checkInfo := func(expected []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+		values, err := conn.queryRowArgs(ctx, "select SYS_CONTEXT('USERENV', 'MODULE'), SYS_CONTEXT('USERENV', 'ACTION'), SYS_CONTEXT('USERENV', 'CLIENT_INFO') from dual")
+		cancel()
+		if err != nil {
+			t.Fatal("query error:", err)
+		}
+		for i := range expected {
+			if values[i] != expected[i] {
+				t.Errorf("value %v - received: %v - expected: %v", i, values[i], expected[i])
+			}
+		}
+	}
+
+	err := conn.SetEndToEndInfo("go-oci8 test", "set info", "client info")
+	if err != nil {
+		t.Fatal("set end to end info error:", err)
+	}
+	checkInfo([]string{"go-oci8 test", "set info", "client info"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	tx, err := conn.BeginTx(ctx, driver.TxOptions{})
+	cancel()
+	if err != nil {
+		t.Fatal("begin error:", err)
+	}
+	err = tx.(*OCI8Tx).SetEndToEndInfo("go-oci8 tx", "tx info", "tx client info")
+	if err != nil {
+		t.Fatal("tx set end to end info error:", err)
+	}
+	checkInfo([]string{"go-oci8 tx", "tx info", "tx client info"})
+	err = tx.Rollback()
+	if err != nil {
+		t.Fatal("rollback error:", err)
+	}
+}
+
+// TestWithPrefetchRows tests two open queries on the same connection with different prefetch rows
+func TestWithPrefetchRows(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	conn, err := TestDB.Conn(ctx)
+	if err != nil {
+		t.Fatal("conn error:", err)
+	}
+	defer conn.Close()
+
+	query := "select level from dual connect by level <= 1000"
+	rows1, err := conn.QueryContext(WithPrefetchRows(ctx, 500), query)
+	if err != nil {
+		t.Fatal("query 1 error:", err)
+	}
+	defer rows1.Close()
+	rows2, err := conn.QueryContext(WithPrefetchRows(ctx, 2), query)
+	if err != nil {
+		t.Fatal("query 2 error:", err)
+	}
+	defer rows2.Close()
+
+	var level1, level2 int64
+	for i := int64(1); i <= 1000; i++ {
+		if !rows1.Next() || !rows2.Next() {
+			t.Fatalf("rows ended at %v: %v, %v", i, rows1.Err(), rows2.Err())
+		}
+		err = rows1.Scan(&level1)
+		if err != nil {
+			t.Fatal("scan 1 error:", err)
+		}
+		err = rows2.Scan(&level2)
+		if err != nil {
+			t.Fatal("scan 2 error:", err)
+		}
+		if level1 != i || level2 != i {
+			t.Fatalf("levels - received: %v, %v - expected: %v", level1, level2, i)
+		}
+	}
+
+	// the statement prefetch rows is the context prefetch rows
+	driverConn := testGetConn(t, "")
+	defer driverConn.Close()
+	for _, expected := range []uint32{500, 2, 0} {
+		stmt, err := driverConn.PrepareContext(ctx, query)
+		if err != nil {
+			t.Fatal("prepare error:", err)
+		}
+		rows, err := stmt.(*OCI8Stmt).QueryContext(WithPrefetchRows(ctx, expected), nil)
+		if err != nil {
+			stmt.Close()
+			t.Fatal("query error:", err)
+		}
+		// test files can not use cgo, 11 is OCI_ATTR_PREFETCH_ROWS of oci.h
+		var prefetchRows uint32
+		_, err = stmt.(*OCI8Stmt).ociAttrGet(unsafe.Pointer(&prefetchRows), 11)
+		rows.Close()
+		stmt.Close()
+		if err != nil {
+			t.Fatal("prefetch rows error:", err)
+		}
+		if prefetchRows != expected {
+			t.Errorf("prefetch rows - received: %v - expected: %v", prefetchRows, expected)
+		}
+	}
+}
+
+// TestDestructiveCompileStoredObject tests compiling a broken stored procedure returns the compile error
+func TestDestructiveCompileStoredObject(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	procedureName := "COMPILE_" + TestTimeString
+	// create or replace with a compile error succeeds with info, so it is not an error
+	testExecQuery(t, "create or replace procedure "+procedureName+" as begin null end;", nil)
+	defer testExecQuery(t, "drop procedure "+procedureName, nil)
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	compileErrors, err := conn.CompileStoredObject(ctx, "PROCEDURE", "", procedureName)
+	cancel()
+	if err != nil {
+		t.Fatal("compile stored object error:", err)
+	}
+	if len(compileErrors) < 1 {
+		t.Fatal("no compile errors")
+	}
+	if compileErrors[0].Line != 1 || compileErrors[0].Attribute != "ERROR" || !strings.HasPrefix(compileErrors[0].Text, "PLS-") {
+		t.Errorf("compile error - received: %+v - expected: line 1 PLS- ERROR", compileErrors[0])
+	}
+
+	testExecQuery(t, "create or replace procedure "+procedureName+" as begin null; end;", nil)
+
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	compileErrors, err = conn.CompileStoredObject(ctx, "PROCEDURE", "", procedureName)
+	cancel()
+	if err != nil {
+		t.Fatal("compile stored object error:", err)
+	}
+	if len(compileErrors) != 0 {
+		t.Errorf("compile errors - received: %+v - expected: none", compileErrors)
+	}
+}
+
+// TestDestructiveCompileSchema tests CompileSchema compiles a procedure and function invalidated by dropping their table
+func TestDestructiveCompileSchema(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "COMPILE_SCHEMA_" + TestTimeString
+	procedureName := "COMPILE_P_" + TestTimeString
+	functionName := "COMPILE_F_" + TestTimeString
+	testExecQuery(t, "create table "+tableName+" ( A INTEGER )", nil)
+	defer testDropTable(t, tableName)
+	testExecQuery(t, "create or replace procedure "+procedureName+" as begin delete from "+tableName+"; end;", nil)
+	defer testExecQuery(t, "drop procedure "+procedureName, nil)
+	testExecQuery(t, "create or replace function "+functionName+" return number as c number; begin select count(1) into c from "+tableName+"; return c; end;", nil)
+	defer testExecQuery(t, "drop function "+functionName, nil)
+
+	// dropping the table invalidates the procedure and function
+	testExecQuery(t, "drop table "+tableName, nil)
+	testExecQuery(t, "create table "+tableName+" ( A INTEGER )", nil)
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	invalidQuery := "select count(1) from USER_OBJECTS where OBJECT_NAME in (:1, :2) and OBJECT_TYPE in ('PROCEDURE', 'FUNCTION') and STATUS = 'INVALID'"
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	values, err := conn.queryRowArgs(ctx, invalidQuery, procedureName, functionName)
+	cancel()
+	if err != nil {
+		t.Fatal("query row error:", err)
+	}
+	if values[0] != float64(2) {
+		t.Fatalf("invalid objects - received: %v - expected: %v", values[0], 2)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 2*TestContextTimeout)
+	err = conn.CompileSchema(ctx, "", false, true)
+	cancel()
+	if err != nil {
+		t.Fatal("compile schema error:", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	values, err = conn.queryRowArgs(ctx, invalidQuery, procedureName, functionName)
+	cancel()
+	if err != nil {
+		t.Fatal("query row error:", err)
+	}
+	if values[0] != float64(0) {
+		t.Fatalf("invalid objects - received: %v - expected: %v", values[0], 0)
+	}
+
+	err = conn.CompileSchema(context.Background(), "a;b", false, false)
+	if err == nil || err.Error() != "invalid schema: a;b" {
+		t.Fatalf("compile schema error - received: %v - expected: invalid schema: a;b", err)
+	}
+}
+
+// TestOLSLabel tests setting and reading the Oracle Label Security session label.
+// Without Oracle Label Security the call is checked to fail on SA_SESSION.
+func TestOLSLabel(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	values, err := conn.queryRowArgs(ctx, "select POLICY_NAME from ALL_SA_POLICIES where rownum = 1")
+	cancel()
+	if err != nil {
+		err = conn.SetOLSLabel("NO_POLICY", "NO_LABEL")
+		if err == nil || !strings.Contains(err.Error(), "SA_SESSION") {
+			t.Fatalf("set label without label security error - received: %v - expected: SA_SESSION error", err)
+		}
+		t.Skip("Oracle Label Security policies not found")
+	}
+	policyName, _ := values[0].(string)
+
+	label, err := conn.GetOLSLabel(policyName)
+	if err != nil {
+		t.Fatal("get label error:", err)
+	}
+	err = conn.SetOLSLabel(policyName, label)
+	if err != nil {
+		t.Fatal("set label error:", err)
+	}
+	label2, err := conn.GetOLSLabel(policyName)
+	if err != nil {
+		t.Fatal("get label error:", err)
+	}
+	if label2 != label {
+		t.Errorf("label - received: %v - expected: %v", label2, label)
+	}
+
+	if len(conn.cachedStmts) != 2 {
+		t.Errorf("len cachedStmts - received: %v - expected: %v", len(conn.cachedStmts), 2)
+	}
+}
+
+// TestLock tests acquiring and releasing a DBMS_LOCK lock from two connections
+func TestLock(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn1 := testGetConn(t, "")
+	defer conn1.Close()
+	conn2 := testGetConn(t, "")
+	defer conn2.Close()
+
+	lockName := "GO_OCI8_LOCK_" + TestTimeString
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	err := conn1.AcquireLock(ctx, lockName, time.Second, LockExclusive)
+	cancel()
+	if err != nil {
+		t.Fatal("acquire lock error:", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	err = conn1.AcquireLock(ctx, lockName, time.Second, LockExclusive)
+	cancel()
+	if err != ErrLockAlreadyOwned {
+		t.Errorf("acquire owned lock error - received: %v - expected: %v", err, ErrLockAlreadyOwned)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	err = conn2.AcquireLock(ctx, lockName, time.Second, LockShared)
+	cancel()
+	if err != ErrLockTimeout {
+		t.Errorf("acquire locked lock error - received: %v - expected: %v", err, ErrLockTimeout)
+	}
+
+	err = conn1.ReleaseLock(lockName)
+	if err != nil {
+		t.Fatal("release lock error:", err)
+	}
+	err = conn1.ReleaseLock(lockName)
+	if err != ErrLockNotOwned {
+		t.Errorf("release not owned lock error - received: %v - expected: %v", err, ErrLockNotOwned)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	err = conn2.AcquireLock(ctx, lockName, time.Second, LockShared)
+	cancel()
+	if err != nil {
+		t.Fatal("acquire released lock error:", err)
+	}
+	err = conn2.ReleaseLock(lockName)
+	if err != nil {
+		t.Fatal("release lock error:", err)
+	}
+}
+
+// TestDestructiveLockTransaction tests AcquireLock does not commit the transaction of the connection
+func TestDestructiveLockTransaction(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "LOCK_TX_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	lockName := "GO_OCI8_LOCK_TX_" + TestTimeString
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	tx, err := conn.BeginTx(ctx, driver.TxOptions{})
+	if err != nil {
+		t.Fatal("begin tx error:", err)
+	}
+	err = conn.execArgs(ctx, "insert into "+tableName+" ( A ) values ( 1 )")
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+
+	err = conn.AcquireLock(ctx, lockName, time.Second, LockExclusive)
+	if err != nil {
+		t.Fatal("acquire lock error:", err)
+	}
+	err = conn.ReleaseLock(lockName)
+	if err != nil {
+		t.Fatal("release lock error:", err)
+	}
+
+	err = tx.Rollback()
+	if err != nil {
+		t.Fatal("rollback error:", err)
+	}
+
+	values, err := conn.queryRowArgs(ctx, "select count(1) from "+tableName)
+	if err != nil {
+		t.Fatal("query row error:", err)
+	}
+	if len(values) != 1 || values[0] != float64(0) {
+		t.Errorf("count - received: %v - expected: %v", values, []driver.Value{float64(0)})
+	}
+}
+
+// TestDestructiveFetchAllRows tests fetching all the rows of a table and the max_rows limit
+func TestDestructiveFetchAllRows(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "FETCH_ALL_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER, B VARCHAR2(20) )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	err = testExec(t, "insert into "+tableName+" ( A, B ) select level, 'row ' || level from dual connect by level <= 100", nil)
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	results, err := conn.FetchAllRows(ctx, "select A, B from "+tableName+" where A > :1 order by A", 0)
+	cancel()
+	if err != nil {
+		t.Fatal("fetch all rows error:", err)
+	}
+	if len(results) != 100 {
+		t.Fatalf("len rows - received: %v - expected: %v", len(results), 100)
+	}
+	for i, row := range results {
+		if len(row) != 2 {
+			t.Fatalf("row %v len columns - received: %v - expected: %v", i, len(row), 2)
+		}
+		if row[0] != int64(i+1) || row[1] != fmt.Sprintf("row %v", i+1) {
+			t.Fatalf("row %v - received: %v - expected: %v", i, row, []interface{}{int64(i + 1), fmt.Sprintf("row %v", i+1)})
+		}
+	}
+
+	conn2 := testGetConn(t, "?max_rows=99")
+	defer conn2.Close()
+
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	_, err = conn2.FetchAllRows(ctx, "select A, B from "+tableName)
+	cancel()
+	if err == nil {
+		t.Fatal("fetch more than max rows error is nil")
+	}
+}
+
+// TestDestructiveDescribeType tests describing an object type and a table type
+func TestDestructiveDescribeType(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	typeName := "DESCRIBE_" + TestTimeString
+	tableTypeName := "DESCRIBE_TABLE_" + TestTimeString
+	testExecQuery(t, "create type "+typeName+" as object (ID NUMBER(10), AMOUNT NUMBER(12,2), NAME VARCHAR2(30))", nil)
+	defer testExecQuery(t, "drop type "+typeName, nil)
+	testExecQuery(t, "create type "+tableTypeName+" as table of "+typeName, nil)
+	defer testExecQuery(t, "drop type "+tableTypeName, nil)
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	typeDescriptor, err := conn.DescribeType(ctx, typeName)
+	if err != nil {
+		t.Fatal("describe type error:", err)
+	}
+	if typeDescriptor.TypeCode != 108 { // OCI_TYPECODE_OBJECT
+		t.Errorf("type code - received: %v - expected: %v", typeDescriptor.TypeCode, 108)
+	}
+	expected := []AttributeInfo{
+		{Name: "ID", TypeCode: 2, TypeName: "NUMBER", Precision: 10, Scale: 0},     // OCI_TYPECODE_NUMBER
+		{Name: "AMOUNT", TypeCode: 2, TypeName: "NUMBER", Precision: 12, Scale: 2}, // OCI_TYPECODE_NUMBER
+		{Name: "NAME", TypeCode: 9, TypeName: "VARCHAR2"},                          // OCI_TYPECODE_VARCHAR2
+	}
+	if !reflect.DeepEqual(typeDescriptor.Attributes, expected) {
+		t.Errorf("attributes - received: %+v - expected: %+v", typeDescriptor.Attributes, expected)
+	}
+
+	typeDescriptor, err = conn.DescribeType(ctx, tableTypeName)
+	if err != nil {
+		t.Fatal("describe table type error:", err)
+	}
+	if typeDescriptor.TypeCode != 122 { // OCI_TYPECODE_NAMEDCOLLECTION
+		t.Errorf("table type code - received: %v - expected: %v", typeDescriptor.TypeCode, 122)
+	}
+	if len(typeDescriptor.Attributes) != 0 {
+		t.Errorf("table type attributes - received: %+v - expected: none", typeDescriptor.Attributes)
+	}
+	if typeDescriptor.Element == nil || typeDescriptor.Element.TypeCode != 108 || !strings.HasSuffix(typeDescriptor.Element.TypeName, "."+typeName) {
+		t.Errorf("table type element - received: %+v - expected: %v", typeDescriptor.Element, typeName)
+	}
+}
+
+// TestDestructiveDirectPathLoad tests loading rows with a direct path loader then aborting a load
+func TestDestructiveDirectPathLoad(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "DIRECT_PATH_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER, B VARCHAR2(20), C DATE )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	loader, err := conn.NewDirectPathLoader(tableName, []string{"A", "B", "C"})
+	if err != nil {
+		t.Fatal("new direct path loader error:", err)
+	}
+
+	date := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	rows := make([][]interface{}, 1000)
+	for i := range rows {
+		rows[i] = []interface{}{int64(i + 1), fmt.Sprintf("row %v", i+1), date}
+	}
+	rows[999][1] = nil
+
+	err = loader.Load(rows)
+	if err != nil {
+		loader.Abort()
+		t.Fatal("load error:", err)
+	}
+	err = loader.Finish()
+	if err != nil {
+		t.Fatal("finish error:", err)
+	}
+	err = loader.Finish()
+	if err == nil {
+		t.Fatal("finish twice error is nil")
+	}
+
+	queryResults := testQueryResults{
+		query: "select count(1), count(B), max(A), max(C) from " + tableName,
+		queryResults: []testQueryResult{
+			{
+				results: [][]interface{}{{float64(1000), float64(999), float64(1000), date}},
+			},
+		},
+	}
+	testRunQueryResults(t, queryResults)
+
+	loader, err = conn.NewDirectPathLoader(tableName, []string{"A"})
+	if err != nil {
+		t.Fatal("new direct path loader error:", err)
+	}
+	err = loader.Load([][]interface{}{{int64(1), "extra value"}})
+	if err == nil {
+		t.Fatal("load extra value error is nil")
+	}
+	err = loader.Load([][]interface{}{{int64(1001)}})
+	if err != nil {
+		loader.Abort()
+		t.Fatal("load error:", err)
+	}
+	err = loader.Abort()
+	if err != nil {
+		t.Fatal("abort error:", err)
+	}
+
+	testRunQueryResults(t, queryResults)
+}
+
+// benchmarkDirectPathRows is the number of rows loaded in the direct path benchmarks
+const benchmarkDirectPathRows = 100000
+
+// BenchmarkDirectPathLoad benchmarks loading 100K rows with a direct path loader
+func BenchmarkDirectPathLoad(b *testing.B) {
+	if TestDisableDatabase || TestDisableDestructive {
+		b.SkipNow()
+	}
+
+	b.StopTimer()
+
+	tableName := "DIRPATH_LOAD_" + TestTimeString
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	_, err := TestDB.ExecContext(ctx, "create table "+tableName+" ( A INTEGER, B VARCHAR2(20) )")
+	cancel()
+	if err != nil {
+		b.Fatal("create table error:", err)
+	}
+	defer TestDB.Exec("drop table " + tableName)
+
+	driverConn, err := OCI8Driver.Open(testGetDSN(""))
+	if err != nil {
+		b.Fatal("open error:", err)
+	}
+	conn := driverConn.(*OCI8Conn)
+	defer conn.Close()
+
+	rows := make([][]interface{}, benchmarkDirectPathRows)
+	for i := range rows {
+		rows[i] = []interface{}{int64(i), "benchmark"}
+	}
+
+	b.StartTimer()
+
+	for n := 0; n < b.N; n++ {
+		loader, err := conn.NewDirectPathLoader(tableName, []string{"A", "B"})
+		if err != nil {
+			b.Fatal("new direct path loader error:", err)
+		}
+		err = loader.Load(rows)
+		if err != nil {
+			loader.Abort()
+			b.Fatal("load error:", err)
+		}
+		err = loader.Finish()
+		if err != nil {
+			b.Fatal("finish error:", err)
+		}
+	}
+
+	b.StopTimer()
+}
+
+// BenchmarkDirectPathInsert benchmarks inserting 100K rows with a prepared insert statement to compare with BenchmarkDirectPathLoad
+func BenchmarkDirectPathInsert(b *testing.B) {
+	if TestDisableDatabase || TestDisableDestructive {
+		b.SkipNow()
+	}
+
+	b.StopTimer()
+
+	tableName := "DIRPATH_INSERT_" + TestTimeString
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	_, err := TestDB.ExecContext(ctx, "create table "+tableName+" ( A INTEGER, B VARCHAR2(20) )")
+	cancel()
+	if err != nil {
+		b.Fatal("create table error:", err)
+	}
+	defer TestDB.Exec("drop table " + tableName)
+
+	b.StartTimer()
+
+	for n := 0; n < b.N; n++ {
+		tx, err := TestDB.Begin()
+		if err != nil {
+			b.Fatal("begin error:", err)
+		}
+		stmt, err := tx.Prepare("insert into " + tableName + " ( A, B ) values ( :1, :2 )")
+		if err != nil {
+			tx.Rollback()
+			b.Fatal("prepare error:", err)
+		}
+		for i := 0; i < benchmarkDirectPathRows; i++ {
+			_, err = stmt.Exec(int64(i), "benchmark")
+			if err != nil {
+				stmt.Close()
+				tx.Rollback()
+				b.Fatal("exec error:", err)
+			}
+		}
+		stmt.Close()
+		err = tx.Commit()
+		if err != nil {
+			b.Fatal("commit error:", err)
+		}
+	}
+
+	b.StopTimer()
+}
+
+// BenchmarkThreadModeInsert benchmarks a tight insert loop with the threaded and no_mutex oci_thread_mode
+func BenchmarkThreadModeInsert(b *testing.B) {
+	if TestDisableDatabase || TestDisableDestructive {
+		b.SkipNow()
+	}
+
+	tableName := "THREAD_MODE_" + TestTimeString
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	_, err := TestDB.ExecContext(ctx, "create table "+tableName+" ( A INTEGER, B VARCHAR2(20) )")
+	cancel()
+	if err != nil {
+		b.Fatal("create table error:", err)
+	}
+	defer TestDB.Exec("drop table " + tableName)
+
+	for _, threadMode := range []string{"threaded", "no_mutex"} {
+		b.Run(threadMode, func(b *testing.B) {
+			b.StopTimer()
+			conn, err := OCI8Driver.Open(testGetDSN("?oci_thread_mode=" + threadMode))
+			if err != nil {
+				b.Fatal("open error:", err)
+			}
+			defer conn.Close()
+
+			stmt, err := conn.Prepare("insert into " + tableName + " ( A, B ) values ( :1, :2 )")
+			if err != nil {
+				b.Fatal("prepare error:", err)
+			}
+			defer stmt.Close()
+
+			b.StartTimer()
+			for n := 0; n < b.N; n++ {
+				_, err = stmt.(*OCI8Stmt).ExecContext(context.Background(), []driver.NamedValue{{Ordinal: 1, Value: int64(n)}, {Ordinal: 2, Value: threadMode}})
+				if err != nil {
+					b.Fatal("exec error:", err)
+				}
+			}
+			b.StopTimer()
+		})
+	}
+}
+
+// TestPipe tests sending a DBMS_PIPE message from one connection and receiving it on another
+func TestPipe(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	pipeName := "PIPE_" + TestTimeString
+
+	conn1 := testGetConn(t, "")
+	defer conn1.Close()
+	conn2 := testGetConn(t, "")
+	defer conn2.Close()
+
+	err := conn1.CreatePipe(pipeName, 0, true)
+	if err != nil {
+		if strings.Contains(err.Error(), "PLS-00201") {
+			t.Skip("no execute on DBMS_PIPE")
+		}
+		t.Fatal("create pipe error:", err)
+	}
+	defer conn1.RemovePipe(pipeName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	_, err = conn1.ReceiveMessage(ctx, pipeName, 0)
+	cancel()
+	if err != ErrPipeTimeout {
+		t.Fatalf("receive message error - received: %v - expected: %v", err, ErrPipeTimeout)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	_, err = conn1.ReceiveMessage(ctx, pipeName, -1)
+	cancel()
+	if err != context.DeadlineExceeded {
+		t.Fatalf("receive message error - received: %v - expected: %v", err, context.DeadlineExceeded)
+	}
+
+	err = conn2.SendMessage(pipeName, time.Second, 42, "hello")
+	if err != nil {
+		t.Fatal("send message error:", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	items, err := conn1.ReceiveMessage(ctx, pipeName, 5*time.Second)
+	cancel()
+	if err != nil {
+		t.Fatal("receive message error:", err)
+	}
+	expected := []interface{}{float64(42), "hello"}
+	if !reflect.DeepEqual(items, expected) {
+		t.Fatalf("items - received: %v - expected: %v", items, expected)
+	}
+}
+
+// TestHTTP tests sending UTL_HTTP GET and POST requests from the database to a test server
+func TestHTTP(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, "%v %v", r.Header.Get("Content-Type"), string(body))
+			return
+		}
+		fmt.Fprint(w, "hello")
+	}))
+	defer server.Close()
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	statusCode, body, err := conn.HTTPGet(ctx, server.URL)
+	cancel()
+	if err != nil {
+		if strings.Contains(err.Error(), "PLS-00201") || strings.Contains(err.Error(), "ORA-24247") || strings.Contains(err.Error(), "ORA-12541") {
+			t.Skip("no UTL_HTTP access to test server:", err)
+		}
+		t.Fatal("http get error:", err)
+	}
+	if statusCode != http.StatusOK || body != "hello" {
+		t.Fatalf("http get - received: %v %v - expected: %v %v", statusCode, body, http.StatusOK, "hello")
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	statusCode, body, err = conn.HTTPPost(ctx, server.URL, "text/plain", "data")
+	cancel()
+	if err != nil {
+		t.Fatal("http post error:", err)
+	}
+	if statusCode != http.StatusCreated || body != "text/plain data" {
+		t.Fatalf("http post - received: %v %v - expected: %v %v", statusCode, body, http.StatusCreated, "text/plain data")
+	}
+}
+
+// TestDestructiveRebind tests executing a statement prepared once with Rebind
+func TestDestructiveRebind(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "REBIND_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER, B VARCHAR2(4000), C BINARY_DOUBLE )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	stmt, err := conn.PrepareContext(ctx, "insert into "+tableName+" ( A, B, C ) values ( :1, :2, :3 )")
+	cancel()
+	if err != nil {
+		t.Fatal("prepare error:", err)
+	}
+	defer stmt.Close()
+
+	queryResults := testQueryResults{
+		query:        "select A, B, C from " + tableName + " order by A",
+		queryResults: []testQueryResult{{}},
+	}
+	for i := 0; i < 10; i++ {
+		b := strings.Repeat("b", i*500)
+		var c interface{} = float64(i) + 0.5
+		var expectedB interface{} = b
+		if i == 0 {
+			expectedB = nil
+		}
+		if i == 5 {
+			c = nil
+		}
+		err = stmt.(*OCI8Stmt).Rebind([]driver.NamedValue{{Ordinal: 1, Value: i}, {Ordinal: 2, Value: b}, {Ordinal: 3, Value: c}})
+		if err != nil {
+			t.Fatalf("rebind %v error: %v", i, err)
+		}
+		queryResults.queryResults[0].results = append(queryResults.queryResults[0].results, []interface{}{int64(i), expectedB, c})
+	}
+
+	testRunQueryResults(t, queryResults)
+}
+
+// TestDestructiveAutoReturnRowid tests getting the inserted ROWID with autoreturn_rowid then selecting the row by ROWID
+func TestDestructiveAutoReturnRowid(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "AUTO_ROWID_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER, B VARCHAR2(20) )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	conn := testGetConn(t, "?autoreturn_rowid=true")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	stmt, err := conn.PrepareContext(ctx, "insert into "+tableName+" ( A, B ) values ( :1, :2 )")
+	if err != nil {
+		t.Fatal("prepare error:", err)
+	}
+	defer stmt.Close()
+
+	if stmt.NumInput() != 2 {
+		t.Fatalf("num input - received: %v - expected: %v", stmt.NumInput(), 2)
+	}
+
+	result, err := stmt.(*OCI8Stmt).ExecContext(ctx, []driver.NamedValue{{Ordinal: 1, Value: int64(1)}, {Ordinal: 2, Value: "one"}})
+	if err != nil {
+		t.Fatal("exec error:", err)
+	}
+	rowid, err := result.(*OCI8Result).InsertedRowID()
+	if err != nil {
+		t.Fatal("inserted rowid error:", err)
+	}
+	if rowid == "" {
+		t.Fatal("inserted rowid is empty")
+	}
+
+	values, err := conn.queryRowArgs(ctx, "select A, B from "+tableName+" where rowid = chartorowid(:1)", rowid)
+	if err != nil {
+		t.Fatal("select by rowid error:", err)
+	}
+	if values[0] != int64(1) || values[1] != "one" {
+		t.Fatalf("row - received: %v - expected: %v", values, []interface{}{int64(1), "one"})
+	}
+
+	updateStmt, err := conn.PrepareContext(ctx, "update "+tableName+" set B = 'two'")
+	if err != nil {
+		t.Fatal("prepare error:", err)
+	}
+	defer updateStmt.Close()
+	result, err = updateStmt.(*OCI8Stmt).ExecContext(ctx, nil)
+	if err != nil {
+		t.Fatal("exec error:", err)
+	}
+	_, err = result.(*OCI8Result).InsertedRowID()
+	if err != ErrNoRowid {
+		t.Fatalf("update inserted rowid error - received: %v - expected: %v", err, ErrNoRowid)
+	}
+
+	// ExecBatch and Rebind do not bind :oci8_rowid, the statement is prepared again without it
+	_, err = stmt.(*OCI8Stmt).ExecBatch(ctx, [][]interface{}{{2, "two"}, {3, "three"}})
+	if err != nil {
+		t.Fatal("exec batch error:", err)
+	}
+	err = stmt.(*OCI8Stmt).RebindContext(ctx, []driver.NamedValue{{Ordinal: 1, Value: int64(4)}, {Ordinal: 2, Value: "four"}})
+	if err != nil {
+		t.Fatal("rebind error:", err)
+	}
+	values, err = conn.queryRowArgs(ctx, "select count(1) from "+tableName)
+	if err != nil {
+		t.Fatal("count error:", err)
+	}
+	if values[0] != float64(4) {
+		t.Fatalf("count - received: %v - expected: %v", values[0], 4)
+	}
+}
+
+// TestDestructiveTableExists tests TableExists and ObjectExists before and after creating and dropping a table and a view
+func TestDestructiveTableExists(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "TABLE_EXISTS_" + TestTimeString
+	viewName := "VIEW_EXISTS_" + TestTimeString
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	testTableExists := func(expected bool) {
+		ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+		exists, err := conn.TableExists(ctx, "", tableName)
+		cancel()
+		if err != nil {
+			t.Fatal("table exists error:", err)
+		}
+		if exists != expected {
+			t.Fatalf("table exists - received: %v - expected: %v", exists, expected)
+		}
+	}
+
+	testTableExists(false)
+
+	err := testExec(t, "create table "+tableName+" ( A INTEGER )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	testTableExists(true)
+
+	err = testExec(t, "create view "+viewName+" as select A from "+tableName, nil)
+	if err != nil {
+		testDropTable(t, tableName)
+		t.Fatal("create view error:", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	exists, err := conn.ObjectExists(ctx, "VIEW", TestUsername, viewName)
+	cancel()
+	testExec(t, "drop view "+viewName, nil)
+	if err != nil {
+		testDropTable(t, tableName)
+		t.Fatal("object exists error:", err)
+	}
+	if !exists {
+		testDropTable(t, tableName)
+		t.Fatal("view does not exist")
+	}
+
+	prepareCount := conn.Stats().PrepareCount
+	testDropTable(t, tableName)
+	testTableExists(false)
+	if conn.Stats().PrepareCount != prepareCount {
+		t.Fatalf("prepare count - received: %v - expected: %v", conn.Stats().PrepareCount, prepareCount)
+	}
+}
+
+// TestDestructiveBatchErrors tests ExecBatch with a duplicate row then getting the row error with BatchErrors
+func TestDestructiveBatchErrors(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "BATCH_ERRORS_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER PRIMARY KEY, B VARCHAR2(20) )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	stmt, err := conn.PrepareContext(ctx, "insert into "+tableName+" ( A, B ) values ( :1, :2 )")
+	if err != nil {
+		t.Fatal("prepare error:", err)
+	}
+	defer stmt.Close()
+
+	rows := [][]interface{}{{1, "one"}, {2, nil}, {1, "duplicate"}, {3, "three"}}
+	result, err := stmt.(*OCI8Stmt).ExecBatch(ctx, rows)
+	if err != nil {
+		t.Fatal("exec batch error:", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		t.Fatal("rows affected error:", err)
+	}
+	if rowsAffected != 3 {
+		t.Fatalf("rows affected - received: %v - expected: %v", rowsAffected, 3)
+	}
+
+	batchErrors, err := stmt.(*OCI8Stmt).BatchErrors()
+	if err != nil {
+		t.Fatal("batch errors error:", err)
+	}
+	if len(batchErrors) != 1 {
+		t.Fatalf("len batch errors - received: %v - expected: %v", len(batchErrors), 1)
+	}
+	if batchErrors[0].RowIndex != 2 || batchErrors[0].ErrorCode != 1 || !strings.HasPrefix(batchErrors[0].ErrorMessage, "ORA-00001") {
+		t.Fatalf("batch error - received: %+v - expected row index 2 and ORA-00001", batchErrors[0])
+	}
+
+	queryResults := testQueryResults{
+		query: "select A, B from " + tableName + " order by A",
+		queryResults: []testQueryResult{
+			{
+				results: [][]interface{}{{int64(1), "one"}, {int64(2), nil}, {int64(3), "three"}},
+			},
+		},
+	}
+	testRunQueryResults(t, queryResults)
+
+	_, err = stmt.(*OCI8Stmt).ExecBatch(ctx, [][]interface{}{{4, "four"}, {5}})
+	if err == nil {
+		t.Fatal("exec batch missing value error is nil")
+	}
+	_, err = stmt.(*OCI8Stmt).ExecBatch(ctx, [][]interface{}{{4, "four"}, {"five", "five"}})
+	if err == nil {
+		t.Fatal("exec batch mixed types error is nil")
+	}
+}
+
+// TestDestructiveUnicodeNormalization tests binding composed and decomposed strings with unicode_normalization then sorting them
+func TestDestructiveUnicodeNormalization(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "UNICODE_NORM_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER, B NVARCHAR2(20) )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	db := testGetDB("?unicode_normalization=NFC")
+	if db == nil {
+		t.Fatal("db is nil")
+	}
+	defer db.Close()
+
+	// e with acute accent decomposed, composed, then decomposed with another letter
+	values := []string{"f", "e\u0301", "\u00e9", "d", "e\u0301a"}
+	for i, value := range values {
+		ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+		_, err = db.ExecContext(ctx, "insert into "+tableName+" ( A, B ) values ( :1, :2 )", i, value)
+		cancel()
+		if err != nil {
+			t.Fatal("insert error:", err)
+		}
+	}
+
+	// ExecBatch and Rebind normalize strings the same as Exec
+	conn := testGetConn(t, "?unicode_normalization=NFC")
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	stmt, err := conn.prepare(ctx, "insert into "+tableName+" ( A, B ) values ( :1, :2 )", false)
+	if err != nil {
+		t.Fatal("prepare error:", err)
+	}
+	defer stmt.Close()
+	_, err = stmt.ExecBatch(ctx, [][]interface{}{{10, "e\u0301"}, {11, "e\u0301"}})
+	if err != nil {
+		t.Fatal("exec batch error:", err)
+	}
+	err = stmt.RebindContext(ctx, []driver.NamedValue{{Ordinal: 1, Value: int64(12)}, {Ordinal: 2, Value: "e\u0301"}})
+	if err != nil {
+		t.Fatal("rebind error:", err)
+	}
+
+	queryResults := testQueryResults{
+		query: "select B, count(1) from " + tableName + " group by B order by NLSSORT(B, 'NLS_SORT=BINARY')",
+		queryResults: []testQueryResult{
+			{
+				results: [][]interface{}{{"d", float64(1)}, {"f", float64(1)}, {"\u00e9", float64(5)}, {"\u00e9a", float64(1)}},
+			},
+		},
+	}
+	testRunQueryResults(t, queryResults)
+}
+
+// TestSessionID tests getting the session SID then finding it in V$SESSION
+func TestSessionID(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	sessionID, err := conn.SessionID()
+	if err != nil {
+		t.Fatal("session id error:", err)
+	}
+	if sessionID < 1 {
+		t.Fatalf("session id - received: %v - expected more than 0", sessionID)
+	}
+
+	prepareCount := conn.Stats().PrepareCount
+	sessionID2, err := conn.SessionID()
+	if err != nil {
+		t.Fatal("session id error:", err)
+	}
+	if sessionID2 != sessionID || conn.Stats().PrepareCount != prepareCount {
+		t.Fatalf("cached session id - received: %v - expected: %v", sessionID2, sessionID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	values, err := conn.queryRowArgs(ctx, "select count(1) from V$SESSION where SID = :1 and AUDSID = userenv('SESSIONID')", sessionID)
+	cancel()
+	if err != nil {
+		if strings.Contains(err.Error(), "ORA-00942") {
+			t.Skip("no access to V$SESSION")
+		}
+		t.Fatal("query V$SESSION error:", err)
+	}
+	if values[0] != float64(1) {
+		t.Fatalf("V$SESSION count - received: %v - expected: %v", values[0], 1)
+	}
+}
+
+// TestCursorBind tests binding an OCICursorBind to a PL/SQL block that opens a cursor then reading the cursor rows
+func TestCursorBind(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	stmt, err := conn.PrepareContext(ctx, "begin open :1 for select * from dual; end;")
+	if err != nil {
+		t.Fatal("prepare error:", err)
+	}
+	defer stmt.Close()
+
+	cursor := &OCICursorBind{}
+	_, err = stmt.(*OCI8Stmt).ExecContext(ctx, []driver.NamedValue{{Ordinal: 1, Value: cursor}})
+	if err != nil {
+		t.Fatal("exec error:", err)
+	}
+
+	rows, err := cursor.Rows()
+	if err != nil {
+		t.Fatal("cursor rows error:", err)
+	}
+	defer rows.Close()
+
+	columns := rows.Columns()
+	if len(columns) != 1 || columns[0] != "DUMMY" {
+		t.Fatalf("columns - received: %v - expected: %v", columns, []string{"DUMMY"})
+	}
+
+	dest := make([]driver.Value, 1)
+	err = rows.Next(dest)
+	if err != nil {
+		t.Fatal("next error:", err)
+	}
+	if dest[0] != "X" {
+		t.Fatalf("value - received: %v - expected: %v", dest[0], "X")
+	}
+	err = rows.Next(dest)
+	if err != io.EOF {
+		t.Fatalf("next error - received: %v - expected: %v", err, io.EOF)
+	}
+
+	_, err = cursor.Rows()
+	if err == nil {
+		t.Fatal("second cursor rows error is nil")
+	}
+}
+
+// TestDestructiveSchedulerJob tests creating a DBMS_SCHEDULER job, finding it in USER_SCHEDULER_JOBS, then dropping it
+func TestDestructiveSchedulerJob(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	jobName := "JOB_" + TestTimeString
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	err := conn.CreateSchedulerJob(ctx, SchedulerJob{
+		JobName:        jobName,
+		JobAction:      "begin null; end;",
+		StartDate:      time.Now().Add(24 * time.Hour),
+		RepeatInterval: "FREQ=DAILY",
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "ORA-27486") {
+			t.Skip("no create job privilege")
+		}
+		t.Fatal("create scheduler job error:", err)
+	}
+
+	values, err := conn.queryRowArgs(ctx, "select JOB_TYPE, JOB_ACTION, REPEAT_INTERVAL, ENABLED from USER_SCHEDULER_JOBS where JOB_NAME = :1", jobName)
+	if err != nil {
+		conn.DropSchedulerJob(ctx, jobName, true)
+		t.Fatal("query USER_SCHEDULER_JOBS error:", err)
+	}
+	expected := []driver.Value{"PLSQL_BLOCK", "begin null; end;", "FREQ=DAILY", "FALSE"}
+	if !reflect.DeepEqual(values, expected) {
+		conn.DropSchedulerJob(ctx, jobName, true)
+		t.Fatalf("job - received: %v - expected: %v", values, expected)
+	}
+
+	err = conn.DropSchedulerJob(ctx, jobName, false)
+	if err != nil {
+		t.Fatal("drop scheduler job error:", err)
+	}
+
+	_, err = conn.queryRowArgs(ctx, "select JOB_NAME from USER_SCHEDULER_JOBS where JOB_NAME = :1", jobName)
+	if err != io.EOF {
+		t.Fatalf("dropped job error - received: %v - expected: %v", err, io.EOF)
+	}
+}
+
+// TestDestructiveBFile tests selecting and binding BFILE references to a file in a DIRECTORY object
+func TestDestructiveBFile(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	directoryName := "BFILE_DIR_" + TestTimeString
+	err := testExec(t, "create directory "+directoryName+" as '/tmp'", nil)
+	if err != nil {
+		if strings.Contains(err.Error(), "ORA-01031") {
+			t.Skip("no create directory privilege")
+		}
+		t.Fatal("create directory error:", err)
+	}
+	defer testExec(t, "drop directory "+directoryName, nil)
+
+	tableName := "BFILE_" + TestTimeString
+	err = testExec(t, "create table "+tableName+" ( A INTEGER, B BFILE )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	err = testExec(t, "insert into "+tableName+" ( A, B ) values ( 1, BFILENAME('"+directoryName+"', 'file1.txt') )", nil)
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+	err = testExec(t, "insert into "+tableName+" ( A, B ) values ( :1, :2 )", []interface{}{2, BFileRef{Directory: directoryName, FileName: "file2.txt"}})
+	if err != nil {
+		t.Fatal("insert bind error:", err)
+	}
+
+	queryResults := testQueryResults{
+		query: "select B from " + tableName + " order by A",
+		queryResults: []testQueryResult{
+			{
+				results: [][]interface{}{
+					{BFileRef{Directory: directoryName, FileName: "file1.txt"}},
+					{BFileRef{Directory: directoryName, FileName: "file2.txt"}},
+				},
+			},
+		},
+	}
+	testRunQueryResults(t, queryResults)
+
+	var bfile BFileRef
+	err = TestDB.QueryRow("select B from " + tableName + " where A = 1").Scan(&bfile)
+	if err != nil {
+		t.Fatal("scan error:", err)
+	}
+	if bfile.FileName != "file1.txt" {
+		t.Fatalf("file name - received: %v - expected: %v", bfile.FileName, "file1.txt")
+	}
+}
+
+// TestDestructiveFlashbackQuery tests inserting then deleting a row then seeing the row with a flashback query
+func TestDestructiveFlashbackQuery(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "FLASHBACK_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER, B VARCHAR2(20) )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	err = testExec(t, "insert into "+tableName+" ( A, B ) values ( 1, 'deleted' )", nil)
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	values, err := conn.queryRowArgs(ctx, "select DBMS_FLASHBACK.GET_SYSTEM_CHANGE_NUMBER from dual")
+	if err != nil {
+		if strings.Contains(err.Error(), "PLS-00201") || strings.Contains(err.Error(), "ORA-00904") {
+			t.Skip("no execute on DBMS_FLASHBACK")
+		}
+		t.Fatal("get SCN error:", err)
+	}
+	scn := int64(values[0].(float64))
+
+	err = testExec(t, "delete from "+tableName, nil)
+	if err != nil {
+		t.Fatal("delete error:", err)
+	}
+
+	testFlashbackRows := func(rows driver.Rows, expected []driver.Value) {
+		defer rows.Close()
+		dest := make([]driver.Value, 2)
+		var results [][]driver.Value
+		for {
+			err := rows.Next(dest)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal("next error:", err)
+			}
+			results = append(results, []driver.Value{dest[0], dest[1]})
+		}
+		if expected == nil && len(results) != 0 || expected != nil && (len(results) != 1 || !reflect.DeepEqual(results[0], expected)) {
+			t.Fatalf("rows - received: %v - expected: %v", results, expected)
+		}
+	}
+
+	rows, err := conn.FlashbackQuery(ctx, scn, "select A, B from "+tableName+" where A = :1", 1)
+	if err != nil {
+		t.Fatal("flashback query error:", err)
+	}
+	testFlashbackRows(rows, []driver.Value{int64(1), "deleted"})
+
+	// flashback mode is disabled after the query
+	stmt, err := conn.PrepareContext(ctx, "select A, B from "+tableName)
+	if err != nil {
+		t.Fatal("prepare error:", err)
+	}
+	defer stmt.Close()
+	rows, err = stmt.(*OCI8Stmt).QueryContext(ctx, nil)
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+	testFlashbackRows(rows, nil)
+}
+
+// TestCrypto tests encrypting then decrypting data with DBMS_CRYPTO, with a random IV for each encrypt
+func TestCrypto(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+	var tests = []struct {
+		algorithm CryptoAlgorithm
+		key       []byte
+		data      []byte
+	}{
+		{algorithm: CryptoAES128, key: key[:16], data: []byte("a known plaintext")},
+		{algorithm: CryptoAES192, key: key[:24], data: []byte("a known plaintext")},
+		{algorithm: CryptoAES256, key: key, data: []byte("a known plaintext")},
+		{algorithm: Crypto3DES, key: key[:24], data: []byte("a known plaintext")},
+		{algorithm: CryptoAES256, key: key, data: bytes.Repeat([]byte("0123456789"), 5000)},
+	}
+
+	for _, tt := range tests {
+		ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+		encrypted, err := conn.EncryptRAW(ctx, tt.data, tt.key, tt.algorithm)
+		cancel()
+		if err != nil {
+			if strings.Contains(err.Error(), "PLS-00201") {
+				t.Skip("no execute on DBMS_CRYPTO")
+			}
+			t.Fatal("encrypt error:", err)
+		}
+		if bytes.Equal(encrypted, tt.data) {
+			t.Fatalf("encrypted equal to data for algorithm %v", tt.algorithm)
+		}
+
+		// a random IV is used for each call
+		ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+		encryptedAgain, err := conn.EncryptRAW(ctx, tt.data, tt.key, tt.algorithm)
+		cancel()
+		if err != nil {
+			t.Fatal("encrypt again error:", err)
+		}
+		if bytes.Equal(encrypted, encryptedAgain) {
+			t.Fatalf("encrypted equal for the same data for algorithm %v", tt.algorithm)
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+		decrypted, err := conn.DecryptRAW(ctx, encrypted, tt.key, tt.algorithm)
+		cancel()
+		if err != nil {
+			t.Fatal("decrypt error:", err)
+		}
+		if !bytes.Equal(decrypted, tt.data) {
+			t.Fatalf("decrypted - received: %q - expected: %q", decrypted, tt.data)
+		}
+	}
+
+	_, err := conn.EncryptRAW(context.Background(), []byte("data"), key, CryptoAlgorithm(1))
+	if err == nil || err.Error() != "invalid crypto algorithm: 1" {
+		t.Fatal("invalid algorithm error:", err)
+	}
+	_, err = conn.DecryptRAW(context.Background(), make([]byte, 16), key, CryptoAES256)
+	if err == nil || err.Error() != "encrypted data of length 16 is not longer than the 16 byte IV" {
+		t.Fatal("short encrypted data error:", err)
+	}
+}
+
+// TestDestructiveCopyTable tests copying the rows of a table matching a where condition with a bind
+func TestDestructiveCopyTable(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	srcName := "COPY_SRC_" + TestTimeString
+	destName := "COPY_DEST_" + TestTimeString
+	err := testExec(t, "create table "+srcName+" ( A INTEGER, B VARCHAR2(20), C NUMBER(10,2), D DATE )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, srcName)
+
+	err = testExec(t, "insert into "+srcName+" select level, 'row ' || level, level / 4, sysdate from dual connect by level <= 1000", nil)
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	err = conn.CopyTable(ctx, srcName, destName, "A > :1", int64(600))
+	cancel()
+	if err != nil {
+		t.Fatal("copy table error:", err)
+	}
+	defer testDropTable(t, destName)
+
+	values, err := conn.queryRowArgs(context.Background(), "select count(*), min(A) from "+destName)
+	if err != nil {
+		t.Fatal("count error:", err)
+	}
+	if values[0] != float64(400) || values[1] != float64(601) {
+		t.Fatalf("count, min - received: %v - expected: %v", values, []interface{}{float64(400), float64(601)})
+	}
+
+	typesQuery := "select listagg(column_name || ' ' || data_type || ' ' || data_length || ' ' || data_precision || ' ' || data_scale, ', ') within group (order by column_id) from USER_TAB_COLUMNS where table_name = :1"
+	srcTypes, err := conn.queryRowArgs(context.Background(), typesQuery, srcName)
+	if err != nil {
+		t.Fatal("src column types error:", err)
+	}
+	destTypes, err := conn.queryRowArgs(context.Background(), typesQuery, destName)
+	if err != nil {
+		t.Fatal("dest column types error:", err)
+	}
+	if destTypes[0] != srcTypes[0] {
+		t.Fatalf("column types - received: %v - expected: %v", destTypes[0], srcTypes[0])
+	}
+}
+
+// TestDestructiveParallelDML tests enabling and disabling parallel DML then checking V$SESSION PDML_STATUS
+func TestDestructiveParallelDML(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "PARALLEL_DML_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER, B VARCHAR2(20) )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	err = testExec(t, "insert into "+tableName+" select level, 'row ' || level from dual connect by level <= 100000", nil)
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	testPDMLStatus := func(expected string) {
+		values, err := conn.queryRowArgs(context.Background(), "select PDML_STATUS from V$SESSION where SID = SYS_CONTEXT('USERENV', 'SID')")
+		if err != nil {
+			if strings.Contains(err.Error(), "ORA-00942") {
+				t.Skip("no access to V$SESSION")
+			}
+			t.Fatal("pdml status error:", err)
+		}
+		if values[0] != expected {
+			t.Fatalf("pdml status - received: %v - expected: %v", values[0], expected)
+		}
+	}
+
+	var tests = []struct {
+		degree int
+		status string
+	}{
+		{degree: 0, status: "ENABLED"},
+		{degree: 4, status: "FORCED"},
+	}
+
+	for _, tt := range tests {
+		err = conn.EnableParallelDML(tt.degree)
+		if err != nil {
+			t.Fatal("enable parallel dml error:", err)
+		}
+		testPDMLStatus(tt.status)
+
+		ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+		err = conn.execArgs(ctx, "update "+tableName+" set B = 'updated ' || A")
+		cancel()
+		if err != nil {
+			t.Fatal("update error:", err)
+		}
+
+		err = conn.DisableParallelDML()
+		if err != nil {
+			t.Fatal("disable parallel dml error:", err)
+		}
+		testPDMLStatus("DISABLED")
+	}
+
+	values, err := conn.queryRowArgs(context.Background(), "select count(*) from "+tableName+" where B like 'updated %'")
+	if err != nil {
+		t.Fatal("count error:", err)
+	}
+	if values[0] != float64(100000) {
+		t.Fatalf("count - received: %v - expected: %v", values[0], 100000)
+	}
+}
+
+// TestDestructiveApproxCount tests the approximate count before and after gathering table statistics
+func TestDestructiveApproxCount(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "APPROX_COUNT_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	err = testExec(t, "insert into "+tableName+" select level from dual connect by level <= 1000", nil)
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	result, err := conn.ApproxCount(ctx, tableName, "")
+	cancel()
+	if err != nil {
+		t.Fatal("approx count error:", err)
+	}
+	if !result.Stale || result.Count < 950 || result.Count > 1050 {
+		t.Fatalf("approx count - received: %+v - expected: stale about 1000", result)
+	}
+
+	err = conn.execArgs(context.Background(), "begin DBMS_STATS.GATHER_TABLE_STATS(user, :1); end;", tableName)
+	if err != nil {
+		t.Fatal("gather table stats error:", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	result, err = conn.ApproxCount(ctx, strings.ToLower(tableName), TestUsername)
+	cancel()
+	if err != nil {
+		t.Fatal("approx count error:", err)
+	}
+	if result.Stale || result.Count != 1000 {
+		t.Fatalf("approx count - received: %+v - expected: %+v", result, CountResult{Count: 1000})
+	}
+
+	_, err = conn.ApproxCount(context.Background(), "NOT_"+tableName, "")
+	if err == nil || err.Error() != "table not found: NOT_"+tableName {
+		t.Fatal("table not found error:", err)
+	}
+}
+
+// TestDestructiveEdition tests switching the session edition then checking CURRENT_EDITION_NAME
+func TestDestructiveEdition(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	editionName := "EDITION_" + TestTimeString
+	err := testExec(t, "create edition "+editionName, nil)
+	if err != nil {
+		if strings.Contains(err.Error(), "ORA-01031") {
+			t.Skip("no create edition privilege")
+		}
+		t.Fatal("create edition error:", err)
+	}
+	defer func() {
+		err := testExec(t, "drop edition "+editionName, nil)
+		if err != nil {
+			t.Error("drop edition error:", err)
+		}
+	}()
+
+	testCurrentEdition := func(conn *OCI8Conn, expected string) {
+		values, err := conn.queryRowArgs(context.Background(), "select SYS_CONTEXT('USERENV', 'CURRENT_EDITION_NAME') from dual")
+		if err != nil {
+			t.Fatal("current edition error:", err)
+		}
+		if values[0] != expected {
+			t.Fatalf("current edition - received: %v - expected: %v", values[0], expected)
+		}
+	}
+
+	conn := testGetConn(t, "")
+	err = conn.SetEdition(editionName)
+	if err != nil {
+		conn.Close()
+		t.Fatal("set edition error:", err)
+	}
+	testCurrentEdition(conn, editionName)
+
+	err = conn.SetEdition("ora$base")
+	if err != nil {
+		conn.Close()
+		t.Fatal("set edition error:", err)
+	}
+	testCurrentEdition(conn, "ORA$BASE")
+	conn.Close()
+
+	conn = testGetConn(t, "?default_edition="+editionName)
+	testCurrentEdition(conn, editionName)
+	conn.Close()
+}
+
+// TestCursorCount tests the open cursor count goes up with open statements and down when they are closed
+func TestCursorCount(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	startCount, err := conn.CursorCount()
+	if err != nil {
+		if strings.Contains(err.Error(), "ORA-00942") {
+			t.Skip("no access to V$MYSTAT")
+		}
+		t.Fatal("cursor count error:", err)
+	}
+
+	maxCursors, err := conn.MaxCursors()
+	if err != nil {
+		if !strings.Contains(err.Error(), "ORA-00942") {
+			t.Fatal("max cursors error:", err)
+		}
+	} else if maxCursors < 1 {
+		t.Fatalf("max cursors - received: %v - expected more than 0", maxCursors)
+	}
+
+	var stmts []driver.Stmt
+	var rowsList []driver.Rows
+	for i := 0; i < 5; i++ {
+		stmt, err := conn.PrepareContext(context.Background(), "select "+strconv.Itoa(i)+" from dual")
+		if err != nil {
+			t.Fatal("prepare error:", err)
+		}
+		stmts = append(stmts, stmt)
+		rows, err := stmt.(*OCI8Stmt).QueryContext(context.Background(), nil)
+		if err != nil {
+			t.Fatal("query error:", err)
+		}
+		rowsList = append(rowsList, rows)
+	}
+
+	openCount, err := conn.CursorCount()
+	if err != nil {
+		t.Fatal("cursor count error:", err)
+	}
+	if openCount < startCount+len(stmts) {
+		t.Fatalf("open cursor count - received: %v - expected at least: %v", openCount, startCount+len(stmts))
+	}
+
+	for i := range stmts {
+		rowsList[i].Close()
+		stmts[i].Close()
+	}
+
+	// the cursors are closed on the server with the next round trip
+	err = conn.Ping(context.Background())
+	if err != nil {
+		t.Fatal("ping error:", err)
+	}
+	closedCount, err := conn.CursorCount()
+	if err != nil {
+		t.Fatal("cursor count error:", err)
+	}
+	if closedCount >= openCount {
+		t.Fatalf("closed cursor count - received: %v - expected less than: %v", closedCount, openCount)
+	}
+}
+
+// TestDestructiveSmartAlloc tests smart_alloc uses small piecewise define buffers for short values and grows them for long values
+func TestDestructiveSmartAlloc(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "SMART_ALLOC_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER, B VARCHAR2(4000), C VARCHAR2(4000), D VARCHAR2(4000) )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	err = testExec(t, "insert into "+tableName+" select level, 'b' || level, 'c' || level, 'd' || level from dual connect by level <= 1000", nil)
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+	longValue := strings.Repeat("x", 3000)
+	err = testExec(t, "update "+tableName+" set C = :1 where A = 500", []interface{}{longValue})
+	if err != nil {
+		t.Fatal("update error:", err)
+	}
+
+	testDefineSizes := func(dsn string) (int, int) {
+		conn := testGetConn(t, dsn)
+		defer conn.Close()
+
+		stmt, err := conn.PrepareContext(context.Background(), "select A, B, C, D from "+tableName+" order by A")
+		if err != nil {
+			t.Fatal("prepare error:", err)
+		}
+		defer stmt.Close()
+
+		rows, err := stmt.(*OCI8Stmt).QueryContext(context.Background(), nil)
+		if err != nil {
+			t.Fatal("query error:", err)
+		}
+		defer rows.Close()
+
+		defines := rows.(*OCI8Rows).defines
+		var startSize, endSize int
+		for i := 1; i < len(defines); i++ {
+			startSize += int(defines[i].maxSize)
+		}
+
+		dest := make([]driver.Value, 4)
+		count := 0
+		for {
+			err = rows.Next(dest)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal("next error:", err)
+			}
+			count++
+			a := dest[0].(int64)
+			expected := []driver.Value{a, "b" + strconv.FormatInt(a, 10), "c" + strconv.FormatInt(a, 10), "d" + strconv.FormatInt(a, 10)}
+			if a == 500 {
+				expected[2] = longValue
+			}
+			if !reflect.DeepEqual(dest, expected) {
+				t.Fatalf("row %v - received: %v - expected: %v", a, dest, expected)
+			}
+		}
+		if count != 1000 {
+			t.Fatalf("count - received: %v - expected: %v", count, 1000)
+		}
+
+		for i := 1; i < len(defines); i++ {
+			endSize += int(defines[i].maxSize)
+		}
+		return startSize, endSize
+	}
+
+	startSize, endSize := testDefineSizes("")
+	t.Logf("define buffer bytes - default: %v", endSize)
+	if startSize != endSize || startSize < 3*4000 {
+		t.Fatalf("default define sizes - received: %v, %v - expected equal and at least %v", startSize, endSize, 3*4000)
+	}
+	fullSize := startSize
+
+	startSize, endSize = testDefineSizes("?smart_alloc=true")
+	t.Logf("define buffer bytes - smart_alloc start: %v - end: %v", startSize, endSize)
+	if startSize != 3*smartAllocSize {
+		t.Fatalf("smart_alloc start define size - received: %v - expected: %v", startSize, 3*smartAllocSize)
+	}
+	// only the long value column is grown
+	if endSize != 2*smartAllocSize+fullSize/3 {
+		t.Fatalf("smart_alloc end define size - received: %v - expected: %v", endSize, 2*smartAllocSize+fullSize/3)
+	}
+}
+
+// TestDestructiveParameters tests describing the bind variables of a procedure call, a function call, and a query
+func TestDestructiveParameters(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	procedureName := "PARAMS_PROC_" + TestTimeString
+	err := testExec(t, "create or replace procedure "+procedureName+"(A in number, B out varchar2, C in out date) as begin B := A; C := C + 1; end;", nil)
+	if err != nil {
+		t.Fatal("create procedure error:", err)
+	}
+	defer testExec(t, "drop procedure "+procedureName, nil)
+
+	functionName := "PARAMS_FUNC_" + TestTimeString
+	err = testExec(t, "create or replace function "+functionName+"(A in varchar2) return number as begin return length(A); end;", nil)
+	if err != nil {
+		t.Fatal("create function error:", err)
+	}
+	defer testExec(t, "drop function "+functionName, nil)
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	var tests = []struct {
+		query      string
+		parameters []ParameterInfo
+	}{
+		{query: "begin " + procedureName + "(:X, C => :Z, B => :Y); end;", parameters: []ParameterInfo{
+			{Name: "X", Direction: ParameterIn, DataType: 2, MaxLength: 22},
+			{Name: "Z", Direction: ParameterInOut, DataType: 12, MaxLength: 7},
+			{Name: "Y", Direction: ParameterOut, DataType: 1},
+		}},
+		{query: "begin :R := " + functionName + "(:A); end;", parameters: []ParameterInfo{
+			{Name: "R", Direction: ParameterOut, DataType: 2, MaxLength: 22},
+			{Name: "A", Direction: ParameterIn, DataType: 1},
+		}},
+		{query: "begin DBMS_OUTPUT.PUT_LINE(:A); end;", parameters: []ParameterInfo{
+			{Name: "A", Direction: ParameterIn},
+		}},
+		{query: "select :A, :B, :A from dual", parameters: []ParameterInfo{
+			{Name: "A", Direction: ParameterIn},
+			{Name: "B", Direction: ParameterIn},
+		}},
+		{query: "select 1 from dual", parameters: []ParameterInfo{}},
+	}
+
+	for _, tt := range tests {
+		stmt, err := conn.PrepareContext(context.Background(), tt.query)
+		if err != nil {
+			t.Fatal("prepare error:", err)
+		}
+		parameters, err := stmt.(*OCI8Stmt).Parameters()
+		stmt.Close()
+		if err != nil {
+			t.Fatal("parameters error:", err)
+		}
+		// max length of VARCHAR2 arguments without a size depends on the database
+		for i := range parameters {
+			if parameters[i].DataType == 1 {
+				parameters[i].MaxLength = 0
+			}
+		}
+		if !reflect.DeepEqual(parameters, tt.parameters) {
+			t.Errorf("parameters %v - received: %+v - expected: %+v", tt.query, parameters, tt.parameters)
+		}
+	}
+}
+
+// TestDestructiveRecycleBin tests dropping a table then finding it in the recycle bin and restoring it
+func TestDestructiveRecycleBin(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "RECYCLE_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+
+	err = testExec(t, "insert into "+tableName+" ( A ) values ( 1 )", nil)
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+	err = testExec(t, "drop table "+tableName, nil)
+	if err != nil {
+		t.Fatal("drop table error:", err)
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	testFindEntry := func() *RecycleBinEntry {
+		ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+		entries, err := conn.RecycleBinContents(ctx)
+		cancel()
+		if err != nil {
+			t.Fatal("recycle bin contents error:", err)
+		}
+		for i := range entries {
+			if entries[i].OriginalName == tableName && entries[i].Type == "TABLE" {
+				return &entries[i]
+			}
+		}
+		return nil
+	}
+
+	entry := testFindEntry()
+	if entry == nil {
+		t.Skip("dropped table not in recycle bin, recyclebin may be off")
+	}
+	if !strings.HasPrefix(entry.ObjectName, "BIN$") || !entry.CanUndrop || entry.DropTime.IsZero() {
+		t.Fatalf("recycle bin entry - received: %+v", entry)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	err = conn.RestoreFromRecycleBin(ctx, tableName)
+	cancel()
+	if err != nil {
+		t.Fatal("restore error:", err)
+	}
+
+	entry = testFindEntry()
+	if entry != nil {
+		t.Fatalf("restored table in recycle bin: %+v", entry)
+	}
+
+	values, err := conn.queryRowArgs(context.Background(), "select A from "+tableName)
+	if err != nil {
+		t.Fatal("select error:", err)
+	}
+	if values[0] != int64(1) {
+		t.Fatalf("restored table value - received: %v - expected: %v", values[0], int64(1))
+	}
+
+	err = testExec(t, "drop table "+tableName, nil)
+	if err != nil {
+		t.Fatal("drop table error:", err)
+	}
+	err = conn.PurgeRecycleBin(context.Background())
+	if err != nil {
+		t.Fatal("purge recycle bin error:", err)
+	}
+	entry = testFindEntry()
+	if entry != nil {
+		t.Fatalf("purged table in recycle bin: %+v", entry)
+	}
+}
+
+// TestDestructiveCompareObjects tests comparing a table with an equal copy and a modified copy using DBMS_COMPARISON
+func TestDestructiveCompareObjects(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "COMPARE_" + TestTimeString
+	copyName := "COMPARE_COPY_" + TestTimeString
+	for _, name := range []string{tableName, copyName} {
+		err := testExec(t, "create table "+name+" ( A INTEGER primary key, B VARCHAR2(20) )", nil)
+		if err != nil {
+			t.Fatal("create table error:", err)
+		}
+		defer testDropTable(t, name)
+
+		err = testExec(t, "insert into "+name+" select level, 'row ' || level from dual connect by level <= 100", nil)
+		if err != nil {
+			t.Fatal("insert error:", err)
+		}
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	scan := ComparisonScan{ComparisonName: "CMP_" + TestTimeString, ObjectName: tableName, RemoteObjectName: copyName}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	result, err := conn.CompareObjects(ctx, scan)
+	cancel()
+	if err != nil {
+		if strings.Contains(err.Error(), "PLS-00201") || strings.Contains(err.Error(), "ORA-01031") {
+			t.Skip("no execute on DBMS_COMPARISON")
+		}
+		t.Fatal("compare objects error:", err)
+	}
+	if result.DifferentCount != 0 || result.MissingInSource != 0 || result.MissingInDest != 0 {
+		t.Fatalf("equal copy - received: %+v - expected no differences", result)
+	}
+
+	err = testExec(t, "update "+copyName+" set B = 'changed' where A <= 3", nil)
+	if err != nil {
+		t.Fatal("update error:", err)
+	}
+	err = testExec(t, "delete from "+copyName+" where A > 98", nil)
+	if err != nil {
+		t.Fatal("delete error:", err)
+	}
+	err = testExec(t, "insert into "+copyName+" ( A, B ) values ( 101, 'new' )", nil)
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	result, err = conn.CompareObjects(ctx, scan)
+	cancel()
+	if err != nil {
+		t.Fatal("compare objects error:", err)
+	}
+	if result.DifferentCount != 3 || result.MissingInSource != 1 || result.MissingInDest != 2 {
+		t.Fatalf("modified copy - received: %+v - expected: 3 different, 1 missing in source, 2 missing in dest", result)
+	}
+
+	// an existing comparison with the same name is not dropped
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	err = conn.execArgs(ctx, "begin DBMS_COMPARISON.CREATE_COMPARISON(comparison_name => :1, schema_name => null, object_name => :2, dblink_name => null, remote_object_name => :3); end;",
+		scan.ComparisonName, tableName, copyName)
+	if err != nil {
+		t.Fatal("create comparison error:", err)
+	}
+	defer conn.execArgs(context.Background(), "begin DBMS_COMPARISON.DROP_COMPARISON(:1); end;", scan.ComparisonName)
+	_, err = conn.CompareObjects(ctx, scan)
+	if err == nil {
+		t.Fatal("existing comparison - expected error")
+	}
+	values, err := conn.queryRowArgs(ctx, "select count(*) from USER_COMPARISON where COMPARISON_NAME = :1", scan.ComparisonName)
+	if err != nil {
+		t.Fatal("select comparison error:", err)
+	}
+	if values[0] != float64(1) {
+		t.Fatalf("existing comparison count - received: %v - expected: 1", values[0])
+	}
+}
+
+// TestDestructiveChangePassword tests changing the password, connecting with the new password and with ExecParallel, then changing it back
+func TestDestructiveChangePassword(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive || TestPassword == "" {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	newPassword := "Np" + TestTimeString
+	err := conn.ChangePassword(TestPassword, newPassword)
+	if err != nil {
+		if strings.Contains(err.Error(), "ORA-28003") || strings.Contains(err.Error(), "ORA-28007") {
+			t.Skip("password verify function or reuse:", err)
+		}
+		t.Fatal("change password error:", err)
+	}
+	defer func() {
+		err := conn.ChangePassword(newPassword, TestPassword)
+		if err != nil {
+			t.Error("change password back error:", err)
+		}
+	}()
+
+	// the session stays usable
+	_, err = conn.queryRowArgs(context.Background(), "select 1 from dual")
+	if err != nil {
+		t.Fatal("select error:", err)
+	}
+
+	newConn, err := OCI8Driver.Open(TestUsername + "/" + newPassword + "@" + TestHostValid)
+	if err != nil {
+		t.Fatal("connect with new password error:", err)
+	}
+	newConn.Close()
+
+	// the parallel connections use the new password
+	errs := conn.ExecParallel(context.Background(), []string{"select 1 from dual"})
+	if errs[0] != nil {
+		t.Fatal("exec parallel error:", errs[0])
+	}
+}
+
+// TestDestructiveNewPassword tests connecting as a user with an expired password, ORA-28001,
+// with the new_password DSN parameter, which changes the password at logon. Needs the CREATE USER and DROP USER privileges.
+func TestDestructiveNewPassword(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	username := "NEW_PW_" + TestTimeString
+	oldPassword := "Op" + TestTimeString
+	newPassword := "Np" + TestTimeString
+	err := testExec(t, "create user "+username+" identified by \""+oldPassword+"\" password expire", nil)
+	if err != nil {
+		if strings.Contains(err.Error(), "ORA-01031") {
+			t.Skip("no create user privilege")
+		}
+		t.Fatal("create user error:", err)
+	}
+	defer testExecQuery(t, "drop user "+username+" cascade", nil)
+	testExecQuery(t, "grant create session to "+username, nil)
+
+	// without new_password the logon fails
+	_, err = OCI8Driver.Open(username + "/" + oldPassword + "@" + TestHostValid)
+	if !isPasswordExpiredError(err) {
+		t.Fatalf("expired password open - received: %v - expected ORA-28001", err)
+	}
+
+	conn, err := OCI8Driver.Open(username + "/" + oldPassword + "@" + TestHostValid + "?new_password=" + newPassword)
+	if err != nil {
+		t.Fatal("open with new_password error:", err)
+	}
+	values, err := conn.(*OCI8Conn).queryRowArgs(context.Background(), "select SYS_CONTEXT('USERENV', 'SESSION_USER') from dual")
+	conn.Close()
+	if err != nil {
+		t.Fatal("select error:", err)
+	}
+	if values[0] != username {
+		t.Errorf("session user - received: %v - expected: %v", values[0], username)
+	}
+
+	// the new password is the password now, and new_password is not used when the password is not expired
+	conn, err = OCI8Driver.Open(username + "/" + newPassword + "@" + TestHostValid + "?new_password=" + oldPassword)
+	if err != nil {
+		t.Fatal("open with new password error:", err)
+	}
+	conn.Close()
+
+	_, err = OCI8Driver.Open(username + "/" + oldPassword + "@" + TestHostValid)
+	if err == nil {
+		t.Fatal("open with old password error is nil")
+	}
+}
+
+// TestDestructiveScanLONG tests streaming a 10 MB LONG value with ScanLONG.
+// The LONG value is the text of a view, USER_VIEWS TEXT, with a 10 MB comment, since LONG values can not be bound.
+func TestDestructiveScanLONG(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	viewName := "SCAN_LONG_" + TestTimeString
+	viewText := "select /* " + strings.Repeat("0123456789", 1024*1024) + " */ 1 A from dual"
+	err := testExec(t, "create view "+viewName+" as "+viewText, nil)
+	if err != nil {
+		t.Fatal("create view error:", err)
+	}
+	defer testExec(t, "drop view "+viewName, nil)
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	stmt, err := conn.PrepareContext(context.Background(), "select VIEW_NAME, TEXT from USER_VIEWS where VIEW_NAME = :1")
+	if err != nil {
+		t.Fatal("prepare error:", err)
+	}
+	defer stmt.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	rows, err := stmt.(*OCI8Stmt).QueryContext(ctx, []driver.NamedValue{{Ordinal: 1, Value: viewName}})
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+	defer rows.Close()
+
+	err = rows.(*OCI8Rows).ScanLONG(0, ioutil.Discard)
+	if err == nil || err.Error() != "column 0 is not a LONG" {
+		t.Fatal("scan long not a LONG error:", err)
+	}
+
+	var buffer bytes.Buffer
+	err = rows.(*OCI8Rows).ScanLONG(1, &buffer)
+	if err != nil {
+		t.Fatal("scan long error:", err)
+	}
+
+	dest := make([]driver.Value, 2)
+	err = rows.Next(dest)
+	if err != nil {
+		t.Fatal("next error:", err)
+	}
+	if dest[0] != viewName || dest[1] != int64(len(viewText)) {
+		t.Fatalf("dest - received: %v %v - expected: %v %v", dest[0], dest[1], viewName, len(viewText))
+	}
+	if !bytes.Equal(buffer.Bytes(), []byte(viewText)) {
+		t.Fatalf("streamed LONG not equal - received length: %v - expected length: %v", buffer.Len(), len(viewText))
+	}
+
+	err = rows.Next(dest)
+	if err != io.EOF {
+		t.Fatal("next error:", err)
+	}
+}
+
+// TestDestructiveSQLPlanBaseline tests loading a SQL plan baseline from the cursor cache then evolving it
+func TestDestructiveSQLPlanBaseline(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	query := "select /* oci8 plan baseline " + TestTimeString + " */ count(1) from dual"
+	_, err := conn.queryRowArgs(context.Background(), query)
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+
+	values, err := conn.queryRowArgs(context.Background(), "select SQL_ID, to_char(PLAN_HASH_VALUE) from V$SQL where SQL_TEXT = :1", query)
+	if err != nil {
+		if strings.Contains(err.Error(), "ORA-00942") {
+			t.Skip("no access to V$SQL")
+		}
+		t.Fatal("sql id error:", err)
+	}
+	sqlID := values[0].(string)
+	planHash := values[1].(string)
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	err = conn.LoadSQLPlanBaseline(ctx, sqlID, planHash)
+	cancel()
+	if err != nil {
+		if strings.Contains(err.Error(), "ORA-38171") || strings.Contains(err.Error(), "ORA-01031") || strings.Contains(err.Error(), "PLS-00201") {
+			t.Skip("no SQL plan management privilege:", err)
+		}
+		t.Fatal("load sql plan baseline error:", err)
+	}
+
+	values, err = conn.queryRowArgs(context.Background(), "select SQL_HANDLE, PLAN_NAME from DBA_SQL_PLAN_BASELINES where SQL_TEXT = :1", query)
+	if err != nil {
+		t.Fatal("plan name error:", err)
+	}
+	sqlHandle := values[0].(string)
+	planName := values[1].(string)
+	defer conn.execArgs(context.Background(), "declare dropped pls_integer; begin dropped := DBMS_SPM.DROP_SQL_PLAN_BASELINE(sql_handle => :1); end;", sqlHandle)
+
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	report, err := conn.EvolveBaseline(ctx, planName)
+	cancel()
+	if err != nil {
+		t.Fatal("evolve baseline error:", err)
+	}
+	if !strings.Contains(report, planName) {
+		t.Fatalf("evolve report does not have plan name %v: %v", planName, report)
+	}
+
+	err = conn.LoadSQLPlanBaseline(context.Background(), sqlID, "abc")
+	if err == nil || err.Error() != "invalid plan hash: abc" {
+		t.Fatal("invalid plan hash error:", err)
+	}
+}
+
+// TestDestructiveLOBPrefetchSize tests fetching CLOB columns with and without lob_prefetch_size
+func TestDestructiveLOBPrefetchSize(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "LOB_PREFETCH_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER, B CLOB )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	err = testExec(t, "insert into "+tableName+" select level, rpad('b', 100 * level, 'b') from dual connect by level <= 100", nil)
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+
+	testFetchLOBs := func(dsn string) int64 {
+		conn := testGetConn(t, dsn)
+		defer conn.Close()
+
+		roundTripsQuery := "select m.VALUE from V$MYSTAT m, V$STATNAME n where m.STATISTIC# = n.STATISTIC# and n.NAME = 'SQL*Net roundtrips to/from client'"
+		ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+		defer cancel()
+		before, roundTripsErr := conn.queryRowArgs(ctx, roundTripsQuery)
+
+		stmt, err := conn.PrepareContext(ctx, "select A, B from "+tableName+" order by A")
+		if err != nil {
+			t.Fatal("prepare error:", err)
+		}
+		defer stmt.Close()
+
+		rows, err := stmt.(*OCI8Stmt).QueryContext(ctx, nil)
+		if err != nil {
+			t.Fatal("query error:", err)
+		}
+		defer rows.Close()
+
+		dest := make([]driver.Value, 2)
+		count := 0
+		for {
+			err = rows.Next(dest)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal("next error:", err)
+			}
+			count++
+			a := dest[0].(int64)
+			if dest[1] != strings.Repeat("b", 100*int(a)) {
+				t.Errorf("row %v B length - received: %v - expected: %v", a, len(dest[1].(string)), 100*a)
+			}
+		}
+		if count != 100 {
+			t.Errorf("count - received: %v - expected: %v", count, 100)
+		}
+
+		if roundTripsErr != nil {
+			return -1
+		}
+		after, err := conn.queryRowArgs(ctx, roundTripsQuery)
+		if err != nil {
+			return -1
+		}
+		return int64(after[0].(float64) - before[0].(float64))
+	}
+
+	withoutPrefetch := testFetchLOBs("")
+	withPrefetch := testFetchLOBs("?lob_prefetch_size=16384")
+	if withoutPrefetch < 0 || withPrefetch < 0 {
+		t.Log("no access to V$MYSTAT, round trips not measured")
+		return
+	}
+	t.Logf("round trips - without lob_prefetch_size: %v - with lob_prefetch_size: %v", withoutPrefetch, withPrefetch)
+	if withPrefetch >= withoutPrefetch {
+		t.Errorf("round trips with lob_prefetch_size - received: %v - expected less than: %v", withPrefetch, withoutPrefetch)
+	}
+}
+
+// TestPingContext tests that PingContext with a done context fails fast and an interrupted ping is a bad connection
+func TestPingContext(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	time.Sleep(2 * time.Millisecond)
+	start := time.Now()
+	err := conn.PingContext(ctx)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("ping error - received: %v - expected: %v", err, context.DeadlineExceeded)
+	}
+	if time.Since(start) > 10*time.Millisecond {
+		t.Fatalf("ping took %v", time.Since(start))
+	}
+
+	// a ping that is interrupted by OCIBreak returns driver.ErrBadConn
+	for i := 0; i < 20; i++ {
+		ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+		err = conn.PingContext(ctx)
+		cancel()
+		if err == driver.ErrBadConn {
+			break
+		}
+		if err != nil && err != context.DeadlineExceeded {
+			t.Fatal("ping error:", err)
+		}
+	}
+
+	// database/sql discards the interrupted connections, so the pool stays usable
+	for i := 0; i < 20; i++ {
+		ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+		err = TestDB.PingContext(ctx)
+		cancel()
+		if err != nil && err != context.DeadlineExceeded {
+			t.Fatal("ping error:", err)
+		}
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	err = TestDB.PingContext(ctx)
+	cancel()
+	if err != nil {
+		t.Fatal("ping error:", err)
+	}
+}
+
+// TestDestructiveXMLType tests round-tripping a schema-based binary XMLTYPE, natively with the xml_native build tag, otherwise as a CLOB with xml_as_clob
+func TestDestructiveXMLType(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	schemaURL := "http://go-oci8.test/xmltype_" + TestTimeString + ".xsd"
+	schemaDoc := `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" elementFormDefault="qualified">` +
+		`<xs:element name="item"><xs:complexType><xs:sequence><xs:element name="name" type="xs:string"/></xs:sequence></xs:complexType></xs:element>` +
+		`</xs:schema>`
+	err := testExec(t, "begin DBMS_XMLSCHEMA.REGISTERSCHEMA(SCHEMAURL => :1, SCHEMADOC => :2, LOCAL => TRUE, GENTYPES => FALSE, GENTABLES => FALSE, OPTIONS => DBMS_XMLSCHEMA.REGISTER_BINARYXML); end;",
+		[]interface{}{schemaURL, schemaDoc})
+	if err != nil {
+		t.Fatal("register schema error:", err)
+	}
+	defer func() {
+		err := testExec(t, "begin DBMS_XMLSCHEMA.DELETESCHEMA(:1, DBMS_XMLSCHEMA.DELETE_CASCADE_FORCE); end;", []interface{}{schemaURL})
+		if err != nil {
+			t.Error("delete schema error:", err)
+		}
+	}()
+
+	tableName := "XMLTYPE_" + TestTimeString
+	err = testExec(t, "create table "+tableName+" ( A INTEGER, B XMLTYPE ) XMLTYPE column B store as binary xml XMLSCHEMA \""+schemaURL+"\" ELEMENT \"item\"", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	err = testExec(t, "insert into "+tableName+" ( A, B ) values ( 1, XMLTYPE(:1) )", []interface{}{"<item><name>go-oci8</name></item>"})
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+	err = testExec(t, "insert into "+tableName+" ( A, B ) values ( 2, null )", nil)
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	if !xmlNative {
+		// without xml_as_clob the query is not described or converted
+		defaultConn := testGetConn(t, "")
+		_, err = defaultConn.queryRowArgs(ctx, "select A, B from "+tableName+" where A = 1")
+		defaultConn.Close()
+		if err != ErrXMLNative {
+			t.Errorf("query without xml_as_clob error - received: %v - expected: %v", err, ErrXMLNative)
+		}
+	}
+
+	conn := testGetConn(t, "?xml_as_clob=true")
+	defer conn.Close()
+
+	// without the xml_native build tag the query is prepared with the XMLTYPE column converted to CLOB
+	values, err := conn.queryRowArgs(ctx, "select A, B from "+tableName+" where A = 1")
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+	if fmt.Sprint(values[0]) != "1" {
+		t.Errorf("A - received: %v - expected: %v", values[0], 1)
+	}
+	xml, _ := values[1].(string)
+	if !strings.Contains(xml, "<name>go-oci8</name>") {
+		t.Errorf("XMLTYPE - received: %v - expected to contain: %v", values[1], "<name>go-oci8</name>")
+	}
+
+	values, err = conn.queryRowArgs(ctx, "select B from "+tableName+" where A = :1", 2)
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+	if values[0] != nil {
+		t.Errorf("null XMLTYPE - received: %v - expected: %v", values[0], nil)
+	}
+
+	if xmlNative {
+		return
+	}
+
+	// queries that cannot be wrapped in a select are not converted
+	for _, query := range []string{
+		"select A, B from " + tableName + " where A = 1 for update",
+		"select B, B from " + tableName + " where A = 1",
+	} {
+		_, err = conn.queryRowArgs(ctx, query)
+		if err != ErrXMLNative {
+			t.Errorf("query %v error - received: %v - expected: %v", query, err, ErrXMLNative)
+		}
+	}
+}
+
+// TestDestructiveObjectAsJSON tests selecting an object column with and without object_as_json
+func TestDestructiveObjectAsJSON(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	typeName := "OBJECT_JSON_" + TestTimeString
+	tableName := "OBJECT_JSON_T_" + TestTimeString
+	testExecQuery(t, "create type "+typeName+" as object (ID number(10), NAME varchar2(30))", nil)
+	defer testExecQuery(t, "drop type "+typeName, nil)
+	testExecQuery(t, "create table "+tableName+" ( A INTEGER, B "+typeName+" )", nil)
+	defer testDropTable(t, tableName)
+	testExecQuery(t, "insert into "+tableName+" ( A, B ) values ( 1, "+typeName+"(1, 'a') )", nil)
+	testExecQuery(t, "insert into "+tableName+" ( A, B ) values ( 2, "+typeName+"(2, null) )", nil)
+
+	query := "select A, B from " + tableName + " where A = :1"
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+	values, err := conn.queryRowArgs(ctx, query, 1)
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+	expected := []driver.Value{int64(1), map[string]interface{}{"ID": int64(1), "NAME": "a"}}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("object - received: %v - expected: %v", values, expected)
+	}
+
+	jsonConn := testGetConn(t, "?object_as_json=true")
+	defer jsonConn.Close()
+	for _, tt := range []struct {
+		a        int64
+		expected map[string]interface{}
+	}{
+		{a: 1, expected: map[string]interface{}{"ID": float64(1), "NAME": "a"}},
+		{a: 2, expected: map[string]interface{}{"ID": float64(2), "NAME": nil}},
+	} {
+		values, err = jsonConn.queryRowArgs(ctx, query, tt.a)
+		if err != nil {
+			t.Fatal("query error:", err)
+		}
+		if values[0] != tt.a {
+			t.Errorf("A - received: %v - expected: %v", values[0], tt.a)
+		}
+		jsonString, ok := values[1].(string)
+		if !ok {
+			t.Fatalf("B type - received: %T - expected: string", values[1])
+		}
+		var object map[string]interface{}
+		err = json.Unmarshal([]byte(jsonString), &object)
+		if err != nil {
+			t.Fatal("unmarshal error:", err)
+		}
+		if !reflect.DeepEqual(object, tt.expected) {
+			t.Errorf("JSON object - received: %v - expected: %v", object, tt.expected)
+		}
+	}
+
+	// queries without object columns are not changed
+	values, err = jsonConn.queryRowArgs(ctx, "select A from "+tableName+" where A = :1", 2)
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+	if values[0] != int64(2) {
+		t.Errorf("A - received: %v - expected: %v", values[0], 2)
+	}
+}
+
+// TestDestructivePurgeStatementCache tests running a query after altering its table and purging the statement cache
+func TestDestructivePurgeStatementCache(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "PURGE_CACHE_" + TestTimeString
+	testExecQuery(t, "create table "+tableName+" ( A INTEGER, B VARCHAR2(10) )", nil)
+	defer testDropTable(t, tableName)
+	testExecQuery(t, "insert into "+tableName+" ( A, B ) values ( 1, 'b' )", nil)
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	query := "select * from " + tableName
+	values, err := conn.queryRowArgs(ctx, query)
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+	if len(values) != 2 {
+		t.Fatalf("columns - received: %v - expected: %v", len(values), 2)
+	}
+
+	_, err = conn.execScriptStatement(ctx, "alter table "+tableName+" add ( C VARCHAR2(10) default 'c' )")
+	if err != nil {
+		t.Fatal("alter table error:", err)
+	}
+
+	err = conn.PurgeStatementCache()
+	if err != nil {
+		t.Fatal("purge statement cache error:", err)
+	}
+
+	values, err = conn.queryRowArgs(ctx, query)
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+	expected := []driver.Value{int64(1), "b", "c"}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("values - received: %v - expected: %v", values, expected)
+	}
+
+	// internal cached statements are prepared again
+	exists, err := conn.TableExists(ctx, "", tableName)
+	if err != nil {
+		t.Fatal("table exists error:", err)
+	}
+	if !exists {
+		t.Error("table exists is false")
+	}
+	err = conn.PurgeStatementCache()
+	if err != nil {
+		t.Fatal("purge statement cache error:", err)
+	}
+	if len(conn.cachedStmts) != 0 {
+		t.Errorf("cached statements - received: %v - expected: %v", len(conn.cachedStmts), 0)
+	}
+}
+
+// TestGetTransactionID tests that each transaction has a different transaction id
+func TestGetTransactionID(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	_, err := conn.GetTransactionID()
+	if err == nil {
+		t.Fatal("get transaction id outside of a transaction error is nil")
+	}
+
+	var transactionIDs []string
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+		tx, err := conn.BeginTx(ctx, driver.TxOptions{})
+		cancel()
+		if err != nil {
+			t.Fatal("begin tx error:", err)
+		}
+
+		transactionID, err := conn.GetTransactionID()
+		if err != nil {
+			tx.Rollback()
+			t.Fatal("get transaction id error:", err)
+		}
+		if transactionID == "" {
+			tx.Rollback()
+			t.Fatal("transaction id is empty")
+		}
+
+		// cached for the transaction
+		cachedID, err := conn.GetTransactionID()
+		if err != nil {
+			tx.Rollback()
+			t.Fatal("get transaction id error:", err)
+		}
+		if cachedID != transactionID {
+			t.Errorf("transaction id - received: %v - expected: %v", cachedID, transactionID)
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			t.Fatal("commit error:", err)
+		}
+		transactionIDs = append(transactionIDs, transactionID)
+	}
+
+	if transactionIDs[0] == transactionIDs[1] {
+		t.Errorf("transaction ids are the same: %v", transactionIDs[0])
+	}
+}
+
+// TestDestructiveProfiler tests profiling a PL/SQL procedure with DBMS_PROFILER. Needs the profiler tables from proftab.sql.
+func TestDestructiveProfiler(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	exists, err := conn.TableExists(ctx, "", "PLSQL_PROFILER_RUNS")
+	if err != nil {
+		t.Fatal("table exists error:", err)
+	}
+	if !exists {
+		t.Skip("no PLSQL_PROFILER_RUNS table, run rdbms/admin/proftab.sql")
+	}
+
+	procedureName := "PROFILER_" + TestTimeString
+	testExecQuery(t, "create procedure "+procedureName+" is x number := 0; begin for i in 1..1000 loop x := x + i; end loop; end;", nil)
+	defer testExecQuery(t, "drop procedure "+procedureName, nil)
+
+	runID, err := conn.StartProfiler("go-oci8 " + TestTimeString)
+	if err != nil {
+		t.Fatal("start profiler error:", err)
+	}
+	if runID < 1 {
+		t.Errorf("run id - received: %v - expected more than 0", runID)
+	}
+	// the run number is the run of the comment
+	values, err := conn.queryRowArgs(ctx, "select max(RUNID) from PLSQL_PROFILER_RUNS where RUN_COMMENT = :1", "go-oci8 "+TestTimeString)
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+	if fmt.Sprint(values[0]) != strconv.FormatInt(runID, 10) {
+		t.Errorf("run id - received: %v - expected: %v", runID, values[0])
+	}
+
+	err = conn.execArgs(ctx, "begin "+procedureName+"; end;")
+	if err != nil {
+		t.Fatal("exec error:", err)
+	}
+
+	err = conn.FlushProfilerData()
+	if err != nil {
+		t.Fatal("flush profiler data error:", err)
+	}
+
+	err = conn.StopProfiler()
+	if err != nil {
+		t.Fatal("stop profiler error:", err)
+	}
+
+	values, err = conn.queryRowArgs(ctx, "select RUN_COMMENT from PLSQL_PROFILER_RUNS where RUNID = :1", runID)
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+	if values[0] != "go-oci8 "+TestTimeString {
+		t.Errorf("run comment - received: %v - expected: %v", values[0], "go-oci8 "+TestTimeString)
+	}
+
+	_, err = conn.execScriptStatement(ctx, "delete from PLSQL_PROFILER_DATA where RUNID = "+strconv.FormatInt(runID, 10))
+	if err != nil {
+		t.Error("delete error:", err)
+	}
+	_, err = conn.execScriptStatement(ctx, "delete from PLSQL_PROFILER_UNITS where RUNID = "+strconv.FormatInt(runID, 10))
+	if err != nil {
+		t.Error("delete error:", err)
+	}
+	_, err = conn.execScriptStatement(ctx, "delete from PLSQL_PROFILER_RUNS where RUNID = "+strconv.FormatInt(runID, 10))
+	if err != nil {
+		t.Error("delete error:", err)
+	}
+}
+
+// TestJSONAggregate tests scanning JSON_ARRAYAGG and JSON_OBJECTAGG results into json.RawMessage and []byte
+func TestJSONAggregate(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	// VARCHAR2 JSON is returned as a string, which scans into []byte
+	var arrayBytes []byte
+	err := TestDB.QueryRowContext(ctx, "select JSON_ARRAYAGG(level order by level) from dual connect by level <= 3").Scan(&arrayBytes)
+	if err != nil {
+		t.Fatal("scan error:", err)
+	}
+	if string(arrayBytes) != "[1,2,3]" {
+		t.Errorf("JSON_ARRAYAGG - received: %s - expected: %v", arrayBytes, "[1,2,3]")
+	}
+
+	// the JSON type, Oracle 21c or higher, is returned as []byte, which scans into json.RawMessage
+	var array json.RawMessage
+	err = TestDB.QueryRowContext(ctx, "select JSON_ARRAYAGG(level order by level returning JSON) from dual connect by level <= 3").Scan(&array)
+	if err != nil {
+		if strings.Contains(err.Error(), "ORA-") {
+			t.Skip("no JSON type:", err)
+		}
+		t.Fatal("scan error:", err)
+	}
+	var values []int
+	err = json.Unmarshal(array, &values)
+	if err != nil {
+		t.Fatal("unmarshal error:", err)
+	}
+	if !reflect.DeepEqual(values, []int{1, 2, 3}) {
+		t.Errorf("JSON_ARRAYAGG - received: %v - expected: %v", values, []int{1, 2, 3})
+	}
+
+	rows, err := TestDB.QueryContext(ctx, "select JSON_OBJECTAGG(KEY 'k' || level VALUE level returning JSON) from dual connect by level <= 2")
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+	defer rows.Close()
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatal("column types error:", err)
+	}
+	if columnTypes[0].ScanType() != reflect.TypeOf(json.RawMessage{}) {
+		t.Errorf("scan type - received: %v - expected: %v", columnTypes[0].ScanType(), reflect.TypeOf(json.RawMessage{}))
+	}
+	if !rows.Next() {
+		t.Fatal("no rows:", rows.Err())
+	}
+	var object json.RawMessage
+	err = rows.Scan(&object)
+	if err != nil {
+		t.Fatal("scan error:", err)
+	}
+	var objectValues map[string]int
+	err = json.Unmarshal(object, &objectValues)
+	if err != nil {
+		t.Fatal("unmarshal error:", err)
+	}
+	if !reflect.DeepEqual(objectValues, map[string]int{"k1": 1, "k2": 2}) {
+		t.Errorf("JSON_OBJECTAGG - received: %v - expected: %v", objectValues, map[string]int{"k1": 1, "k2": 2})
+	}
+}
+
+// TestFetchOneRow tests fetching the first row of a query into Go values
+func TestFetchOneRow(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	var count float64
+	var name string
+	err := conn.FetchOneRow(ctx, "select 3, 'abc' from dual", &count, &name)
+	if err != nil {
+		t.Fatal("fetch one row error:", err)
+	}
+	if count != 3 || name != "abc" {
+		t.Errorf("values - received: %v %v - expected: %v %v", count, name, 3, "abc")
+	}
+
+	err = conn.FetchOneRow(ctx, "select 1 from dual where 1 = 0", &count)
+	if err != sql.ErrNoRows {
+		t.Errorf("no rows error - received: %v - expected: %v", err, sql.ErrNoRows)
+	}
+
+	// only the first row is used
+	err = conn.FetchOneRow(ctx, "select level from dual connect by level <= 10 order by level", &count)
+	if err != nil {
+		t.Fatal("fetch one row error:", err)
+	}
+	if count != 1 {
+		t.Errorf("first row - received: %v - expected: %v", count, 1)
+	}
+
+	// OCI errors are returned directly
+	err = conn.FetchOneRow(ctx, "select * from no_such_table_"+TestTimeString, &count)
+	if err == nil || !strings.Contains(err.Error(), "ORA-00942") {
+		t.Errorf("error - received: %v - expected: %v", err, "ORA-00942")
+	}
+}
+
+// TestDestructiveStreamInsert tests StreamInsert of 50000 rows from a channel and stopping with an error row
+func TestDestructiveStreamInsert(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "STREAM_INSERT_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER, B VARCHAR2(20) )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	conn := testGetConn(t, "?batch_size=3000")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	rows := make(chan []interface{})
+	go func() {
+		for i := 0; i < 50000; i++ {
+			rows <- []interface{}{i, "row " + strconv.Itoa(i)}
+		}
+		close(rows)
+	}()
+
+	count, err := conn.StreamInsert(ctx, tableName, []string{"A", "B"}, rows)
+	if err != nil {
+		t.Fatal("stream insert error:", err)
+	}
+	if count != 50000 {
+		t.Fatalf("count - received: %v - expected: %v", count, 50000)
+	}
+
+	values, err := conn.queryRowArgs(ctx, "select count(*), count(distinct A) from "+tableName)
+	if err != nil {
+		t.Fatal("query row error:", err)
+	}
+	if values[0] != float64(50000) || values[1] != float64(50000) {
+		t.Fatalf("table count - received: %v - expected: %v", values, 50000)
+	}
+
+	streamErr := errors.New("stream error")
+	rows = make(chan []interface{})
+	go func() {
+		for i := 0; i < 3500; i++ {
+			rows <- []interface{}{i, "row " + strconv.Itoa(i)}
+		}
+		rows <- []interface{}{streamErr}
+	}()
+
+	count, err = conn.StreamInsert(ctx, tableName, []string{"A", "B"}, rows)
+	if err != streamErr {
+		t.Fatalf("stream insert error - received: %v - expected: %v", err, streamErr)
+	}
+	if count != 3000 {
+		t.Fatalf("count - received: %v - expected: %v", count, 3000)
+	}
+}
+
+// TestWithHint tests running a query with a WithHint optimizer hint and an invalid hint
+func TestWithHint(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	var value string
+	err := TestDB.QueryRowContext(WithHint(ctx, "FULL(dual)"), "select /*+ NO_PARALLEL */ DUMMY from dual").Scan(&value)
+	if err != nil {
+		t.Fatal("query row error:", err)
+	}
+	if value != "X" {
+		t.Fatalf("value - received: %v - expected: %v", value, "X")
+	}
+
+	err = TestDB.QueryRowContext(WithHint(ctx, "FULL(dual) */ x"), "select DUMMY from dual").Scan(&value)
+	if err == nil || err.Error() != "invalid hint: FULL(dual) */ x" {
+		t.Fatalf("query row error - received: %v - expected: invalid hint: FULL(dual) */ x", err)
+	}
+}
+
+// TestDestructiveGenerateSequenceValues tests GenerateSequenceValues returns increasing values in one round trip
+func TestDestructiveGenerateSequenceValues(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	sequenceName := "SEQUENCE_VALUES_" + TestTimeString
+	testExecQuery(t, "create sequence "+sequenceName+" start with 100 nocache", nil)
+	defer testExecQuery(t, "drop sequence "+sequenceName, nil)
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	roundTripsQuery := "select m.VALUE from V$MYSTAT m, V$STATNAME n where m.STATISTIC# = n.STATISTIC# and n.NAME = 'SQL*Net roundtrips to/from client'"
+	before, roundTripsErr := conn.queryRowArgs(ctx, roundTripsQuery)
+	// the round trips of the round trips query itself
+	overhead, _ := conn.queryRowArgs(ctx, roundTripsQuery)
+
+	values, err := conn.GenerateSequenceValues(ctx, sequenceName, 10)
+	if err != nil {
+		t.Fatal("generate sequence values error:", err)
+	}
+
+	after, _ := conn.queryRowArgs(ctx, roundTripsQuery)
+
+	if len(values) != 10 {
+		t.Fatalf("len values - received: %v - expected: %v", len(values), 10)
+	}
+	if values[0] != 100 {
+		t.Errorf("first value - received: %v - expected: %v", values[0], 100)
+	}
+	for i := 1; i < len(values); i++ {
+		if values[i] <= values[i-1] {
+			t.Errorf("values not increasing: %v", values)
+			break
+		}
+	}
+
+	if roundTripsErr != nil || overhead == nil || after == nil {
+		t.Log("no access to V$MYSTAT, round trips not measured")
+	} else {
+		roundTrips := (after[0].(float64) - overhead[0].(float64)) - (overhead[0].(float64) - before[0].(float64))
+		if roundTrips != 1 {
+			t.Errorf("round trips - received: %v - expected: %v", roundTrips, 1)
+		}
+	}
+
+	_, err = conn.GenerateSequenceValues(ctx, sequenceName, 0)
+	if err == nil || err.Error() != "invalid count: 0" {
+		t.Errorf("generate sequence values error - received: %v - expected: invalid count: 0", err)
+	}
+	_, err = conn.GenerateSequenceValues(ctx, "a;b", 1)
+	if err == nil || err.Error() != "invalid sequence name: a;b" {
+		t.Errorf("generate sequence values error - received: %v - expected: invalid sequence name: a;b", err)
+	}
+}
+
+// TestDestructiveAQ tests enqueueing and dequeueing RAW messages on a test queue
+func TestDestructiveAQ(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	queueTable := "AQ_TABLE_" + TestTimeString
+	queueName := "AQ_" + TestTimeString
+	err := testExec(t, "begin DBMS_AQADM.CREATE_QUEUE_TABLE(queue_table => '"+queueTable+"', queue_payload_type => 'RAW', sort_list => 'PRIORITY,ENQ_TIME'); "+
+		"DBMS_AQADM.CREATE_QUEUE(queue_name => '"+queueName+"', queue_table => '"+queueTable+"'); "+
+		"DBMS_AQADM.START_QUEUE(queue_name => '"+queueName+"'); end;", nil)
+	if err != nil {
+		if strings.Contains(err.Error(), "PLS-00201") {
+			t.Skip("no execute on DBMS_AQADM")
+		}
+		t.Fatal("create queue error:", err)
+	}
+	defer testExecQuery(t, "begin DBMS_AQADM.DROP_QUEUE_TABLE(queue_table => '"+queueTable+"', force => TRUE); end;", nil)
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	_, err = conn.DequeueMessage(ctx, queueName, DequeueOptions{})
+	if err != ErrAQTimeout {
+		t.Fatalf("dequeue message error - received: %v - expected: %v", err, ErrAQTimeout)
+	}
+
+	messages := []*AQMessage{
+		{Payload: []byte("low"), Correlation: "LOW", Priority: 5},
+		{Payload: []byte{0, 1, 2, 255}, Correlation: "HIGH", Priority: 1},
+	}
+	for _, msg := range messages {
+		err = conn.EnqueueMessage(ctx, queueName, msg)
+		if err != nil {
+			t.Fatal("enqueue message error:", err)
+		}
+		if len(msg.ID) != 16 {
+			t.Fatalf("message id - received: %v - expected 16 bytes", msg.ID)
+		}
+	}
+
+	msg, err := conn.DequeueMessage(ctx, queueName, DequeueOptions{Correlation: "LOW", Browse: true})
+	if err != nil {
+		t.Fatal("dequeue message error:", err)
+	}
+	if !bytes.Equal(msg.ID, messages[0].ID) || string(msg.Payload) != "low" {
+		t.Fatalf("browse message - received: %+v - expected: %+v", msg, messages[0])
+	}
+
+	for _, expected := range []*AQMessage{messages[1], messages[0]} {
+		msg, err = conn.DequeueMessage(ctx, queueName, DequeueOptions{Wait: time.Second})
+		if err != nil {
+			t.Fatal("dequeue message error:", err)
+		}
+		if !reflect.DeepEqual(msg, expected) {
+			t.Fatalf("dequeue message - received: %+v - expected: %+v", msg, expected)
+		}
+	}
+
+	_, err = conn.DequeueMessage(ctx, queueName, DequeueOptions{})
+	if err != ErrAQTimeout {
+		t.Fatalf("dequeue message error - received: %v - expected: %v", err, ErrAQTimeout)
+	}
+}
+
+// TestDestructiveListTables tests ListTables and ListColumns return a created table and its columns
+func TestDestructiveListTables(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "LIST_TABLES_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( ID NUMBER(10) NOT NULL, NAME VARCHAR2(50), PRICE NUMBER(8, 2), CREATED DATE )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	tables, err := conn.ListTables(ctx, "")
+	if err != nil {
+		t.Fatal("list tables error:", err)
+	}
+	var table *TableInfo
+	for i := range tables {
+		if tables[i].Name == tableName {
+			table = &tables[i]
+			break
+		}
+	}
+	if table == nil {
+		t.Fatalf("list tables - table %v not found", tableName)
+	}
+	if table.Owner == "" || table.Status != "VALID" {
+		t.Errorf("table - received: %+v - expected an owner and status: VALID", table)
+	}
+
+	columns, err := conn.ListColumns(ctx, "", strings.ToLower(tableName))
+	if err != nil {
+		t.Fatal("list columns error:", err)
+	}
+	expected := []ColumnInfo{
+		{Name: "ID", ID: 1, DataType: "NUMBER", DataLength: 22, Precision: 10},
+		{Name: "NAME", ID: 2, DataType: "VARCHAR2", DataLength: 50, Nullable: true},
+		{Name: "PRICE", ID: 3, DataType: "NUMBER", DataLength: 22, Precision: 8, Scale: 2, Nullable: true},
+		{Name: "CREATED", ID: 4, DataType: "DATE", DataLength: 7, Nullable: true},
+	}
+	if !reflect.DeepEqual(columns, expected) {
+		t.Fatalf("columns - received: %+v - expected: %+v", columns, expected)
+	}
+
+	columns, err = conn.ListColumns(ctx, "", "NOT_A_TABLE_"+TestTimeString)
+	if err != nil {
+		t.Fatal("list columns error:", err)
+	}
+	if len(columns) != 0 {
+		t.Fatalf("columns - received: %+v - expected: none", columns)
+	}
+}
+
+// TestDestructiveExportObjectDDL tests reading a table DDL with ExportObjectDDL then recreating the table with it
+func TestDestructiveExportObjectDDL(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "EXPORT_DDL_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( ID INTEGER PRIMARY KEY, NAME VARCHAR2(50) )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	exportDDL := func(objectType string) string {
+		reader, err := conn.ExportObjectDDL(ctx, objectType, "", tableName)
+		if err != nil {
+			t.Fatal("export object DDL error:", err)
+		}
+		defer reader.Close()
+		ddl, err := ioutil.ReadAll(reader)
+		if err != nil {
+			t.Fatal("read DDL error:", err)
+		}
+		return strings.TrimSpace(string(ddl))
+	}
+
+	ddl := exportDDL("TABLE")
+	if !strings.HasPrefix(ddl, "CREATE TABLE") || !strings.Contains(ddl, `"`+tableName+`"`) {
+		t.Fatalf("table DDL - received: %v - expected CREATE TABLE %v", ddl, tableName)
+	}
+
+	constraintDDL := exportDDL("CONSTRAINT")
+	if !strings.Contains(constraintDDL, "PRIMARY KEY") {
+		t.Fatalf("constraint DDL - received: %v - expected PRIMARY KEY", constraintDDL)
+	}
+
+	// the exported DDL is valid SQL if it recreates the table
+	testExecQuery(t, "drop table "+tableName, nil)
+	_, err = conn.execScriptStatement(ctx, ddl)
+	if err != nil {
+		t.Fatalf("exec DDL %v error: %v", ddl, err)
+	}
+
+	reader, err := conn.ExportObjectDDL(ctx, "TABLE", "", tableName)
+	if err != nil {
+		t.Fatal("export object DDL error:", err)
+	}
+	err = reader.Close()
+	if err != nil {
+		t.Fatal("close error:", err)
+	}
+	_, err = reader.Read(make([]byte, 10))
+	if err == nil {
+		t.Fatal("read after close error is nil")
+	}
+
+	_, err = conn.ExportObjectDDL(ctx, "TABLE", "", "NOT_A_TABLE_"+TestTimeString)
+	if err == nil || !strings.Contains(err.Error(), "ORA-31603") {
+		t.Fatalf("export object DDL error - received: %v - expected ORA-31603", err)
+	}
+}
+
+// TestTraceFile tests TraceFile returns a trace file path of the session
+func TestTraceFile(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	traceFile, err := conn.TraceFile()
+	if err != nil {
+		if strings.Contains(err.Error(), "ORA-00942") {
+			t.Skip("no select on V$DIAG_INFO")
+		}
+		t.Fatal("trace file error:", err)
+	}
+	if !strings.HasSuffix(traceFile, ".trc") || !strings.Contains(traceFile, "trace") {
+		t.Fatalf("trace file - received: %v - expected a trace directory .trc path", traceFile)
+	}
+}
+
+// TestLOBOut tests out binds that are always bound as a temporary CLOB or BLOB
+func TestLOBOut(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	for _, length := range []int{0, 10, 40000} {
+		var text string
+		err := conn.execArgs(ctx, "declare c clob; begin DBMS_LOB.CREATETEMPORARY(c, true); for i in 1 .. :1 loop DBMS_LOB.WRITEAPPEND(c, 1, 'a'); end loop; :2 := c; end;",
+			int64(length), lobOut{dest: &text})
+		if err != nil {
+			t.Fatal("CLOB out error:", err)
+		}
+		if text != strings.Repeat("a", length) {
+			t.Errorf("CLOB out length - received: %v - expected: %v", len(text), length)
+		}
+
+		var data []byte
+		err = conn.execArgs(ctx, "declare b blob; begin DBMS_LOB.CREATETEMPORARY(b, true); for i in 1 .. :1 loop DBMS_LOB.WRITEAPPEND(b, 1, hextoraw('01')); end loop; :2 := b; end;",
+			int64(length), lobOut{dest: &data})
+		if err != nil {
+			t.Fatal("BLOB out error:", err)
+		}
+		if !bytes.Equal(data, bytes.Repeat([]byte{1}, length)) {
+			t.Errorf("BLOB out length - received: %v - expected: %v", len(data), length)
+		}
+	}
+
+	var number int64
+	err := conn.execArgs(ctx, "begin :1 := 1; end;", lobOut{dest: &number})
+	if err == nil {
+		t.Fatal("int64 LOB out error is nil")
+	}
+}
+
+// TestDestructiveLargePLSQL tests executing a 40000 byte PL/SQL block with exec and query, and as a LargePLSQL bind
+func TestDestructiveLargePLSQL(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "LARGE_PLSQL_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	var block strings.Builder
+	block.WriteString("declare n number := 0; begin ")
+	for block.Len() < 40000 {
+		block.WriteString("n := n + 1; ")
+	}
+	block.WriteString("insert into " + tableName + " ( A ) values ( n ); end;")
+	count := strings.Count(block.String(), "n := n + 1;")
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	_, err = TestDB.ExecContext(ctx, block.String())
+	if err != nil {
+		t.Fatal("exec error:", err)
+	}
+
+	_, err = TestDB.ExecContext(ctx, "begin execute immediate :1; end;", LargePLSQL{Text: block.String()})
+	if err != nil {
+		t.Fatal("exec LargePLSQL error:", err)
+	}
+
+	rows, err := TestDB.QueryContext(ctx, block.String())
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+	err = rows.Close()
+	if err != nil {
+		t.Fatal("rows close error:", err)
+	}
+
+	queryResults := testQueryResults{
+		query: "select A from " + tableName,
+		queryResults: []testQueryResult{
+			{
+				results: [][]interface{}{{int64(count)}, {int64(count)}, {int64(count)}},
+			},
+		},
+	}
+	testRunQueryResults(t, queryResults)
+
+	_, err = TestDB.ExecContext(ctx, block.String(), 1)
+	if err == nil {
+		t.Fatal("exec with bind error is nil")
+	}
+}
+
+// TestDestructiveLockTable tests a conflicting NOWAIT table lock gets ORA-00054 until the lock is rolled back
+func TestDestructiveLockTable(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "LOCK_TABLE_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	conn1 := testGetConn(t, "")
+	defer conn1.Close()
+	conn2 := testGetConn(t, "")
+	defer conn2.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	err = conn1.LockTable(ctx, tableName, LockExclusive, false)
+	if err == nil || err.Error() != "lock table needs a transaction" {
+		t.Fatalf("lock table error - received: %v - expected: lock table needs a transaction", err)
+	}
+
+	tx1, err := conn1.BeginTx(ctx, driver.TxOptions{})
+	if err != nil {
+		t.Fatal("begin tx error:", err)
+	}
+	err = conn1.LockTable(ctx, tableName, LockExclusive, false)
+	if err != nil {
+		t.Fatal("lock table error:", err)
+	}
+
+	tx2, err := conn2.BeginTx(ctx, driver.TxOptions{})
+	if err != nil {
+		t.Fatal("begin tx error:", err)
+	}
+	err = conn2.LockTable(ctx, tableName, LockShared, false)
+	if err == nil || !strings.Contains(err.Error(), "ORA-00054") {
+		t.Fatalf("lock table error - received: %v - expected ORA-00054", err)
+	}
+
+	err = tx1.Rollback()
+	if err != nil {
+		t.Fatal("rollback error:", err)
+	}
+
+	err = conn2.LockTable(ctx, tableName, LockShared, false)
+	if err != nil {
+		t.Fatal("lock table error:", err)
+	}
+	err = tx2.Commit()
+	if err != nil {
+		t.Fatal("commit error:", err)
+	}
+}
+
+// TestDestructiveWorkspace tests the Workspace Manager lifecycle of a version-enabled table:
+// create a workspace, go to it, change a row, merge it into LIVE, then remove it
+func TestDestructiveWorkspace(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "WORKSPACE_" + TestTimeString
+	workspaceName := "GO_OCI8_WS_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A INTEGER PRIMARY KEY, B VARCHAR2(20) )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+	defer testDropTable(t, tableName)
+
+	err = testExec(t, "begin DBMS_WM.EnableVersioning('"+tableName+"'); end;", nil)
+	if err != nil {
+		if strings.Contains(err.Error(), "PLS-00201") || strings.Contains(err.Error(), "ORA-20") {
+			t.Skip("no Workspace Manager access:", err)
+		}
+		t.Fatal("enable versioning error:", err)
+	}
+	defer testExecQuery(t, "begin DBMS_WM.DisableVersioning('"+tableName+"', true); end;", nil)
+	testExecQuery(t, "insert into "+tableName+" ( A, B ) values ( 1, 'live' )", nil)
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	err = conn.CreateWorkspace(ctx, workspaceName)
+	if err != nil {
+		t.Fatal("create workspace error:", err)
+	}
+	defer conn.RemoveWorkspace(context.Background(), workspaceName)
+
+	err = conn.GotoWorkspace(ctx, workspaceName)
+	if err != nil {
+		t.Fatal("goto workspace error:", err)
+	}
+	err = conn.execArgs(ctx, "update "+tableName+" set B = :1 where A = 1", "workspace")
+	if err != nil {
+		t.Fatal("update error:", err)
+	}
+
+	err = conn.GotoWorkspace(ctx, "LIVE")
+	if err != nil {
+		t.Fatal("goto workspace error:", err)
+	}
+	values, err := conn.queryRowArgs(ctx, "select B from "+tableName+" where A = 1")
+	if err != nil {
+		t.Fatal("query row error:", err)
+	}
+	if values[0] != "live" {
+		t.Fatalf("LIVE value before merge - received: %v - expected: %v", values[0], "live")
+	}
+
+	err = conn.MergeWorkspace(ctx, workspaceName, "NOT_THE_PARENT")
+	if err == nil {
+		t.Fatal("merge workspace into not the parent error is nil")
+	}
+	err = conn.MergeWorkspace(ctx, workspaceName, "LIVE")
+	if err != nil {
+		t.Fatal("merge workspace error:", err)
+	}
+
+	values, err = conn.queryRowArgs(ctx, "select B from "+tableName+" where A = 1")
+	if err != nil {
+		t.Fatal("query row error:", err)
+	}
+	if values[0] != "workspace" {
+		t.Fatalf("LIVE value after merge - received: %v - expected: %v", values[0], "workspace")
+	}
+
+	err = conn.RemoveWorkspace(ctx, workspaceName)
+	if err != nil {
+		t.Fatal("remove workspace error:", err)
+	}
+}
+
+// TestDestructiveExecParallel tests ExecParallel creating 5 indexes with 3 connections, and a failed statement not stopping the others
+func TestDestructiveExecParallel(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	var stmts []string
+	var indexNames []interface{}
+	for i := 0; i < 5; i++ {
+		tableName := "EXEC_PAR_" + strconv.Itoa(i) + "_" + TestTimeString
+		err := testExec(t, "create table "+tableName+" ( A INTEGER, B VARCHAR2(20) )", nil)
+		if err != nil {
+			t.Fatal("create table error:", err)
+		}
+		defer testDropTable(t, tableName)
+		testExecQuery(t, "insert into "+tableName+" ( A, B ) select level, 'b' || level from dual connect by level <= 1000", nil)
+
+		indexName := "EXEC_PAR_I" + strconv.Itoa(i) + "_" + TestTimeString
+		stmts = append(stmts, "create index "+indexName+" on "+tableName+" ( A, B )")
+		indexNames = append(indexNames, indexName)
+	}
+	stmts = append(stmts[:2], append([]string{"create index EXEC_PAR_X on NOT_A_TABLE_" + TestTimeString + " ( A )"}, stmts[2:]...)...)
+
+	conn := testGetConn(t, "?max_parallel=3")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*TestContextTimeout)
+	defer cancel()
+
+	errs := conn.ExecParallel(ctx, stmts)
+	if len(errs) != len(stmts) {
+		t.Fatalf("len errors - received: %v - expected: %v", len(errs), len(stmts))
+	}
+	for i, err := range errs {
+		if i == 2 {
+			if err == nil || !strings.Contains(err.Error(), "ORA-00942") {
+				t.Errorf("exec parallel %v error - received: %v - expected ORA-00942", stmts[i], err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("exec parallel %v error: %v", stmts[i], err)
+		}
+	}
+
+	values, err := conn.queryRowArgs(ctx, "select count(1) from USER_INDEXES where INDEX_NAME in (:1, :2, :3, :4, :5)", indexNames...)
+	if err != nil {
+		t.Fatal("query row error:", err)
+	}
+	if values[0] != float64(5) {
+		t.Fatalf("indexes - received: %v - expected: %v", values[0], 5)
+	}
+}
+
+// TestDBMSOutput tests DBMS_OUTPUT lines are logged when rows are closed and when the connection is closed
+func TestDBMSOutput(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "?dbms_output_buffer=100000")
+	var buffer bytes.Buffer
+	conn.logger = log.New(&buffer, "", 0)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+
+	err := conn.execArgs(ctx, "begin DBMS_OUTPUT.PUT_LINE('hello'); DBMS_OUTPUT.PUT_LINE(:1); end;", "world")
+	if err != nil {
+		t.Fatal("exec error:", err)
+	}
+	if buffer.Len() != 0 {
+		t.Fatalf("log before rows close - received: %q - expected none", buffer.String())
+	}
+
+	_, err = conn.queryRowArgs(ctx, "select 1 from dual")
+	if err != nil {
+		t.Fatal("query row error:", err)
+	}
+	expected := "DBMS_OUTPUT: hello\nDBMS_OUTPUT: world\n"
+	if buffer.String() != expected {
+		t.Fatalf("log after rows close - received: %q - expected: %q", buffer.String(), expected)
+	}
+
+	err = conn.execArgs(ctx, "begin DBMS_OUTPUT.PUT_LINE(rpad('x', 1000, 'x')); DBMS_OUTPUT.PUT_LINE(rpad('y', 32000, 'y')); DBMS_OUTPUT.PUT_LINE(rpad('z', 1000, 'z')); end;")
+	if err != nil {
+		t.Fatal("exec error:", err)
+	}
+	lines, err := conn.FlushDBMSOutput(ctx)
+	if err != nil {
+		t.Fatal("flush DBMS_OUTPUT error:", err)
+	}
+	expectedLines := []string{strings.Repeat("x", 1000), strings.Repeat("y", 32000), strings.Repeat("z", 1000)}
+	if !reflect.DeepEqual(lines, expectedLines) {
+		t.Fatalf("lines - received: %v lines - expected: %v lines", len(lines), len(expectedLines))
+	}
+	lines, err = conn.FlushDBMSOutput(ctx)
+	if err != nil {
+		t.Fatal("flush DBMS_OUTPUT error:", err)
+	}
+	if len(lines) != 0 {
+		t.Fatalf("lines - received: %v - expected none", lines)
+	}
+
+	buffer.Reset()
+	err = conn.execArgs(ctx, "begin DBMS_OUTPUT.PUT_LINE('goodbye'); end;")
+	if err != nil {
+		t.Fatal("exec error:", err)
+	}
+	err = conn.Close()
+	if err != nil {
+		t.Fatal("close error:", err)
+	}
+	if buffer.String() != "DBMS_OUTPUT: goodbye\n" {
+		t.Fatalf("log after connection close - received: %q - expected: %q", buffer.String(), "DBMS_OUTPUT: goodbye\n")
+	}
+}
+
+// TestTransactionName tests setting a global transaction id and two-phase prepare
+func TestTransactionName(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	driverTx, err := conn.BeginTx(ctx, driver.TxOptions{})
+	cancel()
+	if err != nil {
+		t.Fatal("begin tx error:", err)
+	}
+	tx := driverTx.(*OCI8Tx)
+
+	err = tx.SetTransactionName("GTRID"+TestTimeString, "BQUAL", 1)
+	if err != nil {
+		tx.Rollback()
+		t.Fatal("set transaction name error:", err)
+	}
+
+	// nothing changed so the branch is read-only
+	err = tx.Prepare()
+	if err != nil {
+		tx.Rollback()
+		t.Fatal("prepare error:", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		t.Fatal("commit error:", err)
+	}
+	if tx.trans != nil {
+		t.Error("transaction handle is not freed by commit")
+	}
+
+	// a second global transaction on the connection uses a new transaction handle
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	driverTx, err = conn.BeginTx(ctx, driver.TxOptions{})
+	cancel()
+	if err != nil {
+		t.Fatal("begin tx error:", err)
+	}
+	tx = driverTx.(*OCI8Tx)
+	err = tx.SetTransactionName("GTRID2"+TestTimeString, "BQUAL", 1)
+	if err != nil {
+		tx.Rollback()
+		t.Fatal("set transaction name error:", err)
+	}
+	err = tx.Rollback()
+	if err != nil {
+		t.Fatal("rollback error:", err)
+	}
+	if tx.trans != nil {
+		t.Error("transaction handle is not freed by rollback")
+	}
+
+	// prepare of a transaction without a global transaction id
+	ctx, cancel = context.WithTimeout(context.Background(), TestContextTimeout)
+	driverTx, err = conn.BeginTx(ctx, driver.TxOptions{})
+	cancel()
+	if err != nil {
+		t.Fatal("begin tx error:", err)
+	}
+	tx = driverTx.(*OCI8Tx)
+	err = tx.Prepare()
+	if err != nil && (len(err.Error()) < 4 || err.Error()[0:4] != "ORA-") {
+		t.Fatalf("prepare error - received: %v - expected ORA- error or nil", err)
+	}
+	err = tx.Rollback()
+	if err != nil {
+		t.Fatal("rollback error:", err)
+	}
+}
+
+// TestDestructiveExecuteScript tests running a script with a PL/SQL block
+func TestDestructiveExecuteScript(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "EXECUTE_SCRIPT_" + TestTimeString
+	script := "create table " + tableName + " ( A INTEGER );\n" +
+		"begin\n  insert into " + tableName + " ( A ) values ( 1 );\n  insert into " + tableName + " ( A ) values ( 2 );\nend;\n/\n" +
+		"update " + tableName + " set A = A + 1;\n"
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+	defer testDropTable(t, tableName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	results, err := conn.ExecuteScript(ctx, script)
+	cancel()
+	if err != nil {
+		t.Fatal("execute script error:", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("len results - received: %v - expected: %v", len(results), 3)
+	}
+
+	rowsAffected, err := results[2].RowsAffected()
+	if err != nil {
+		t.Fatal("rows affected error:", err)
+	}
+	if rowsAffected != 2 {
+		t.Fatalf("rows affected - received: %v - expected: %v", rowsAffected, 2)
+	}
+}
+
+// TestSelectRowLimiting tests 12c row limiting clause with bind parameters
+func TestSelectRowLimiting(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	db := testGetDB("?questionph=true")
+	if db == nil {
+		t.Fatal("db is null")
+	}
+	defer db.Close()
+
+	queries := []struct {
+		db    *sql.DB
+		query string
+		args  []interface{}
+	}{
+		{db: TestDB, query: "select level from dual connect by level <= 10 order by 1 offset :off rows fetch next :lim rows only", args: []interface{}{sql.Named("off", 3), sql.Named("lim", 4)}},
+		{db: TestDB, query: "select level from dual connect by level <= 10 order by 1 offset :1 rows fetch next :2 rows only", args: []interface{}{3, 4}},
+		{db: db, query: "select level from dual connect by level <= 10 order by 1 offset ? rows fetch next ? rows only", args: []interface{}{3, 4}},
+	}
+
+	for _, query := range queries {
+		ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+		rows, err := query.db.QueryContext(ctx, query.query, query.args...)
+		if err != nil {
+			cancel()
+			if strings.HasPrefix(err.Error(), "ORA-00933") {
+				// row limiting clause needs Oracle 12c or higher
+				t.Skip("row limiting not supported:", err)
+			}
+			t.Fatal("query error:", err)
+		}
+
+		var levels []int64
+		for rows.Next() {
+			var level int64
+			err = rows.Scan(&level)
+			if err != nil {
+				rows.Close()
+				cancel()
+				t.Fatal("scan error:", err)
+			}
+			levels = append(levels, level)
+		}
+		err = rows.Err()
+		rows.Close()
+		cancel()
+		if err != nil {
+			t.Fatal("rows error:", err)
+		}
+
+		expected := []int64{4, 5, 6, 7}
+		if !reflect.DeepEqual(levels, expected) {
+			t.Fatalf("query %v - received: %v - expected: %v", query.query, levels, expected)
+		}
+	}
+}
+
+// TestColumnNameMap tests mapping column names to column index
+func TestColumnNameMap(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	conn := testGetConn(t, "")
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	stmt, err := conn.PrepareContext(ctx, "select 1 as one, 2 as \"Two\", 3 as three, 4 as one from dual")
+	if err != nil {
+		t.Fatal("prepare error:", err)
+	}
+	defer stmt.Close()
+
+	driverRows, err := stmt.(*OCI8Stmt).QueryContext(ctx, nil)
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+	rows := driverRows.(*OCI8Rows)
+
+	columnNameMap, err := rows.ColumnNameMap()
+	if err != nil {
+		t.Fatal("column name map error:", err)
+	}
+	expected := map[string]int{"ONE": 0, "TWO": 1, "THREE": 2}
+	if !reflect.DeepEqual(columnNameMap, expected) {
+		t.Fatalf("column name map - received: %v - expected: %v", columnNameMap, expected)
+	}
+	if index, ok := columnNameMap[strings.ToUpper("Three")]; !ok || index != 2 {
+		t.Fatalf("column name map Three - received: %v - expected: %v", index, 2)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		t.Fatal("rows close error:", err)
+	}
+	_, err = rows.ColumnNameMap()
+	if err == nil {
+		t.Fatal("column name map closed error is nil")
+	}
+}
+
 func BenchmarkSimpleInsert(b *testing.B) {
 	if TestDisableDatabase || TestDisableDestructive {
 		b.SkipNow()
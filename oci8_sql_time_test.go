@@ -708,6 +708,55 @@ func TestDestructiveTime(t *testing.T) {
 	testRunQueryResults(t, queryResults)
 }
 
+// TestDestructiveTimestampPrecision checks fractional seconds precision of TIMESTAMP(n)
+func TestDestructiveTimestampPrecision(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	tableName := "TIMESTAMP_PREC_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A int, B TIMESTAMP(0), C TIMESTAMP(3), D TIMESTAMP(6), E TIMESTAMP(3) WITH TIME ZONE )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+
+	defer testDropTable(t, tableName)
+
+	rowsTimestamp := [][]interface{}{
+		{
+			1,
+			time.Date(2099, 1, 2, 3, 4, 5, 123456000, time.UTC),
+			time.Date(2099, 1, 2, 3, 4, 5, 123456000, time.UTC),
+			time.Date(2099, 1, 2, 3, 4, 5, 123456000, time.UTC),
+			time.Date(2099, 1, 2, 3, 4, 5, 123456000, timeLocations[5]),
+		},
+	}
+	resultsTimestamp := [][]interface{}{
+		{
+			int64(1),
+			time.Date(2099, 1, 2, 3, 4, 5, 0, time.UTC),
+			time.Date(2099, 1, 2, 3, 4, 5, 123000000, time.UTC),
+			time.Date(2099, 1, 2, 3, 4, 5, 123456000, time.UTC),
+			time.Date(2099, 1, 2, 3, 4, 5, 123000000, timeLocations[5]),
+		},
+	}
+
+	err = testExecRows(t, "insert into "+tableName+" ( A, B, C, D, E ) values (:1, :2, :3, :4, :5)", rowsTimestamp)
+	if err != nil {
+		t.Error("insert error:", err)
+	}
+
+	queryResults := testQueryResults{
+		query: "select A, B, C, D, E from " + tableName + " order by A",
+		queryResults: []testQueryResult{
+			{
+				results: resultsTimestamp,
+			},
+		},
+	}
+	testRunQueryResults(t, queryResults)
+}
+
 func TestDestructiveTimeColumnTypes(t *testing.T) {
 	if TestDisableDatabase || TestDisableDestructive {
 		t.SkipNow()
@@ -905,3 +954,133 @@ func TestDestructiveTimeColumnTypes(t *testing.T) {
 	}
 
 }
+
+// TestSessionTimeZone tests that session_timezone changes the time zone of TIMESTAMP WITH LOCAL TIME ZONE values
+func TestSessionTimeZone(t *testing.T) {
+	if TestDisableDatabase {
+		t.SkipNow()
+	}
+
+	expected := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	query := "select cast(timestamp '2006-01-02 15:04:05 +00:00' as TIMESTAMP WITH LOCAL TIME ZONE) from dual"
+
+	for _, test := range []struct {
+		timeZone string
+		offset   int
+	}{
+		{"%2B00:00", 0},
+		{"-07:00", -7 * 60 * 60},
+	} {
+		db := testGetDB("?session_timezone=" + test.timeZone)
+		if db == nil {
+			t.Fatal("db is null")
+		}
+
+		var aTime time.Time
+		ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+		err := db.QueryRowContext(ctx, query).Scan(&aTime)
+		cancel()
+		db.Close()
+		if err != nil {
+			t.Fatal("query error:", err)
+		}
+
+		if !aTime.Equal(expected) {
+			t.Errorf("time - received: %v - expected: %v", aTime, expected)
+		}
+		_, offset := aTime.Zone()
+		if offset != test.offset {
+			t.Errorf("time zone offset - received: %v - expected: %v", offset, test.offset)
+		}
+	}
+}
+
+// TestDestructiveTimestampTZOffset tests that TIMESTAMP WITH TIME ZONE values keep the time zone offset they were inserted with,
+// including daylight saving time and half hour and 45 minute offsets
+func TestDestructiveTimestampTZOffset(t *testing.T) {
+	if TestDisableDatabase || TestDisableDestructive {
+		t.SkipNow()
+	}
+
+	var times []time.Time
+	for _, test := range []struct {
+		name  string
+		times []time.Time
+	}{
+		// Australia/Sydney is +11:00 in January and +10:00 in July, in 1990 Australia/Brisbane also had daylight saving time
+		{"Australia/Sydney", []time.Time{
+			time.Date(2099, 1, 2, 3, 4, 5, 0, time.UTC), time.Date(2099, 7, 2, 3, 4, 5, 0, time.UTC),
+			time.Date(1990, 1, 2, 3, 4, 5, 0, time.UTC), time.Date(1990, 7, 2, 3, 4, 5, 0, time.UTC)}},
+		// America/New_York is -05:00 in January and -04:00 in July
+		{"America/New_York", []time.Time{
+			time.Date(2099, 1, 2, 3, 4, 5, 0, time.UTC), time.Date(2099, 7, 2, 3, 4, 5, 0, time.UTC)}},
+		// Asia/Kathmandu is +05:45, and was +05:30 before 1986
+		{"Asia/Kathmandu", []time.Time{
+			time.Date(2099, 1, 2, 3, 4, 5, 0, time.UTC), time.Date(1985, 1, 2, 3, 4, 5, 0, time.UTC)}},
+	} {
+		location, err := time.LoadLocation(test.name)
+		if err != nil {
+			t.Logf("load location %v error: %v", test.name, err)
+			continue
+		}
+		for _, aTime := range test.times {
+			times = append(times, aTime.In(location))
+		}
+	}
+	if len(times) < 1 {
+		t.Skip("no time zone locations")
+	}
+
+	tableName := "TIMESTAMP_TZ_" + TestTimeString
+	err := testExec(t, "create table "+tableName+" ( A int, B TIMESTAMP(9) WITH TIME ZONE )", nil)
+	if err != nil {
+		t.Fatal("create table error:", err)
+	}
+
+	defer testDropTable(t, tableName)
+
+	rows := make([][]interface{}, len(times))
+	for i, aTime := range times {
+		rows[i] = []interface{}{i, aTime}
+	}
+	err = testExecRows(t, "insert into "+tableName+" ( A, B ) values (:1, :2)", rows)
+	if err != nil {
+		t.Fatal("insert error:", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TestContextTimeout)
+	defer cancel()
+	result, err := TestDB.QueryContext(ctx, "select A, B from "+tableName+" order by A")
+	if err != nil {
+		t.Fatal("query error:", err)
+	}
+	defer result.Close()
+
+	var count int
+	for result.Next() {
+		var index int
+		var aTime time.Time
+		err = result.Scan(&index, &aTime)
+		if err != nil {
+			t.Fatal("scan error:", err)
+		}
+		count++
+
+		expected := times[index]
+		if !aTime.Equal(expected) {
+			t.Errorf("time - received: %v - expected: %v", aTime, expected)
+		}
+		_, offset := aTime.Zone()
+		_, expectedOffset := expected.Zone()
+		if offset != expectedOffset {
+			t.Errorf("time zone offset of %v - received: %v - expected: %v", expected, offset, expectedOffset)
+		}
+	}
+	err = result.Err()
+	if err != nil {
+		t.Fatal("rows error:", err)
+	}
+	if count != len(times) {
+		t.Errorf("rows - received: %v - expected: %v", count, len(times))
+	}
+}
@@ -4,13 +4,20 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // to run database tests
@@ -193,6 +200,46 @@ func TestParseDSN(t *testing.T) {
 		{"sys/syspwd@107.20.30.169:1521/ORCL?loc=America%2FPhoenix&as=sysdba", &DSN{Username: "sys", Password: "syspwd", Connect: "107.20.30.169:1521/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: timeLocations[5], operationMode: 0x00000002}}, // with operationMode: 0x00000002 = C.OCI_SYDBA
 		{"xxmc/xxmc@107.20.30.169:1521/ORCL", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169:1521/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC}},
 		{"xxmc/xxmc@107.20.30.169/ORCL", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?keepalive=60s", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, keepAlive: time.Minute}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?lob_inline_threshold=32767", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, lobInlineThreshold: 32767}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?lock_timeout=10s", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, lockTimeout: 10 * time.Second}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?session_timezone=America%2FPhoenix", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, sessionTimeZone: "America/Phoenix"}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?session_timezone=%2B07:00", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, sessionTimeZone: "+07:00"}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?network_compression=on", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, networkCompression: "on"}},
+		{"@orcl_alias?password_store=wallet", &DSN{Connect: "orcl_alias", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, passwordStoreWallet: true}},
+		{"xxmc@orcl_alias?password_store=wallet", &DSN{Username: "xxmc", Connect: "orcl_alias", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, passwordStoreWallet: true}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?autoreturn_rowid=true", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, autoReturnRowid: true}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?unicode_normalization=NFC", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, normalizeUnicode: true, unicodeNormalization: norm.NFC}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?unicode_normalization=NFKD", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, normalizeUnicode: true, unicodeNormalization: norm.NFKD}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?default_edition=ora$base", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, defaultEdition: "ORA$BASE"}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?smart_alloc=true", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, smartAlloc: true}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?oci_thread_mode=threaded", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?oci_thread_mode=no_mutex", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, noMutex: true}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?lob_prefetch_size=4096", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, lobPrefetchSize: 4096}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?xml_as_clob=true", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, xmlAsCLOB: true}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?object_as_json=true", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, objectAsJSON: true}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?retry_on_errors=60,8177,ORA-00054&retry_count=3&retry_delay=100ms", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, retryOnErrors: []int{60, 8177, 54}, retryCount: 3, retryDelay: 100 * time.Millisecond}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?batch_size=5000", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, batchSize: 5000}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?max_parallel=8", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, maxParallel: 8}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?dbms_output_buffer=20000", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, dbmsOutputBuffer: 20000}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?new_password=yyzz", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, newPassword: "yyzz"}},
+		{"xxmc/xxmc@//107.20.30.169:1521/ORCL", &DSN{Username: "xxmc", Password: "xxmc", Connect: "//107.20.30.169:1521/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC}},
+		{"xxmc/xxmc@//107.20.30.169/ORCL", &DSN{Username: "xxmc", Password: "xxmc", Connect: "//107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC}},
+		{"xxmc/xxmc@107.20.30.169:1521/ORCL:DEDICATED", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169:1521/ORCL:DEDICATED", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, serverType: "DEDICATED"}},
+		{"xxmc/xxmc@//107.20.30.169:1521/ORCL:DEDICATED", &DSN{Username: "xxmc", Password: "xxmc", Connect: "//107.20.30.169:1521/ORCL:DEDICATED", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, serverType: "DEDICATED"}},
+		{"xxmc/xxmc@//107.20.30.169:1521/ORCL:DEDICATED/ORCL1", &DSN{Username: "xxmc", Password: "xxmc", Connect: "//107.20.30.169:1521/ORCL:DEDICATED/ORCL1", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, serverType: "DEDICATED"}},
+		{"xxmc/xxmc@//107.20.30.169/ORCL:shared/ORCL1", &DSN{Username: "xxmc", Password: "xxmc", Connect: "//107.20.30.169/ORCL:shared/ORCL1", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, serverType: "SHARED"}},
+		{"xxmc/xxmc@107.20.30.169/ORCL:POOLED", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL:POOLED", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, serverType: "POOLED"}},
+		{"xxmc/xxmc@107.20.30.169:1521/ORCL/ORCL1", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169:1521/ORCL/ORCL1", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC}},
+		{"xxmc/xxmc@//[::1]:1521/ORCL:DEDICATED/ORCL1", &DSN{Username: "xxmc", Password: "xxmc", Connect: "//[::1]:1521/ORCL:DEDICATED/ORCL1", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, serverType: "DEDICATED"}},
+		{"xxmc/xxmc@tcps://107.20.30.169:2484/ORCL:DEDICATED", &DSN{Username: "xxmc", Password: "xxmc", Connect: "tcps://107.20.30.169:2484/ORCL:DEDICATED", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, serverType: "DEDICATED"}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?max_rows=500", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, maxRows: 500}},
+		{"sys/syspwd@107.20.30.169/ORCL?as=sysdba&prelim_auth=true", &DSN{Username: "sys", Password: "syspwd", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC, prelimAuth: true,
+			operationMode: 0x0000000a}}, // with operationMode: 0x0000000a = C.OCI_SYSDBA | C.OCI_PRELIM_AUTH
+		{"xxmc/xxmc@107.20.30.169/ORCL?sharding_key=abc", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC,
+			shardingKey: &OCI8ShardingKey{components: []interface{}{"abc"}}}},
+		{"xxmc/xxmc@107.20.30.169/ORCL?sharding_key=abc&sharding_key=123&super_sharding_key=east", &DSN{Username: "xxmc", Password: "xxmc", Connect: "107.20.30.169/ORCL", prefetchRows: prefetchRows, prefetchMemory: prefetchMemory, timeLocation: time.UTC,
+			shardingKey: &OCI8ShardingKey{components: []interface{}{"abc", "123"}}, superShardingKey: &OCI8ShardingKey{components: []interface{}{"east"}}}},
 	}
 
 	for _, tt := range dsnTests {
@@ -207,3 +254,1551 @@ func TestParseDSN(t *testing.T) {
 		}
 	}
 }
+
+// TestSetTransactionNameInvalid tests SetTransactionName xid validation
+func TestSetTransactionNameInvalid(t *testing.T) {
+	tx := &OCI8Tx{}
+
+	err := tx.SetTransactionName("", "bqual", 1)
+	if err == nil {
+		t.Fatal("empty gtrid error is nil")
+	}
+	err = tx.SetTransactionName(strings.Repeat("g", 65), "bqual", 1)
+	if err == nil {
+		t.Fatal("long gtrid error is nil")
+	}
+	err = tx.SetTransactionName("gtrid", strings.Repeat("b", 65), 1)
+	if err == nil {
+		t.Fatal("long bqual error is nil")
+	}
+}
+
+// TestSplitScript tests splitting a SQL script into statements
+func TestSplitScript(t *testing.T) {
+	var scriptTests = []struct {
+		script     string
+		statements []string
+	}{
+		{"", nil},
+		{"-- comment only\n", nil},
+		{"select 1 from dual", []string{"select 1 from dual"}},
+		{"select 1 from dual;\nselect 2 from dual;", []string{"select 1 from dual", "select 2 from dual"}},
+		{"select 1 from dual\n/\nselect 2 from dual\n/\n", []string{"select 1 from dual", "select 2 from dual"}},
+		{"select 'a;b' from dual; select \"A;B\" from t;", []string{"select 'a;b' from dual", "select \"A;B\" from t"}},
+		{"select 'it''s;' from dual;", []string{"select 'it''s;' from dual"}},
+		{"select q'[it's;]' from dual; select nq'{;}' from dual;", []string{"select q'[it's;]' from dual", "select nq'{;}' from dual"}},
+		{"select 1 -- one; two\nfrom dual;", []string{"select 1 -- one; two\nfrom dual"}},
+		{"select 1 /* one;\n/\n */ from dual;", []string{"select 1 /* one;\n/\n */ from dual"}},
+		{"select 4 / 2 from dual;", []string{"select 4 / 2 from dual"}},
+		{"create table t (a number);\nbegin\n  insert into t values (1);\n  commit;\nend;\n/\ndrop table t;",
+			[]string{"create table t (a number)", "begin\n  insert into t values (1);\n  commit;\nend;", "drop table t"}},
+		{"declare\n  a number;\nbegin\n  a := 1;\nend;", []string{"declare\n  a number;\nbegin\n  a := 1;\nend;"}},
+		{"/* header */ create or replace procedure p as\nbegin\n  null;\nend;\n/\nCREATE OR REPLACE EDITIONABLE PACKAGE k AS\n  procedure p;\nEND k;\n  /  \ncreate or replace view v as select 1 a from dual;",
+			[]string{"/* header */ create or replace procedure p as\nbegin\n  null;\nend;", "CREATE OR REPLACE EDITIONABLE PACKAGE k AS\n  procedure p;\nEND k;", "create or replace view v as select 1 a from dual"}},
+	}
+
+	for _, tt := range scriptTests {
+		statements := splitScript(tt.script)
+		if !reflect.DeepEqual(statements, tt.statements) {
+			t.Errorf("splitScript(%q): expected %q, actual %q", tt.script, tt.statements, statements)
+		}
+	}
+}
+
+// TestParseScript tests replacing SQL*Plus substitution variables
+func TestParseScript(t *testing.T) {
+	vars := map[string]string{"table": "EMP", "owner": "HR", "name": "O'Brien", "id": "10", "column": "a b", "alias": `x"y`, "close": "]' or 1=1 --"}
+
+	var scriptTests = []struct {
+		script   string
+		options  []ScriptOption
+		expected string
+		err      bool
+	}{
+		{script: "select * from &table where ID = &id", expected: "select * from EMP where ID = 10"},
+		{script: "select * from &owner..&&TABLE.", expected: "select * from HR.EMP"},
+		{script: "select * from &table._HISTORY", expected: "select * from EMP_HISTORY"},
+		{script: "insert into T values ('&name', '&id')", expected: "insert into T values ('O''Brien', '10')"},
+		{script: "select 'it''s &name' from dual", expected: "select 'it''s O''Brien' from dual"},
+		{script: "select 1 \"&table\" from dual", expected: "select 1 \"EMP\" from dual"},
+		{script: "select a & b, '&' from dual", expected: "select a & b, '&' from dual"},
+		{script: "-- &undefined\nselect 1 /* &undefined */ from dual", expected: "-- &undefined\nselect 1 /* &undefined */ from dual"},
+		{script: "select &name from dual", err: true},
+		{script: "select &column from dual", err: true},
+		{script: "select 1 \"&alias\" from dual", err: true},
+		{script: "select &undefined from dual", err: true},
+		{script: "select &undefined., '&other' from &table", options: []ScriptOption{ScriptStrict(false)}, expected: "select &undefined., '&other' from EMP"},
+		{script: "select q'[it's]' from T where ID = &id", expected: "select q'[it's]' from T where ID = 10"},
+		{script: "select q'[it's]' from T where ID = &column", err: true},
+		{script: "select Q'{it's &name}', nq'!&id!' from dual", expected: "select Q'{it's O'Brien}', nq'!10!' from dual"},
+		{script: "select q'<&table>' from dual", expected: "select q'<EMP>' from dual"},
+		{script: "select q'[&close]' from dual", err: true},
+	}
+
+	for _, tt := range scriptTests {
+		parsed, err := ParseScript(tt.script, vars, tt.options...)
+		if tt.err {
+			if err == nil {
+				t.Errorf("ParseScript(%q) error is nil", tt.script)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseScript(%q) error: %v", tt.script, err)
+			continue
+		}
+		if parsed != tt.expected {
+			t.Errorf("ParseScript(%q): expected %q, actual %q", tt.script, tt.expected, parsed)
+		}
+	}
+}
+
+// TestPlaceholders tests converting question mark placeholders
+func TestPlaceholders(t *testing.T) {
+	var placeholderTests = []struct {
+		sql      string
+		expected string
+	}{
+		{"select ?, ? from dual", "select :1, :2 from dual"},
+		{"select * from t order by a offset ? rows fetch next ? rows only", "select * from t order by a offset :1 rows fetch next :2 rows only"},
+		{"select * from t order by a fetch first ? rows only", "select * from t order by a fetch first :1 rows only"},
+		{"select '?', \"?\", ? from dual", "select '?', \"?\", :1 from dual"},
+		{"select 'it''s ?', ? from dual", "select 'it''s ?', :1 from dual"},
+		{"select ? -- why?\n, ? /* what? */ from dual", "select :1 -- why?\n, :2 /* what? */ from dual"},
+		{"select '?", "select '?"},
+		{"", ""},
+		{"select :a, :2 from dual", "select :a, :2 from dual"},
+		{"begin :a := 1; end;", "begin :a := 1; end;"},
+		{"select to_char(sysdate, 'HH24:MI'), ? from dual", "select to_char(sysdate, 'HH24:MI'), :1 from dual"},
+		{"begin x := ?; end;", "begin x := :1; end;"},
+		{"select ?, :a from dual", "select ?, :a from dual"},
+		{"select cardinality(t(1, 2) multiset except distinct t(?)) from dual", "select cardinality(t(1, 2) multiset except distinct t(:1)) from dual"},
+		{"select a from t where b = ? intersect select a from u", "select a from t where b = :1 intersect select a from u"},
+		{"select n multiset intersect all m, ? from t", "select n multiset intersect all m, :1 from t"},
+		{"select json_value(data, '$.name') from t where json_exists(data, ?)", "select json_value(data, '$.name') from t where json_exists(data, :1)"},
+		{"select data from t where json_exists(data, '$?(@.a == 1)') and b = ?", "select data from t where json_exists(data, '$?(@.a == 1)') and b = :1"},
+	}
+
+	for _, tt := range placeholderTests {
+		actual, _ := placeholders(tt.sql)
+		if actual != tt.expected {
+			t.Errorf("placeholders(%q): expected %q, actual %q", tt.sql, tt.expected, actual)
+		}
+	}
+
+	var mixedTests = []struct {
+		sql   string
+		mixed bool
+	}{
+		{"select ?, ? from dual", false},
+		{"select :a, :b from dual", false},
+		{"select ?, :a from dual", true},
+		{"", false},
+	}
+
+	for _, tt := range mixedTests {
+		_, mixed := placeholders(tt.sql)
+		if mixed != tt.mixed {
+			t.Errorf("placeholders(%q) mixed: expected %v, actual %v", tt.sql, tt.mixed, mixed)
+		}
+	}
+}
+
+// TestParseDSNInvalid tests parsing invalid DSN parameters
+func TestParseDSNInvalid(t *testing.T) {
+	var dsnTests = []string{
+		"",
+		"xxmc/xxmc@107.20.30.169/ORCL?keepalive=abc",
+		"xxmc/xxmc@107.20.30.169/ORCL?keepalive=0s",
+		"xxmc/xxmc@?keepalive=60s",
+		"xxmc/xxmc@107.20.30.169/ORCL?super_sharding_key=east",
+		"xxmc/xxmc@107.20.30.169/ORCL?lob_inline_threshold=32768",
+		"xxmc/xxmc@107.20.30.169/ORCL?lob_inline_threshold=-1",
+		"xxmc/xxmc@107.20.30.169/ORCL?session_timezone=",
+		"xxmc/xxmc@107.20.30.169/ORCL?session_timezone=UTC'%3B",
+		"xxmc/xxmc@107.20.30.169/ORCL?smart_alloc=yes",
+		"xxmc/xxmc@107.20.30.169/ORCL?oci_thread_mode=single",
+		"xxmc/xxmc@107.20.30.169/ORCL?default_edition=",
+		"xxmc/xxmc@107.20.30.169/ORCL?default_edition=E1%3B",
+		"xxmc/xxmc@107.20.30.169/ORCL?lob_prefetch_size=0",
+		"xxmc/xxmc@107.20.30.169/ORCL?lob_prefetch_size=-1",
+		"xxmc/xxmc@107.20.30.169/ORCL?lob_prefetch_size=4294967296",
+		"xxmc/xxmc@//107.20.30.169:1521/ORCL:DEDICATE/ORCL1",
+		"xxmc/xxmc@107.20.30.169:1521/ORCL:",
+		"xxmc/xxmc@107.20.30.169/ORCL?object_as_json=yes",
+		"xxmc/xxmc@107.20.30.169/ORCL?xml_as_clob=yes",
+		"xxmc/xxmc@107.20.30.169/ORCL?retry_on_errors=",
+		"xxmc/xxmc@107.20.30.169/ORCL?retry_on_errors=60,x",
+		"xxmc/xxmc@107.20.30.169/ORCL?retry_on_errors=60,ORA-00028",
+		"xxmc/xxmc@107.20.30.169/ORCL?retry_on_errors=3113",
+		"xxmc/xxmc@107.20.30.169/ORCL?retry_on_errors=12519",
+		"xxmc/xxmc@107.20.30.169/ORCL?retry_on_errors=12520",
+		"xxmc/xxmc@107.20.30.169/ORCL?retry_count=0",
+		"xxmc/xxmc@107.20.30.169/ORCL?retry_delay=-1s",
+		"xxmc/xxmc@107.20.30.169/ORCL?retry_count=3",
+		"xxmc/xxmc@107.20.30.169/ORCL?batch_size=0",
+		"xxmc/xxmc@107.20.30.169/ORCL?batch_size=x",
+		"xxmc/xxmc@107.20.30.169/ORCL?max_parallel=0",
+		"xxmc/xxmc@107.20.30.169/ORCL?dbms_output_buffer=1999",
+		"xxmc/xxmc@107.20.30.169/ORCL?dbms_output_buffer=1000001",
+		"xxmc/xxmc@107.20.30.169/ORCL?new_password=",
+		"xxmc@107.20.30.169/ORCL?new_password=yyzz",
+		"sys/syspwd@107.20.30.169/ORCL?as=sysdba&prelim_auth=true&lock_timeout=10s",
+		"xxmc/xxmc@107.20.30.169/ORCL?network_compression=auto",
+		"xxmc/xxmc@?network_compression=on",
+		"xxmc/xxmc@107.20.30.169/ORCL?lock_timeout=abc",
+		"xxmc/xxmc@107.20.30.169/ORCL?lock_timeout=-1s",
+		"xxmc/xxmc@107.20.30.169/ORCL?lock_timeout=1000001s",
+		"sys/syspwd@107.20.30.169/ORCL?sharding_key=abc&as=sysdba",
+		"xxmc/xxmc@107.20.30.169/ORCL?prelim_auth=true",
+		"xxmc/xxmc@107.20.30.169/ORCL?max_rows=0",
+		"xxmc/xxmc@107.20.30.169/ORCL?max_rows=abc",
+		"sys/syspwd@107.20.30.169/ORCL?as=sysdba&prelim_auth=abc",
+		"xxmc/xxmc@107.20.30.169/ORCL?password_store=wallet",
+		"xxmc/xxmc@107.20.30.169/ORCL?autoreturn_rowid=abc",
+		"xxmc/xxmc@107.20.30.169/ORCL?unicode_normalization=nfc",
+		"/@?password_store=wallet",
+		"@107.20.30.169/ORCL?password_store=file",
+	}
+
+	for _, dsnString := range dsnTests {
+		_, err := ParseDSN(dsnString)
+		if err == nil {
+			t.Errorf("ParseDSN(%s) error is nil", dsnString)
+		}
+	}
+}
+
+// TestEnvMode tests the oci_thread_mode DSN parameter sets the OCIEnvNlsCreate mode flags
+func TestEnvMode(t *testing.T) {
+	var tests = []struct {
+		dsnString string
+		expected  uint32
+	}{
+		{dsnString: "xxmc/xxmc@107.20.30.169/ORCL", expected: ociThreaded | ociObject},
+		{dsnString: "xxmc/xxmc@107.20.30.169/ORCL?oci_thread_mode=threaded", expected: ociThreaded | ociObject},
+		{dsnString: "xxmc/xxmc@107.20.30.169/ORCL?oci_thread_mode=no_mutex", expected: ociThreaded | ociObject | ociNoMutex},
+	}
+
+	for _, tt := range tests {
+		dsn, err := ParseDSN(tt.dsnString)
+		if err != nil {
+			t.Fatalf("ParseDSN(%s) error: %v", tt.dsnString, err)
+		}
+		envMode := uint32(dsn.envMode())
+		if envMode != tt.expected {
+			t.Errorf("ParseDSN(%s) env mode - received: %#x - expected: %#x", tt.dsnString, envMode, tt.expected)
+		}
+	}
+}
+
+// TestAddConnectParameter tests adding Oracle Net parameters to connect strings
+func TestAddConnectParameter(t *testing.T) {
+	var connectTests = []struct {
+		connect  string
+		name     string
+		value    string
+		expected string
+	}{
+		{"107.20.30.169:1521/ORCL", "COMPRESSION", "on", "107.20.30.169:1521/ORCL?compression=on"},
+		{"107.20.30.169:1521/ORCL?expire_time=1", "COMPRESSION", "on", "107.20.30.169:1521/ORCL?expire_time=1&compression=on"},
+		{"//db.example.com/ORCL", "EXPIRE_TIME", "1", "//db.example.com/ORCL?expire_time=1"},
+		{"ORCL", "EXPIRE_TIME", "1", "ORCL"},
+		{"ORCL", "COMPRESSION", "on", "ORCL"},
+		{"(DESCRIPTION=(ADDRESS=(PROTOCOL=TCP)(HOST=107.20.30.169)(PORT=1521))(CONNECT_DATA=(SERVICE_NAME=ORCL)))", "COMPRESSION", "on",
+			"(DESCRIPTION=(COMPRESSION=on)(ADDRESS=(PROTOCOL=TCP)(HOST=107.20.30.169)(PORT=1521))(CONNECT_DATA=(SERVICE_NAME=ORCL)))"},
+		{"(ADDRESS=(PROTOCOL=TCP)(HOST=107.20.30.169)(PORT=1521))", "COMPRESSION", "on", "(ADDRESS=(PROTOCOL=TCP)(HOST=107.20.30.169)(PORT=1521))"},
+		{"(DESCRIPTION = (ADDRESS=(PROTOCOL=TCP)(HOST=107.20.30.169)(PORT=1521))(CONNECT_DATA=(SERVICE_NAME=ORCL)))", "EXPIRE_TIME", "1",
+			"(DESCRIPTION =(EXPIRE_TIME=1) (ADDRESS=(PROTOCOL=TCP)(HOST=107.20.30.169)(PORT=1521))(CONNECT_DATA=(SERVICE_NAME=ORCL)))"},
+		{" ( description\n= (ADDRESS=(PROTOCOL=TCP)(HOST=107.20.30.169)(PORT=1521))(CONNECT_DATA=(SERVICE_NAME=ORCL)))", "COMPRESSION", "on",
+			" ( description\n=(COMPRESSION=on) (ADDRESS=(PROTOCOL=TCP)(HOST=107.20.30.169)(PORT=1521))(CONNECT_DATA=(SERVICE_NAME=ORCL)))"},
+	}
+
+	for _, tt := range connectTests {
+		actual := addConnectParameter(tt.connect, tt.name, tt.value)
+		if actual != tt.expected {
+			t.Errorf("addConnectParameter(%v, %v, %v): expected %v, actual %v", tt.connect, tt.name, tt.value, tt.expected, actual)
+		}
+	}
+}
+
+// TestLockTimeoutQuery tests the DDL_LOCK_TIMEOUT alter session statement
+func TestLockTimeoutQuery(t *testing.T) {
+	var lockTimeoutTests = []struct {
+		lockTimeout time.Duration
+		expected    string
+	}{
+		{0, "alter session set DDL_LOCK_TIMEOUT = 0"},
+		{10 * time.Second, "alter session set DDL_LOCK_TIMEOUT = 10"},
+		{1500 * time.Millisecond, "alter session set DDL_LOCK_TIMEOUT = 2"},
+		{time.Millisecond, "alter session set DDL_LOCK_TIMEOUT = 1"},
+	}
+
+	for _, tt := range lockTimeoutTests {
+		query := lockTimeoutQuery(tt.lockTimeout)
+		if query != tt.expected {
+			t.Errorf("lockTimeoutQuery(%v) - received: %v - expected: %v", tt.lockTimeout, query, tt.expected)
+		}
+	}
+}
+
+// TestShardingKeyAddComponent tests adding sharding key components
+func TestShardingKeyAddComponent(t *testing.T) {
+	shardingKey := &OCI8ShardingKey{}
+	for _, value := range []interface{}{"abc", 123, int64(456), []byte{1, 2}} {
+		err := shardingKey.AddComponent(value)
+		if err != nil {
+			t.Fatalf("AddComponent(%v) error: %v", value, err)
+		}
+	}
+
+	expected := []interface{}{"abc", int64(123), int64(456), []byte{1, 2}}
+	if !reflect.DeepEqual(shardingKey.components, expected) {
+		t.Fatalf("components - received: %v - expected: %v", shardingKey.components, expected)
+	}
+
+	err := shardingKey.AddComponent(1.5)
+	if err == nil {
+		t.Fatal("AddComponent(1.5) error is nil")
+	}
+}
+
+// testDecimal is a custom decimal type used to test type mappings
+type testDecimal struct {
+	value string
+}
+
+// testRegisterDecimal registers testDecimal type mapping converters
+func testRegisterDecimal(oci8Driver *OCI8DriverStruct) {
+	oci8Driver.RegisterTypeMapping(reflect.TypeOf(testDecimal{}),
+		func(value interface{}) (driver.Value, error) {
+			return value.(testDecimal).value, nil
+		},
+		func(value driver.Value) (interface{}, error) {
+			switch value := value.(type) {
+			case nil:
+				return nil, nil
+			case string:
+				return testDecimal{value: value}, nil
+			case int64:
+				return testDecimal{value: strconv.FormatInt(value, 10)}, nil
+			case float64:
+				return testDecimal{value: strconv.FormatFloat(value, 'f', -1, 64)}, nil
+			}
+			return nil, fmt.Errorf("invalid decimal value type: %T", value)
+		},
+	)
+}
+
+// TestTypeMapping tests registered type mapping converters
+func TestTypeMapping(t *testing.T) {
+	oci8Driver := &OCI8DriverStruct{}
+	testRegisterDecimal(oci8Driver)
+	stmt := &OCI8Stmt{conn: &OCI8Conn{oci8Driver: oci8Driver}}
+
+	namedValue := &driver.NamedValue{Ordinal: 1, Value: testDecimal{value: "123.45"}}
+	err := stmt.CheckNamedValue(namedValue)
+	if err != nil {
+		t.Fatal("CheckNamedValue error:", err)
+	}
+	if namedValue.Value != "123.45" {
+		t.Fatalf("CheckNamedValue - received: %v - expected: %v", namedValue.Value, "123.45")
+	}
+
+	namedValue = &driver.NamedValue{Ordinal: 1, Value: 1.5}
+	err = stmt.CheckNamedValue(namedValue)
+	if err != driver.ErrSkip {
+		t.Fatalf("CheckNamedValue(1.5) - received: %v - expected: %v", err, driver.ErrSkip)
+	}
+
+	var decimal testDecimal
+	err = oci8Driver.MappedScanner(&decimal).Scan(float64(67.89))
+	if err != nil {
+		t.Fatal("Scan error:", err)
+	}
+	if decimal.value != "67.89" {
+		t.Fatalf("Scan - received: %v - expected: %v", decimal.value, "67.89")
+	}
+
+	var aString string
+	err = oci8Driver.MappedScanner(&aString).Scan("abc")
+	if err == nil {
+		t.Fatal("Scan of type without mapping error is nil")
+	}
+}
+
+// TestDBStartupShutdownInvalid tests invalid startup and shutdown modes are returned as errors before calling OCI
+func TestDBStartupShutdownInvalid(t *testing.T) {
+	conn := &OCI8Conn{}
+
+	err := conn.StartupDatabase(StartupMode(99))
+	if err == nil {
+		t.Fatal("StartupDatabase(99) error is nil")
+	}
+
+	err = conn.ShutdownDatabase(ShutdownMode(0))
+	if err == nil {
+		t.Fatal("ShutdownDatabase(0) error is nil")
+	}
+}
+
+// TestGlobalTempTableQuery tests building the create global temporary table statement
+func TestGlobalTempTableQuery(t *testing.T) {
+	columns := []ColumnDef{{Name: "ID", Type: "NUMBER(10)"}, {Name: "NAME", Type: "VARCHAR2(30)"}}
+	var queryTests = []struct {
+		scope    TempTableScope
+		expected string
+	}{
+		{ScopeTransaction, "create global temporary table GTT ( ID NUMBER(10), NAME VARCHAR2(30) ) on commit delete rows"},
+		{ScopeSession, "create global temporary table GTT ( ID NUMBER(10), NAME VARCHAR2(30) ) on commit preserve rows"},
+	}
+
+	for _, tt := range queryTests {
+		query, err := globalTempTableQuery("GTT", columns, tt.scope)
+		if err != nil {
+			t.Fatalf("globalTempTableQuery(%v) error: %v", tt.scope, err)
+		}
+		if query != tt.expected {
+			t.Errorf("globalTempTableQuery(%v) - received: %v - expected: %v", tt.scope, query, tt.expected)
+		}
+	}
+
+	_, err := globalTempTableQuery("GTT; drop table X", columns, ScopeSession)
+	if err == nil {
+		t.Error("invalid table name error is nil")
+	}
+	_, err = globalTempTableQuery("GTT", []ColumnDef{{Name: "\"ID\"", Type: "NUMBER"}}, ScopeSession)
+	if err == nil {
+		t.Error("invalid column name error is nil")
+	}
+	_, err = globalTempTableQuery("GTT", nil, ScopeSession)
+	if err == nil {
+		t.Error("no columns error is nil")
+	}
+	for _, columnType := range []string{"NUMBER", "number(10)", "NUMBER(10,2)", "NUMBER(10, -2)", "VARCHAR2(100 CHAR)", "NVARCHAR2(100)", "RAW(16 byte)", "DATE"} {
+		_, err = globalTempTableQuery("GTT", []ColumnDef{{Name: "ID", Type: columnType}}, ScopeSession)
+		if err != nil {
+			t.Errorf("column type %v error: %v", columnType, err)
+		}
+	}
+	for _, columnType := range []string{"number) ; drop table x --", "NUMBER(10", "VARCHAR2(100 CHARS)", "NUMBER(a)", "NUMBER default 1", "1NUMBER"} {
+		_, err = globalTempTableQuery("GTT", []ColumnDef{{Name: "ID", Type: columnType}}, ScopeSession)
+		if err == nil {
+			t.Errorf("column type %v error is nil", columnType)
+		}
+	}
+	_, err = globalTempTableQuery("GTT", columns, TempTableScope(9))
+	if err == nil {
+		t.Error("invalid scope error is nil")
+	}
+}
+
+// TestRowsColumns tests Columns returns a copy of the column names
+func TestRowsColumns(t *testing.T) {
+	rows := &OCI8Rows{columnNames: []string{"A", "B"}}
+
+	columns := rows.Columns()
+	if !reflect.DeepEqual(columns, []string{"A", "B"}) {
+		t.Fatalf("Columns - received: %v - expected: %v", columns, []string{"A", "B"})
+	}
+
+	columns[0] = "C"
+	columns = rows.Columns()
+	if columns[0] != "A" {
+		t.Fatalf("Columns after modify - received: %v - expected: %v", columns[0], "A")
+	}
+}
+
+// BenchmarkRowsColumns benchmarks calling Columns on rows
+func BenchmarkRowsColumns(b *testing.B) {
+	rows := &OCI8Rows{columnNames: []string{"A", "B", "C", "D", "E", "F", "G", "H"}}
+	for i := 0; i < b.N; i++ {
+		rows.Columns()
+	}
+}
+
+// TestContextPrefetchRows tests the prefetch rows set by WithPrefetchRows override the connection prefetch rows
+func TestContextPrefetchRows(t *testing.T) {
+	prefetchRows, ok := contextPrefetchRows(context.Background(), 10)
+	if prefetchRows != 10 || ok {
+		t.Errorf("contextPrefetchRows without value - received: %v, %v - expected: %v, %v", prefetchRows, ok, 10, false)
+	}
+
+	ctx := WithPrefetchRows(context.Background(), 500)
+	prefetchRows, ok = contextPrefetchRows(ctx, 10)
+	if prefetchRows != 500 || !ok {
+		t.Errorf("contextPrefetchRows with value - received: %v, %v - expected: %v, %v", prefetchRows, ok, 500, true)
+	}
+
+	prefetchRows, ok = contextPrefetchRows(WithPrefetchRows(ctx, 0), 10)
+	if prefetchRows != 0 || !ok {
+		t.Errorf("contextPrefetchRows with 0 - received: %v, %v - expected: %v, %v", prefetchRows, ok, 0, true)
+	}
+}
+
+// TestCompileQuery tests building the alter compile statement for stored objects
+func TestCompileQuery(t *testing.T) {
+	var queryTests = []struct {
+		kind     string
+		owner    string
+		name     string
+		expected string
+	}{
+		{"PROCEDURE", "", "PROC", "alter procedure PROC compile"},
+		{"function", "SCOTT", "FUNC", "alter function SCOTT.FUNC compile"},
+		{"PACKAGE BODY", "", "PKG", "alter package PKG compile body"},
+		{"TYPE BODY", "SCOTT", "TYP", "alter type SCOTT.TYP compile body"},
+	}
+
+	for _, tt := range queryTests {
+		query, err := compileQuery(tt.kind, tt.owner, tt.name)
+		if err != nil {
+			t.Fatalf("compileQuery(%v, %v, %v) error: %v", tt.kind, tt.owner, tt.name, err)
+		}
+		if query != tt.expected {
+			t.Errorf("compileQuery(%v, %v, %v) - received: %v - expected: %v", tt.kind, tt.owner, tt.name, query, tt.expected)
+		}
+	}
+
+	var invalidTests = []struct {
+		kind  string
+		owner string
+		name  string
+	}{
+		{"TABLE", "", "T"},
+		{"PROCEDURE", "", "PROC compile; drop table T"},
+		{"PROCEDURE", "A.B", "PROC"},
+	}
+
+	for _, tt := range invalidTests {
+		_, err := compileQuery(tt.kind, tt.owner, tt.name)
+		if err == nil {
+			t.Errorf("compileQuery(%v, %v, %v) error is nil", tt.kind, tt.owner, tt.name)
+		}
+	}
+}
+
+// TestLockTableQuery tests building the LOCK TABLE statement of each lock mode
+func TestLockTableQuery(t *testing.T) {
+	var tests = []struct {
+		table    string
+		mode     LockMode
+		wait     bool
+		expected string
+		err      string
+	}{
+		{"T", LockSubShare, true, "lock table T in row share mode", ""},
+		{"T", LockSubExclusive, false, "lock table T in row exclusive mode nowait", ""},
+		{"S.T", LockShared, false, "lock table S.T in share mode nowait", ""},
+		{"T", LockSharedSubExclusive, true, "lock table T in share row exclusive mode", ""},
+		{"T", LockExclusive, false, "lock table T in exclusive mode nowait", ""},
+		{"T", LockMode(1), false, "", "invalid lock mode: 1"},
+		{"T;", LockExclusive, false, "", "invalid table name: T;"},
+	}
+
+	for _, tt := range tests {
+		query, err := lockTableQuery(tt.table, tt.mode, tt.wait)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("lockTableQuery %v %v - received error: %v - expected error: %v", tt.table, tt.mode, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("lockTableQuery %v %v error: %v", tt.table, tt.mode, err)
+			continue
+		}
+		if query != tt.expected {
+			t.Errorf("lockTableQuery %v %v - received: %v - expected: %v", tt.table, tt.mode, query, tt.expected)
+		}
+	}
+}
+
+// TestLockErrors tests mapping DBMS_LOCK return codes to errors
+func TestLockErrors(t *testing.T) {
+	var requestTests = []struct {
+		returnCode int64
+		expected   error
+	}{
+		{0, nil},
+		{1, ErrLockTimeout},
+		{2, ErrLockDeadlock},
+		{4, ErrLockAlreadyOwned},
+	}
+
+	for _, tt := range requestTests {
+		err := lockRequestError(tt.returnCode)
+		if err != tt.expected {
+			t.Errorf("lockRequestError(%v) - received: %v - expected: %v", tt.returnCode, err, tt.expected)
+		}
+	}
+	for _, returnCode := range []int64{3, 5, 6} {
+		if lockRequestError(returnCode) == nil {
+			t.Errorf("lockRequestError(%v) error is nil", returnCode)
+		}
+	}
+
+	if err := lockReleaseError(0); err != nil {
+		t.Errorf("lockReleaseError(0) - received: %v - expected: nil", err)
+	}
+	if err := lockReleaseError(4); err != ErrLockNotOwned {
+		t.Errorf("lockReleaseError(4) - received: %v - expected: %v", err, ErrLockNotOwned)
+	}
+}
+
+// TestDirPathValue tests converting values to direct path load text
+func TestDirPathValue(t *testing.T) {
+	var tests = []struct {
+		value    interface{}
+		expected string
+		isNull   bool
+	}{
+		{value: nil, isNull: true},
+		{value: []byte(nil), isNull: true},
+		{value: "abc", expected: "abc"},
+		{value: "", expected: ""},
+		{value: []byte{0x01, 0xab}, expected: "01ab"},
+		{value: 12, expected: "12"},
+		{value: int64(-34), expected: "-34"},
+		{value: 1.5, expected: "1.5"},
+		{value: true, expected: "1"},
+		{value: false, expected: "0"},
+		{value: time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC), expected: "2006-01-02 15:04:05"},
+	}
+
+	for _, tt := range tests {
+		data, isNull, err := dirPathValue(tt.value)
+		if err != nil {
+			t.Fatalf("dirPathValue(%v) error: %v", tt.value, err)
+		}
+		if isNull != tt.isNull || string(data) != tt.expected {
+			t.Errorf("dirPathValue(%v) - received: %q %v - expected: %q %v", tt.value, data, isNull, tt.expected, tt.isNull)
+		}
+	}
+
+	_, _, err := dirPathValue(struct{}{})
+	if err == nil {
+		t.Fatal("invalid type error is nil")
+	}
+}
+
+// TestPipeSendQuery tests building the DBMS_PIPE pack and send block
+func TestPipeSendQuery(t *testing.T) {
+	date := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	query, args, err := pipeSendQuery([]interface{}{1, "a", []byte{1}, date}, time.UTC)
+	if err != nil {
+		t.Fatal("pipeSendQuery error:", err)
+	}
+	expected := "begin DBMS_PIPE.RESET_BUFFER; DBMS_PIPE.PACK_MESSAGE(:1); DBMS_PIPE.PACK_MESSAGE(:2); DBMS_PIPE.PACK_MESSAGE_RAW(:3); " +
+		"DBMS_PIPE.PACK_MESSAGE(to_date(:4, 'YYYY-MM-DD HH24:MI:SS')); :5 := DBMS_PIPE.SEND_MESSAGE(:6, :7); end;"
+	if query != expected {
+		t.Fatalf("query - received: %v - expected: %v", query, expected)
+	}
+	if len(args) != 4 || args[0] != int64(1) || args[3] != "2006-01-02 15:04:05" {
+		t.Fatalf("args - received: %v", args)
+	}
+
+	_, _, err = pipeSendQuery([]interface{}{struct{}{}}, time.UTC)
+	if err == nil {
+		t.Fatal("invalid item type error is nil")
+	}
+
+	var timeoutTests = []struct {
+		timeout  time.Duration
+		expected int64
+	}{
+		{-1, maxPipeWait},
+		{0, 0},
+		{time.Millisecond, 1},
+		{2 * time.Second, 2},
+	}
+	for _, tt := range timeoutTests {
+		seconds := pipeTimeoutSeconds(tt.timeout)
+		if seconds != tt.expected {
+			t.Errorf("pipeTimeoutSeconds(%v) - received: %v - expected: %v", tt.timeout, seconds, tt.expected)
+		}
+	}
+
+	if err := pipeError("receive", 1); err != ErrPipeTimeout {
+		t.Errorf("pipeError(1) - received: %v - expected: %v", err, ErrPipeTimeout)
+	}
+}
+
+// TestRebindData tests converting values to rebind data
+func TestRebindData(t *testing.T) {
+	var tests = []struct {
+		value    driver.Value
+		expected []byte
+	}{
+		{nil, nil},
+		{int64(1), []byte{1, 0, 0, 0, 0, 0, 0, 0}},
+		{true, []byte{1, 0, 0, 0, 0, 0, 0, 0}},
+		{false, []byte{0, 0, 0, 0, 0, 0, 0, 0}},
+		{"abc", []byte("abc")},
+		{[]byte{1, 2}, []byte{1, 2}},
+	}
+
+	for _, tt := range tests {
+		data, _, err := rebindData(tt.value)
+		if err != nil {
+			t.Fatalf("rebindData(%v) error: %v", tt.value, err)
+		}
+		if !bytes.Equal(data, tt.expected) {
+			t.Errorf("rebindData(%v) - received: %v - expected: %v", tt.value, data, tt.expected)
+		}
+	}
+
+	for _, value := range []driver.Value{time.Now(), strings.Repeat("a", 32768), struct{}{}} {
+		_, _, err := rebindData(value)
+		if err == nil {
+			t.Errorf("rebindData(%T) error is nil", value)
+		}
+	}
+}
+
+// TestReturningRowidQuery tests adding RETURNING ROWID INTO to INSERT queries
+func TestReturningRowidQuery(t *testing.T) {
+	var tests = []struct {
+		query    string
+		expected string
+		changed  bool
+	}{
+		{"insert into T ( A ) values ( :1 )", "insert into T ( A ) values ( :1 ) returning rowid into :oci8_rowid", true},
+		{"  INSERT INTO T VALUES (1);  ", "INSERT INTO T VALUES (1) returning rowid into :oci8_rowid", true},
+		{"insert into T ( A ) values ( :1 ) returning A into :2", "insert into T ( A ) values ( :1 ) returning A into :2", false},
+		{"insert into T select * from S", "insert into T select * from S", false},
+		{"insert all into T values (1) select * from dual", "insert all into T values (1) select * from dual", false},
+		{"update T set A = 1", "update T set A = 1", false},
+		{"insert into T ( A ) select 'VALUES' from S", "insert into T ( A ) select 'VALUES' from S", false},
+		{`insert into T ( "VALUES" ) select A from S`, `insert into T ( "VALUES" ) select A from S`, false},
+		{"insert into T ( A ) select A from S -- values", "insert into T ( A ) select A from S -- values", false},
+		{"insert into T ( A ) values ( 'RETURNING' )", "insert into T ( A ) values ( 'RETURNING' ) returning rowid into :oci8_rowid", true},
+		{`insert into T ( "RETURNING" ) values ( :1 )`, `insert into T ( "RETURNING" ) values ( :1 ) returning rowid into :oci8_rowid`, true},
+		{"insert into T ( A ) values ( :1 ) -- comment", "insert into T ( A ) values ( :1 ) returning rowid into :oci8_rowid", true},
+		{"insert into T ( A ) values ( :1 ); /* comment */\n", "insert into T ( A ) values ( :1 ) returning rowid into :oci8_rowid", true},
+		{"insert /*+ APPEND */ into T values ( 1 )", "insert /*+ APPEND */ into T values ( 1 ) returning rowid into :oci8_rowid", true},
+		{"insert into T ( A ) values ( 1 ) return A into :1", "insert into T ( A ) values ( 1 ) return A into :1", false},
+		{"insert first into T values (1) select * from dual", "insert first into T values (1) select * from dual", false},
+	}
+
+	for _, tt := range tests {
+		query, changed := returningRowidQuery(tt.query)
+		if query != tt.expected || changed != tt.changed {
+			t.Errorf("returningRowidQuery(%v) - received: %v %v - expected: %v %v", tt.query, query, changed, tt.expected, tt.changed)
+		}
+	}
+}
+
+// TestHintQuery tests adding a WithHint optimizer hint after the first keyword
+func TestHintQuery(t *testing.T) {
+	var tests = []struct {
+		query    string
+		hint     string
+		expected string
+		err      string
+	}{
+		{"select * from T", "PARALLEL(T, 8)", "select /*+ PARALLEL(T, 8) */ * from T", ""},
+		{"  SELECT\n* from T", " FULL(T) ", "  SELECT /*+ FULL(T) */\n* from T", ""},
+		{"insert into T values (1)", "APPEND", "insert /*+ APPEND */ into T values (1)", ""},
+		{"update T set A = 1", "INDEX(T T_A)", "update /*+ INDEX(T T_A) */ T set A = 1", ""},
+		{"delete from T", "FULL(T)", "delete /*+ FULL(T) */ from T", ""},
+		{"select /*+ FULL(T) */ * from T", "PARALLEL(T, 8)", "select /*+ FULL(T) PARALLEL(T, 8) */ * from T", ""},
+		{"update  /*+INDEX(T T_A)*/ T set A = 1", "NO_PARALLEL", "update /*+ INDEX(T T_A) NO_PARALLEL */ T set A = 1", ""},
+		{"select /* comment */ * from T", "FULL(T)", "select /*+ FULL(T) */ /* comment */ * from T", ""},
+		{"begin null; end;", "FULL(T)", "begin null; end;", ""},
+		{"with A as (select 1 from dual) select * from A", "FULL(T)", "with A as (select 1 from dual) select * from A", ""},
+		{"select * from T", "", "select * from T", ""},
+		{"select * from T", "FULL(T) */ drop", "", "invalid hint: FULL(T) */ drop"},
+	}
+
+	for _, tt := range tests {
+		query, err := hintQuery(tt.query, tt.hint)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("hintQuery(%v, %v) - received error: %v - expected error: %v", tt.query, tt.hint, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("hintQuery(%v, %v) error: %v", tt.query, tt.hint, err)
+			continue
+		}
+		if query != tt.expected {
+			t.Errorf("hintQuery(%v, %v) - received: %v - expected: %v", tt.query, tt.hint, query, tt.expected)
+		}
+	}
+}
+
+// TestIsLargePLSQL tests finding PL/SQL blocks longer than 32767 bytes
+func TestIsLargePLSQL(t *testing.T) {
+	statements := strings.Repeat("null; ", 6000)
+	var tests = []struct {
+		query    string
+		expected bool
+	}{
+		{"begin " + statements + "end;", true},
+		{"\n  DECLARE n number; BEGIN " + statements + "end;", true},
+		{"Begin\n" + statements + "end;", true},
+		{"begin null; end;", false},
+		{"beginning " + statements, false},
+		{"select '" + statements + "' from dual", false},
+	}
+
+	for _, tt := range tests {
+		result := isLargePLSQL(tt.query)
+		if result != tt.expected {
+			t.Errorf("isLargePLSQL(%.20v) - received: %v - expected: %v", tt.query, result, tt.expected)
+		}
+	}
+}
+
+// TestSelectListConvert tests wrapping a query to convert object columns to JSON and XMLTYPE columns to CLOB
+func TestSelectListConvert(t *testing.T) {
+	var tests = []struct {
+		query       string
+		columns     []string
+		conversions []string
+		expected    string
+	}{
+		{"select ID, O from T", []string{"ID", "O"}, []string{"", "JSON_OBJECT(%v RETURNING CLOB)"},
+			`select oci8_select."ID", JSON_OBJECT(oci8_select."O" RETURNING CLOB) "O" from ( select ID, O from T ) oci8_select`},
+		{" select value(t) from T t order by 1; ", []string{"VALUE(T)"}, []string{"JSON_OBJECT(%v RETURNING CLOB)"},
+			`select JSON_OBJECT(oci8_select."VALUE(T)" RETURNING CLOB) "VALUE(T)" from ( select value(t) from T t order by 1 ) oci8_select`},
+		{"select A, B from T where A = :1", []string{"A", "B"}, []string{"", "XMLSERIALIZE(CONTENT %v AS CLOB)"},
+			`select oci8_select."A", XMLSERIALIZE(CONTENT oci8_select."B" AS CLOB) "B" from ( select A, B from T where A = :1 ) oci8_select`},
+		{"select X, O from T", []string{"X", "O"}, []string{"XMLSERIALIZE(CONTENT %v AS CLOB)", "JSON_OBJECT(%v RETURNING CLOB)"},
+			`select XMLSERIALIZE(CONTENT oci8_select."X" AS CLOB) "X", JSON_OBJECT(oci8_select."O" RETURNING CLOB) "O" from ( select X, O from T ) oci8_select`},
+	}
+
+	for _, tt := range tests {
+		query := selectListConvert(tt.query, tt.columns, tt.conversions)
+		if query != tt.expected {
+			t.Errorf("selectListConvert(%v) - received: %v - expected: %v", tt.query, query, tt.expected)
+		}
+	}
+
+	if hasDuplicateName([]string{"A", "B"}) {
+		t.Error("hasDuplicateName(A, B) is true")
+	}
+	if !hasDuplicateName([]string{"A", "B", "A"}) {
+		t.Error("hasDuplicateName(A, B, A) is false")
+	}
+}
+
+// TestDSNValidate tests that each invalid DSN parameter combination returns its own error
+func TestDSNValidate(t *testing.T) {
+	const sysdba = 0x00000002 // C.OCI_SYSDBA
+	shardingKey := &OCI8ShardingKey{}
+	shardingKey.AddComponent("1")
+
+	var tests = []struct {
+		dsn      DSN
+		expected string
+	}{
+		{DSN{keepAlive: time.Minute}, "keepalive needs a connect string"},
+		{DSN{Connect: "ORCL", keepAlive: time.Minute}, "keepalive cannot be used with a tnsnames.ora alias, add EXPIRE_TIME to the alias descriptor instead"},
+		{DSN{networkCompression: "on"}, "network_compression needs a connect string"},
+		{DSN{Connect: "ORCL", networkCompression: "on"}, "network_compression cannot be used with a tnsnames.ora alias, add COMPRESSION to the alias descriptor instead"},
+		{DSN{Connect: "host/ORCL", superShardingKey: shardingKey}, "super_sharding_key needs sharding_key"},
+		{DSN{Connect: "host/ORCL", shardingKey: shardingKey, operationMode: sysdba}, "sharding_key cannot be used with as"},
+		{DSN{Connect: "host/ORCL", passwordStoreWallet: true, Password: "p"}, "password_store wallet needs an empty password"},
+		{DSN{passwordStoreWallet: true}, "password_store wallet needs a connect string"},
+		{DSN{Connect: "host/ORCL", Password: "p"}, "password needs a username"},
+		{DSN{Connect: "host/ORCL", prelimAuth: true}, "prelim_auth needs as sysdba or sysoper"},
+		{DSN{Connect: "host/ORCL", prelimAuth: true, operationMode: sysdba, lockTimeout: time.Second}, "prelim_auth cannot be used with lock_timeout"},
+		{DSN{Connect: "host/ORCL", prelimAuth: true, operationMode: sysdba, sessionTimeZone: "UTC"}, "prelim_auth cannot be used with session_timezone"},
+		{DSN{Connect: "host/ORCL", prelimAuth: true, operationMode: sysdba, defaultEdition: "ORA$BASE"}, "prelim_auth cannot be used with default_edition"},
+		{DSN{Connect: "host/ORCL", prelimAuth: true, operationMode: sysdba, lobPrefetchSize: 4096}, "prelim_auth cannot be used with lob_prefetch_size"},
+		{DSN{Connect: "host/ORCL", prelimAuth: true, operationMode: sysdba, dbmsOutputBuffer: 20000}, "prelim_auth cannot be used with dbms_output_buffer"},
+		{DSN{Connect: "host/ORCL", Username: "u", newPassword: "n"}, "new_password needs a username and password"},
+		{DSN{Connect: "host/ORCL", Username: "u", Password: "p", newPassword: "n", operationMode: sysdba}, "new_password cannot be used with as"},
+		{DSN{Connect: "host/ORCL", Username: "u", Password: "p", newPassword: "n", shardingKey: &OCI8ShardingKey{}}, "new_password cannot be used with sharding_key"},
+		{DSN{Connect: "host/ORCL", retryCount: 3}, "retry_count and retry_delay need retry_on_errors"},
+		{DSN{Connect: "host/ORCL", retryDelay: time.Second}, "retry_count and retry_delay need retry_on_errors"},
+	}
+
+	for _, tt := range tests {
+		err := tt.dsn.Validate()
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("Validate(%+v) - received: %v - expected: %v", tt.dsn, err, tt.expected)
+		}
+	}
+
+	// DSNs from ParseDSN are valid
+	for _, dsnString := range []string{
+		"xxmc/xxmc@107.20.30.169/ORCL",
+		"sys/syspwd@107.20.30.169/ORCL?as=sysdba&prelim_auth=true",
+		"/@107.20.30.169/ORCL?password_store=wallet",
+	} {
+		dsn, err := ParseDSN(dsnString)
+		if err != nil {
+			t.Fatalf("ParseDSN(%v) error: %v", dsnString, err)
+		}
+		err = dsn.Validate()
+		if err != nil {
+			t.Errorf("Validate(%v) error: %v", dsnString, err)
+		}
+	}
+}
+
+// TestRetryOnErrors tests that execute is retried for the retry error codes up to the retry count
+func TestRetryOnErrors(t *testing.T) {
+	errTransient := errors.New("ORA-00060: deadlock detected while waiting for resource")
+	var tests = []struct {
+		retryErrors   []int
+		retryCount    int
+		failures      int
+		errorCode     int
+		expectedCalls int
+		expectedErr   error
+	}{
+		{retryErrors: []int{60}, retryCount: 3, failures: 10, errorCode: 60, expectedCalls: 4, expectedErr: errTransient},
+		{retryErrors: []int{8177, 60}, retryCount: 3, failures: 2, errorCode: 60, expectedCalls: 3, expectedErr: nil},
+		{retryErrors: []int{8177}, retryCount: 3, failures: 10, errorCode: 60, expectedCalls: 1, expectedErr: errTransient},
+		{retryErrors: nil, retryCount: 1, failures: 10, errorCode: 60, expectedCalls: 1, expectedErr: errTransient},
+		{retryErrors: []int{60}, retryCount: 1, failures: 0, errorCode: 60, expectedCalls: 1, expectedErr: nil},
+	}
+
+	for _, tt := range tests {
+		calls := 0
+		err := retryOnErrors(context.Background(), tt.retryErrors, tt.retryCount, time.Millisecond, func() (int, error) {
+			calls++
+			if calls <= tt.failures {
+				return tt.errorCode, errTransient
+			}
+			return 0, nil
+		})
+		if err != tt.expectedErr {
+			t.Errorf("retryOnErrors(%v, %v) error - received: %v - expected: %v", tt.retryErrors, tt.retryCount, err, tt.expectedErr)
+		}
+		if calls != tt.expectedCalls {
+			t.Errorf("retryOnErrors(%v, %v) calls - received: %v - expected: %v", tt.retryErrors, tt.retryCount, calls, tt.expectedCalls)
+		}
+	}
+
+	// context done while waiting to retry
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := retryOnErrors(ctx, []int{60}, 3, time.Minute, func() (int, error) {
+		return 60, errTransient
+	})
+	if err != context.Canceled {
+		t.Errorf("retryOnErrors error - received: %v - expected: %v", err, context.Canceled)
+	}
+}
+
+// TestScanValue tests storing fetched values in FetchOneRow destinations
+func TestScanValue(t *testing.T) {
+	var aString string
+	var aBytes []byte
+	var anInt int
+	var aFloat64 float64
+	var aTime time.Time
+	var anInterface interface{}
+	var aNullString sql.NullString
+	var aNullInt64 sql.NullInt64
+	var aStringPointer *string
+	aTimeValue := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	var tests = []struct {
+		dest     interface{}
+		value    driver.Value
+		expected interface{}
+		err      bool
+	}{
+		{dest: &aString, value: "a", expected: "a"},
+		{dest: &aString, value: []byte("b"), err: true},
+		{dest: &aBytes, value: []byte("c"), expected: []byte("c")},
+		{dest: &aBytes, value: "c", err: true},
+		{dest: &anInt, value: float64(12), err: true},
+		{dest: &anInt, value: int64(13), err: true},
+		{dest: &aFloat64, value: float64(2.5), expected: float64(2.5)},
+		{dest: &aTime, value: aTimeValue, expected: aTimeValue},
+		{dest: &anInterface, value: int64(15), expected: int64(15)},
+		{dest: &aNullString, value: nil, expected: sql.NullString{}},
+		{dest: &aNullString, value: "d", expected: sql.NullString{String: "d", Valid: true}},
+		{dest: &aNullInt64, value: float64(16), expected: sql.NullInt64{Int64: 16, Valid: true}},
+		{dest: &aStringPointer, value: nil, expected: (*string)(nil)},
+		{dest: &aBytes, value: nil, expected: []byte(nil)},
+		{dest: &aString, value: nil, err: true},
+		{dest: aString, value: "e", err: true},
+		{dest: &aTime, value: "f", err: true},
+	}
+
+	for _, tt := range tests {
+		err := scanValue(tt.dest, tt.value)
+		if tt.err {
+			if err == nil {
+				t.Errorf("scanValue(%T, %v) error is nil", tt.dest, tt.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("scanValue(%T, %v) error: %v", tt.dest, tt.value, err)
+			continue
+		}
+		received := reflect.ValueOf(tt.dest).Elem().Interface()
+		if !reflect.DeepEqual(received, tt.expected) {
+			t.Errorf("scanValue(%T, %v) - received: %v - expected: %v", tt.dest, tt.value, received, tt.expected)
+		}
+	}
+}
+
+// TestStreamInsertQuery tests the StreamInsert insert statement
+func TestStreamInsertQuery(t *testing.T) {
+	var tests = []struct {
+		table   string
+		columns []string
+		query   string
+		err     string
+	}{
+		{table: "A", columns: []string{"B"}, query: "insert into A (B) values (:1)"},
+		{table: "S.A", columns: []string{"B", "C", "D"}, query: "insert into S.A (B, C, D) values (:1, :2, :3)"},
+		{table: "A;", columns: []string{"B"}, err: "invalid table name: A;"},
+		{table: "A", err: "no columns"},
+		{table: "A", columns: []string{"B", "C)"}, err: "invalid column name: C)"},
+	}
+
+	for _, tt := range tests {
+		query, err := streamInsertQuery(tt.table, tt.columns)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("streamInsertQuery %v %v - received error: %v - expected error: %v", tt.table, tt.columns, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("streamInsertQuery %v %v error: %v", tt.table, tt.columns, err)
+			continue
+		}
+		if query != tt.query {
+			t.Errorf("streamInsertQuery %v %v - received: %v - expected: %v", tt.table, tt.columns, query, tt.query)
+		}
+	}
+}
+
+// TestExportDDLQuery tests ExportObjectDDL uses GET_DEPENDENT_DDL for dependent object types
+func TestExportDDLQuery(t *testing.T) {
+	var tests = []struct {
+		objectType string
+		expected   string
+	}{
+		{"TABLE", "declare ddl clob; begin ddl := DBMS_METADATA.GET_DDL(:1, :2, nvl(:3, user)); :oci8_ddl := ddl; end;"},
+		{"package", "declare ddl clob; begin ddl := DBMS_METADATA.GET_DDL(:1, :2, nvl(:3, user)); :oci8_ddl := ddl; end;"},
+		{"OBJECT_GRANT", "declare ddl clob; begin ddl := DBMS_METADATA.GET_DEPENDENT_DDL(:1, :2, nvl(:3, user)); :oci8_ddl := ddl; end;"},
+		{"constraint", "declare ddl clob; begin ddl := DBMS_METADATA.GET_DEPENDENT_DDL(:1, :2, nvl(:3, user)); :oci8_ddl := ddl; end;"},
+	}
+
+	for _, tt := range tests {
+		query := exportDDLQuery(tt.objectType)
+		if query != tt.expected {
+			t.Errorf("exportDDLQuery(%v) - received: %v - expected: %v", tt.objectType, query, tt.expected)
+		}
+	}
+}
+
+// TestTimezoneToLocation tests the location of OCI time zone offsets, where the minute is negative for negative offsets
+func TestTimezoneToLocation(t *testing.T) {
+	tests := []struct {
+		hour   int64
+		minute int64
+		name   string
+		offset int
+	}{
+		{hour: 5, minute: 45, name: "+5:45", offset: 5*3600 + 45*60},
+		{hour: 5, minute: 30, name: "+5:30", offset: 5*3600 + 30*60},
+		{hour: -3, minute: -30, name: "-3:30", offset: -3*3600 - 30*60},
+		{hour: 0, minute: -30, name: "-0:30", offset: -30 * 60},
+		{hour: 9, minute: 5, name: "+9:05", offset: 9*3600 + 5*60},
+		{hour: 10, offset: 10 * 3600},
+		{hour: -5, offset: -5 * 3600},
+	}
+
+	for _, tt := range tests {
+		location := timezoneToLocation(tt.hour, tt.minute)
+		name, offset := time.Date(2099, 1, 2, 3, 4, 5, 0, location).Zone()
+		if offset != tt.offset {
+			t.Errorf("timezoneToLocation(%v, %v) offset - received: %v - expected: %v", tt.hour, tt.minute, offset, tt.offset)
+		}
+		if tt.name != "" && name != tt.name {
+			t.Errorf("timezoneToLocation(%v, %v) name - received: %v - expected: %v", tt.hour, tt.minute, name, tt.name)
+		}
+	}
+}
+
+// TestDSNPreprocessor tests a registered DSN preprocessor that expands environment variables
+func TestDSNPreprocessor(t *testing.T) {
+	oci8Driver := &OCI8DriverStruct{}
+	oci8Driver.RegisterDSNPreprocessor(os.ExpandEnv)
+	oci8Driver.RegisterDSNPreprocessor(func(dsnString string) string {
+		return dsnString + "?prefetch_rows=10"
+	})
+
+	os.Setenv("OCI8_TEST_DSN_PASSWORD", "secret")
+	defer os.Unsetenv("OCI8_TEST_DSN_PASSWORD")
+
+	dsnString := oci8Driver.preprocessDSN("xxmc/$OCI8_TEST_DSN_PASSWORD@107.20.30.169/ORCL")
+	expected := "xxmc/secret@107.20.30.169/ORCL?prefetch_rows=10"
+	if dsnString != expected {
+		t.Fatalf("preprocess DSN - received: %v - expected: %v", dsnString, expected)
+	}
+
+	dsn, err := ParseDSN(oci8Driver.preprocessDSN("xxmc/${OCI8_TEST_DSN_PASSWORD}@107.20.30.169/ORCL"))
+	if err != nil {
+		t.Fatal("ParseDSN error:", err)
+	}
+	if dsn.Password != "secret" || dsn.Connect != "107.20.30.169/ORCL" || dsn.prefetchRows != 10 {
+		t.Fatalf("ParseDSN - received: %+v", dsn)
+	}
+}
+
+// TestCopyTableQueries tests the CopyTable create and insert statements
+func TestCopyTableQueries(t *testing.T) {
+	var tests = []struct {
+		src    string
+		dest   string
+		where  string
+		create string
+		insert string
+		err    string
+	}{
+		{src: "A", dest: "B", create: "create table B as select * from A where 1 = 0", insert: "insert into B select * from A"},
+		{src: "S.A", dest: "T.B", where: "C > :1", create: "create table T.B as select * from S.A where 1 = 0", insert: "insert into T.B select * from S.A where C > :1"},
+		{src: "A", dest: "B", where: " ", create: "create table B as select * from A where 1 = 0", insert: "insert into B select * from A"},
+		{src: "A;", dest: "B", err: "invalid table name: A;"},
+		{src: "A", dest: "R.S.B", err: "invalid table name: R.S.B"},
+		{src: "A", dest: "B.", err: "invalid table name: B."},
+	}
+
+	for _, tt := range tests {
+		create, insert, err := copyTableQueries(tt.src, tt.dest, tt.where)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("copyTableQueries %v %v - received error: %v - expected error: %v", tt.src, tt.dest, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("copyTableQueries %v %v error: %v", tt.src, tt.dest, err)
+			continue
+		}
+		if create != tt.create || insert != tt.insert {
+			t.Errorf("copyTableQueries %v %v - received: %v, %v - expected: %v, %v", tt.src, tt.dest, create, insert, tt.create, tt.insert)
+		}
+	}
+}
+
+// TestProcedureCall tests getting the procedure name and arguments of a PL/SQL block
+func TestProcedureCall(t *testing.T) {
+	var tests = []struct {
+		query      string
+		returnBind string
+		name       string
+		args       []string
+		ok         bool
+	}{
+		{query: "begin proc(:1, :2); end;", name: "proc", args: []string{":1", ":2"}, ok: true},
+		{query: " BEGIN\n\tscott.proc ( :a , 'x,y', f(:b, 2), c => :c ) ;\nEND ; ", name: "scott.proc", args: []string{":a", "'x,y'", "f(:b, 2)", "c => :c"}, ok: true},
+		{query: "begin :r := func(:a); end;", returnBind: "r", name: "func", args: []string{":a"}, ok: true},
+		{query: "begin proc; end;", name: "proc", ok: true},
+		{query: "begin proc(:1); proc(:2); end;"},
+		{query: "declare a number; begin proc(a); end;"},
+		{query: "select :1 from dual"},
+	}
+
+	for _, tt := range tests {
+		returnBind, name, args, ok := procedureCall(tt.query)
+		if ok != tt.ok || returnBind != tt.returnBind || name != tt.name || !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("procedureCall %q - received: %v %v %q %v - expected: %v %v %q %v", tt.query, returnBind, name, args, ok, tt.returnBind, tt.name, tt.args, tt.ok)
+		}
+	}
+}
+
+// TestChangePasswordInvalid tests ChangePassword errors returned before calling OCIPasswordChange
+func TestChangePasswordInvalid(t *testing.T) {
+	conn := &OCI8Conn{closed: true}
+	err := conn.ChangePassword("old", "new")
+	if err != driver.ErrBadConn {
+		t.Fatalf("closed connection - received: %v - expected: %v", err, driver.ErrBadConn)
+	}
+
+	conn = &OCI8Conn{}
+	err = conn.ChangePassword("old", "")
+	if err == nil || err.Error() != "new password is empty" {
+		t.Fatalf("empty new password - received: %v - expected: %v", err, "new password is empty")
+	}
+}
+
+// TestIsPasswordExpiredError tests the ORA-28001 error that changes the password with the new_password DSN parameter at logon
+func TestIsPasswordExpiredError(t *testing.T) {
+	var tests = []struct {
+		err      error
+		expected bool
+	}{
+		{err: errors.New("ORA-28001: the password has expired\n"), expected: true},
+		{err: errors.New("ORA-28002: the password will expire within 7 days\n")},
+		{err: errors.New("ORA-01017: invalid username/password; logon denied\n")},
+		{err: driver.ErrBadConn},
+		{err: nil},
+	}
+
+	for _, tt := range tests {
+		expired := isPasswordExpiredError(tt.err)
+		if expired != tt.expected {
+			t.Errorf("isPasswordExpiredError(%v) - received: %v - expected: %v", tt.err, expired, tt.expected)
+		}
+	}
+}
+
+// TestParsePlanHash tests the plan hash value binds of LoadSQLPlanBaseline
+func TestParsePlanHash(t *testing.T) {
+	var tests = []struct {
+		planHash string
+		value    interface{}
+		err      string
+	}{
+		{planHash: "", value: nil},
+		{planHash: "1388734953", value: int64(1388734953)},
+		{planHash: "4294967295", value: int64(4294967295)},
+		{planHash: "4294967296", err: "invalid plan hash: 4294967296"},
+		{planHash: "-1", err: "invalid plan hash: -1"},
+		{planHash: "1 or 1=1", err: "invalid plan hash: 1 or 1=1"},
+	}
+
+	for _, tt := range tests {
+		value, err := parsePlanHash(tt.planHash)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("parsePlanHash %q - received error: %v - expected error: %v", tt.planHash, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || value != tt.value {
+			t.Errorf("parsePlanHash %q - received: %v, %v - expected: %v", tt.planHash, value, err, tt.value)
+		}
+	}
+
+	if !strings.Contains(loadPlansQuery, ":1 := DBMS_SPM.LOAD_PLANS_FROM_CURSOR_CACHE(sql_id => :2, plan_hash_value => :3)") {
+		t.Errorf("load plans query: %v", loadPlansQuery)
+	}
+	if !strings.Contains(evolveBaselineQuery, ":1 := DBMS_SPM.EVOLVE_SQL_PLAN_BASELINE(plan_name => :2)") {
+		t.Errorf("evolve baseline query: %v", evolveBaselineQuery)
+	}
+}
+
+// TestStartProfilerQuery tests the DBMS_PROFILER binds of StartProfiler and the return status errors
+func TestStartProfilerQuery(t *testing.T) {
+	if !strings.Contains(startProfilerQuery, ":1 := DBMS_PROFILER.START_PROFILER(run_comment => :2, run_number => :3)") {
+		t.Errorf("start profiler query: %v", startProfilerQuery)
+	}
+
+	var tests = []struct {
+		status int64
+		err    string
+	}{
+		{status: 0},
+		{status: 1, err: "start profiler error: incorrect parameter"},
+		{status: 2, err: "start profiler error: data flush failed, check the profiler tables exist and are writable"},
+		{status: -1, err: "start profiler error: profiler version mismatch with the profiler tables"},
+		{status: 3, err: "start profiler error: status 3"},
+	}
+	for _, tt := range tests {
+		err := profilerError("start profiler", tt.status)
+		if (tt.err == "" && err != nil) || (tt.err != "" && (err == nil || err.Error() != tt.err)) {
+			t.Errorf("profilerError(%v) - received: %v - expected: %v", tt.status, err, tt.err)
+		}
+	}
+}
+
+// testRACConn is a mock connection of a RAC node
+type testRACConn struct {
+	driver.Conn
+	dsn string
+}
+
+// TestRACPool tests RAC pool round-robin and latency balancing and removing nodes on connect failures
+func TestRACPool(t *testing.T) {
+	var opened []string
+	badNodes := map[string]bool{}
+	nodeErrors := map[string]error{}
+	latencies := map[string]time.Duration{}
+	testOpen := func(dsn string) (driver.Conn, error) {
+		opened = append(opened, dsn)
+		if badNodes[dsn] {
+			return nil, driver.ErrBadConn
+		}
+		if nodeErrors[dsn] != nil {
+			return nil, nodeErrors[dsn]
+		}
+		time.Sleep(latencies[dsn])
+		return &testRACConn{dsn: dsn}, nil
+	}
+	testOpenNodes := func(pool *OCI8RACPool, count int) []string {
+		var dsns []string
+		for i := 0; i < count; i++ {
+			conn, err := pool.Open("")
+			if err != nil {
+				t.Fatal("open error:", err)
+			}
+			dsns = append(dsns, conn.(*testRACConn).dsn)
+		}
+		return dsns
+	}
+
+	pool := NewRACPool(RACRoundRobin, "node1", "node2", "node3")
+	pool.open = testOpen
+	dsns := testOpenNodes(pool, 6)
+	expected := []string{"node1", "node2", "node3", "node1", "node2", "node3"}
+	if !reflect.DeepEqual(dsns, expected) {
+		t.Fatalf("round-robin - received: %v - expected: %v", dsns, expected)
+	}
+
+	badNodes["node2"] = true
+	opened = nil
+	dsns = testOpenNodes(pool, 4)
+	expected = []string{"node1", "node3", "node1", "node3"}
+	if !reflect.DeepEqual(dsns, expected) {
+		t.Fatalf("bad node removed - received: %v - expected: %v", dsns, expected)
+	}
+	// the bad node is only tried once
+	expected = []string{"node1", "node2", "node3", "node1", "node3"}
+	if !reflect.DeepEqual(opened, expected) {
+		t.Fatalf("bad node opens - received: %v - expected: %v", opened, expected)
+	}
+	if !reflect.DeepEqual(pool.ActiveNodes(), []string{"node1", "node3"}) {
+		t.Fatalf("active nodes - received: %v - expected: %v", pool.ActiveNodes(), []string{"node1", "node3"})
+	}
+
+	badNodes["node1"] = true
+	badNodes["node3"] = true
+	_, err := pool.Open("")
+	if err != ErrNoRACNodes {
+		t.Fatalf("all nodes removed - received: %v - expected: %v", err, ErrNoRACNodes)
+	}
+
+	badNodes = map[string]bool{}
+	pool.RestoreNodes()
+	if len(pool.ActiveNodes()) != 3 {
+		t.Fatalf("restored nodes - received: %v - expected 3 nodes", pool.ActiveNodes())
+	}
+
+	// OCIServerAttach errors of Open remove the node
+	nodeErrors["node1"] = errors.New("ORA-12541: TNS:no listener\n")
+	nodeErrors["node2"] = errors.New("ORA-12514: TNS:listener does not currently know of service requested in connect descriptor\n")
+	nodeErrors["node3"] = errors.New("ORA-12170: TNS:Connect timeout occurred\n")
+	_, err = pool.Open("")
+	if err != ErrNoRACNodes {
+		t.Fatalf("connect errors - received: %v - expected: %v", err, ErrNoRACNodes)
+	}
+
+	// other errors are returned without removing the node
+	nodeErrors = map[string]error{"node1": errors.New("ORA-01017: invalid username/password; logon denied\n")}
+	pool.RestoreNodes()
+	for i := 0; i < 3; i++ {
+		_, err = pool.Open("")
+		if err != nil {
+			break
+		}
+	}
+	if err != nodeErrors["node1"] {
+		t.Fatalf("logon error - received: %v - expected: %v", err, nodeErrors["node1"])
+	}
+	if len(pool.ActiveNodes()) != 3 {
+		t.Fatalf("logon error active nodes - received: %v - expected 3 nodes", pool.ActiveNodes())
+	}
+	nodeErrors = map[string]error{}
+
+	// removed nodes are tried again after the retry interval
+	now := time.Now()
+	pool.now = func() time.Time { return now }
+	badNodes = map[string]bool{"node1": true, "node2": true, "node3": true}
+	_, err = pool.Open("")
+	if err != ErrNoRACNodes {
+		t.Fatalf("outage - received: %v - expected: %v", err, ErrNoRACNodes)
+	}
+	badNodes = map[string]bool{}
+	now = now.Add(defaultRACRetry - time.Second)
+	opened = nil
+	_, err = pool.Open("")
+	if err != ErrNoRACNodes || len(opened) != 0 {
+		t.Fatalf("before retry interval - received: %v opened %v - expected: %v", err, opened, ErrNoRACNodes)
+	}
+	now = now.Add(time.Second)
+	if len(pool.ActiveNodes()) != 3 {
+		t.Fatalf("retry interval active nodes - received: %v - expected 3 nodes", pool.ActiveNodes())
+	}
+	dsns = testOpenNodes(pool, 3)
+	sort.Strings(dsns)
+	expected = []string{"node1", "node2", "node3"}
+	if !reflect.DeepEqual(dsns, expected) {
+		t.Fatalf("after retry interval - received: %v - expected: %v", dsns, expected)
+	}
+
+	// a zero retry interval tries each node once per Open
+	pool.SetRetryInterval(0)
+	badNodes = map[string]bool{"node1": true, "node2": true, "node3": true}
+	opened = nil
+	_, err = pool.Open("")
+	if err != ErrNoRACNodes || len(opened) != 3 {
+		t.Fatalf("zero retry interval - received: %v opened %v - expected: %v", err, opened, ErrNoRACNodes)
+	}
+	badNodes = map[string]bool{}
+
+	latencies["node1"] = 20 * time.Millisecond
+	latencies["node2"] = 10 * time.Millisecond
+	pool = NewRACPool(RACLatency, "node1", "node2", "node3")
+	pool.open = testOpen
+	dsns = testOpenNodes(pool, 6)
+	// each node is opened once to get a latency, then the lowest latency node is used
+	expected = []string{"node1", "node2", "node3", "node3", "node3", "node3"}
+	if !reflect.DeepEqual(dsns, expected) {
+		t.Fatalf("latency - received: %v - expected: %v", dsns, expected)
+	}
+}
+
+// TestOraErrorCode tests getting the error code from ORA- error text
+func TestOraErrorCode(t *testing.T) {
+	var tests = []struct {
+		err      error
+		expected int
+	}{
+		{err: nil, expected: 0},
+		{err: errors.New("ORA-04043: object FOO does not exist\n"), expected: 4043},
+		{err: errors.New("ORA-06564: object FOO does not exist\n"), expected: 6564},
+		{err: errors.New("ORA-0404: short"), expected: 0},
+		{err: errors.New("ORA-abcde: not a code"), expected: 0},
+		{err: driver.ErrBadConn, expected: 0},
+	}
+	for _, tt := range tests {
+		code := oraErrorCode(tt.err)
+		if code != tt.expected {
+			t.Errorf("oraErrorCode(%v) - received: %v - expected: %v", tt.err, code, tt.expected)
+		}
+	}
+}
+
+// TestIsRACNodeFailure tests the Open errors that remove a RAC pool node
+func TestIsRACNodeFailure(t *testing.T) {
+	var tests = []struct {
+		err      error
+		expected bool
+	}{
+		{err: driver.ErrBadConn, expected: true},
+		{err: errors.New("ORA-12541: TNS:no listener\n"), expected: true},
+		{err: errors.New("ORA-12514: TNS:listener does not currently know of service requested in connect descriptor\n"), expected: true},
+		{err: errors.New("ORA-12170: TNS:Connect timeout occurred\n"), expected: true},
+		{err: errors.New("ORA-12545: Connect failed because target host or object does not exist\n"), expected: true},
+		{err: errors.New("ORA-01017: invalid username/password; logon denied\n")},
+		{err: errors.New("ORA-28000: the account is locked\n")},
+		{err: errors.New("ORA-1254")},
+		{err: errors.New("empty dsn")},
+		{err: nil},
+	}
+
+	for _, tt := range tests {
+		failure := isRACNodeFailure(tt.err)
+		if failure != tt.expected {
+			t.Errorf("isRACNodeFailure(%v) - received: %v - expected: %v", tt.err, failure, tt.expected)
+		}
+	}
+}
+
+// TestKeepAliveConnect tests adding EXPIRE_TIME to connect strings
+func TestKeepAliveConnect(t *testing.T) {
+	var connectTests = []struct {
+		connect   string
+		keepAlive time.Duration
+		expected  string
+	}{
+		{"107.20.30.169:1521/ORCL", time.Minute, "107.20.30.169:1521/ORCL?expire_time=1"},
+		{"107.20.30.169:1521/ORCL", 90 * time.Second, "107.20.30.169:1521/ORCL?expire_time=2"},
+		{"107.20.30.169:1521/ORCL", time.Second, "107.20.30.169:1521/ORCL?expire_time=1"},
+		{"(DESCRIPTION=(ADDRESS=(PROTOCOL=TCP)(HOST=107.20.30.169)(PORT=1521))(CONNECT_DATA=(SERVICE_NAME=ORCL)))", 10 * time.Minute,
+			"(DESCRIPTION=(EXPIRE_TIME=10)(ADDRESS=(PROTOCOL=TCP)(HOST=107.20.30.169)(PORT=1521))(CONNECT_DATA=(SERVICE_NAME=ORCL)))"},
+	}
+
+	for _, tt := range connectTests {
+		actual := keepAliveConnect(tt.connect, tt.keepAlive)
+		if actual != tt.expected {
+			t.Errorf("keepAliveConnect(%v, %v): expected %v, actual %v", tt.connect, tt.keepAlive, tt.expected, actual)
+		}
+	}
+}
+
+// TestTruncateFractionalSeconds tests truncating time to fractional seconds precision
+func TestTruncateFractionalSeconds(t *testing.T) {
+	aTime := time.Date(2006, 1, 2, 15, 4, 5, 123456789, time.UTC)
+
+	var truncateTests = []struct {
+		precision  int
+		nanosecond int
+	}{
+		{0, 0},
+		{3, 123000000},
+		{6, 123456000},
+		{9, 123456789},
+	}
+
+	for _, tt := range truncateTests {
+		actual := truncateFractionalSeconds(aTime, tt.precision)
+		if actual.Nanosecond() != tt.nanosecond || actual.Unix() != aTime.Unix() {
+			t.Errorf("truncateFractionalSeconds(%v, %v): expected nanosecond %v, actual %v", aTime, tt.precision, tt.nanosecond, actual)
+		}
+	}
+}
+
+// TestConvertOCIError tests converting OCI errors
+func TestConvertOCIError(t *testing.T) {
+	oraErr := errors.New("ORA-01555: snapshot too old: rollback segment number 10 with name \"_SYSSMU10$\" too small")
+	err := convertOCIError(1555, oraErr)
+	if !errors.Is(err, ErrSnapshotTooOld) {
+		t.Fatalf("convertOCIError 1555 - received: %v - expected: %v", err, ErrSnapshotTooOld)
+	}
+	if err.Error() != oraErr.Error() {
+		t.Fatalf("convertOCIError 1555 text - received: %v - expected: %v", err.Error(), oraErr.Error())
+	}
+
+	err = convertOCIError(3113, errors.New("ORA-03113: end-of-file on communication channel"))
+	if err != driver.ErrBadConn {
+		t.Fatalf("convertOCIError 3113 - received: %v - expected: %v", err, driver.ErrBadConn)
+	}
+
+	oraErr = errors.New("ORA-00942: table or view does not exist")
+	err = convertOCIError(942, oraErr)
+	if err != oraErr || errors.Is(err, ErrSnapshotTooOld) {
+		t.Fatalf("convertOCIError 942 - received: %v - expected: %v", err, oraErr)
+	}
+}
+
+// TestSetSQLNetOra tests SetSQLNetOra can only be set to one directory
+func TestSetSQLNetOra(t *testing.T) {
+	tnsAdminMutex.Lock()
+	saveTNSAdmin := tnsAdmin
+	tnsAdmin = "/opt/oracle/network/admin"
+	tnsAdminMutex.Unlock()
+	defer func() {
+		tnsAdminMutex.Lock()
+		tnsAdmin = saveTNSAdmin
+		tnsAdminMutex.Unlock()
+	}()
+
+	err := SetSQLNetOra("/opt/oracle/network/admin/sqlnet.ora")
+	if err != nil {
+		t.Errorf("set same sqlnet.ora error: %v", err)
+	}
+	err = SetSQLNetOra("/opt/oracle/network/admin")
+	if err != nil {
+		t.Errorf("set same directory error: %v", err)
+	}
+
+	err = SetSQLNetOra("/etc/oracle/sqlnet.ora")
+	expected := "TNS_ADMIN already set to /opt/oracle/network/admin"
+	if err == nil || err.Error() != expected {
+		t.Errorf("set different sqlnet.ora error - received: %v - expected: %v", err, expected)
+	}
+
+	err = SetSQLNetOra("")
+	if err == nil {
+		t.Error("set empty sqlnet.ora error is nil")
+	}
+}
@@ -0,0 +1,47 @@
+package oci8
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+const (
+	setOLSLabelQuery = "begin SA_SESSION.SET_LABEL(:1, :2); end;"
+	getOLSLabelQuery = "select SA_SESSION.READ_LABEL(:1) from dual"
+)
+
+// SetOLSLabel sets the Oracle Label Security session label of the policy with SA_SESSION.SET_LABEL.
+// The statement is prepared once per connection.
+func (conn *OCI8Conn) SetOLSLabel(policyName string, label string) error {
+	stmt, err := conn.cachedStmt(context.Background(), setOLSLabelQuery)
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(context.Background(), []driver.NamedValue{{Ordinal: 1, Value: policyName}, {Ordinal: 2, Value: label}})
+	return err
+}
+
+// GetOLSLabel returns the Oracle Label Security session label of the policy with SA_SESSION.READ_LABEL.
+// The statement is prepared once per connection.
+func (conn *OCI8Conn) GetOLSLabel(policyName string) (string, error) {
+	stmt, err := conn.cachedStmt(context.Background(), getOLSLabelQuery)
+	if err != nil {
+		return "", err
+	}
+
+	rows, err := stmt.QueryContext(context.Background(), []driver.NamedValue{{Ordinal: 1, Value: policyName}})
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	dest := make([]driver.Value, 1)
+	err = rows.Next(dest)
+	if err != nil {
+		return "", err
+	}
+
+	label, _ := dest[0].(string)
+	return label, nil
+}
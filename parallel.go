@@ -0,0 +1,65 @@
+package oci8
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ExecParallel executes the statements at the same time, each on a connection opened with the DSN and current password of this connection,
+// up to the max_parallel DSN parameter connections, which defaults to 4. A connection runs another statement when its statement is done.
+// The returned errors are in the order of the statements, nil for a statement that succeeded.
+// A failed statement does not stop the other statements. If the context is done, the running statements are stopped
+// with OCIBreak and the statements not yet run are not run, all with the context error.
+// The statements run in their own sessions, so they do not see the transaction of this connection,
+// and each one is committed. Statements can not have binds, like CREATE INDEX DDL.
+func (conn *OCI8Conn) ExecParallel(ctx context.Context, stmts []string) []error {
+	errs := make([]error, len(stmts))
+	maxParallel := conn.maxParallel
+	if maxParallel < 1 {
+		maxParallel = defaultMaxParallel
+	}
+	if maxParallel > len(stmts) {
+		maxParallel = len(stmts)
+	}
+
+	indexes := make(chan int)
+	var waitGroup sync.WaitGroup
+	for i := 0; i < maxParallel; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			var parallelConn *OCI8Conn
+			for index := range indexes {
+				if ctx.Err() != nil {
+					errs[index] = ctx.Err()
+					continue
+				}
+				if parallelConn == nil {
+					dsn := conn.dsn
+					driverConn, err := conn.oci8Driver.open(&dsn)
+					if err != nil {
+						errs[index] = fmt.Errorf("open connection error: %w", err)
+						continue
+					}
+					parallelConn = driverConn.(*OCI8Conn)
+				}
+				_, errs[index] = parallelConn.execScriptStatement(ctx, stmts[index])
+				if errs[index] != nil && ctx.Err() != nil {
+					errs[index] = ctx.Err()
+				}
+			}
+			if parallelConn != nil {
+				parallelConn.Close()
+			}
+		}()
+	}
+
+	for index := range stmts {
+		indexes <- index
+	}
+	close(indexes)
+	waitGroup.Wait()
+
+	return errs
+}
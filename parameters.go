@@ -0,0 +1,302 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"regexp"
+	"strings"
+	"unsafe"
+)
+
+// procedureCallRegexp matches an anonymous PL/SQL block that calls a single procedure, or assigns the result of a function to a bind
+var procedureCallRegexp = regexp.MustCompile(`(?is)^\s*begin\s+(?::([a-z0-9_$#]+)\s*:=\s*)?([a-z][a-z0-9_$#.]*)\s*(?:\(([^;]*)\))?\s*;\s*end\s*;?\s*$`)
+
+// Parameters describes the bind variables of the statement, in the order they first appear, without duplicate names.
+// The bind names are from OCIStmtGetBindInfo. OCI does not describe the binds of a statement, so for SQL statements
+// the binds have Direction ParameterIn and no DataType or MaxLength.
+// For a PL/SQL block that only calls a standalone procedure or function, like begin proc(:a, b => :b); end; or begin :r := func(:a); end;,
+// the procedure is described with OCIDescribeAny and binds passed directly as arguments get the argument direction, data type, and max length.
+// If the procedure cannot be described, like a procedure in a package, the binds are returned as for SQL statements.
+func (stmt *OCI8Stmt) Parameters() ([]ParameterInfo, error) {
+	names, err := stmt.ociStmtGetBindInfo()
+	if err != nil {
+		return nil, err
+	}
+	parameters := make([]ParameterInfo, len(names))
+	for i, name := range names {
+		parameters[i] = ParameterInfo{Name: name, Direction: ParameterIn}
+	}
+	if len(parameters) < 1 {
+		return parameters, nil
+	}
+
+	var stmtType C.ub2
+	_, err = stmt.ociAttrGet(unsafe.Pointer(&stmtType), C.OCI_ATTR_STMT_TYPE)
+	if err != nil {
+		return nil, err
+	}
+	if stmtType != C.OCI_STMT_BEGIN {
+		return parameters, nil
+	}
+
+	var queryP *C.OraText // statement text
+	size, err := stmt.ociAttrGet(unsafe.Pointer(&queryP), C.OCI_ATTR_STATEMENT)
+	if err != nil {
+		return nil, err
+	}
+
+	returnBind, procedureName, args, ok := procedureCall(cGoStringN(queryP, int(size)))
+	if !ok {
+		return parameters, nil
+	}
+
+	describe, param, err := stmt.conn.ociDescribeAny(procedureName, C.OCI_PTYPE_UNK)
+	if err != nil {
+		switch oraErrorCode(err) {
+		case 4043, 6564:
+			// packaged procedures, like DBMS_OUTPUT.PUT_LINE, are not described, ORA-04043: object does not exist,
+			// or ORA-06564: object does not exist
+			return parameters, nil
+		}
+		return nil, err
+	}
+	defer C.OCIHandleFree(unsafe.Pointer(describe), C.OCI_HTYPE_DESCRIBE)
+
+	var ptype C.ub1
+	_, err = stmt.conn.ociAttrGet(param, unsafe.Pointer(&ptype), C.OCI_ATTR_PTYPE)
+	if err != nil {
+		return nil, err
+	}
+	if ptype != C.OCI_PTYPE_PROC && ptype != C.OCI_PTYPE_FUNC {
+		return parameters, nil
+	}
+
+	var argList *C.OCIParam // parameter list of the arguments, for functions position 0 is the return value
+	_, err = stmt.conn.ociAttrGet(param, unsafe.Pointer(&argList), C.OCI_ATTR_LIST_ARGUMENTS)
+	if err != nil {
+		return nil, err
+	}
+	var numParams C.ub2
+	_, err = stmt.conn.ociAttrGet(argList, unsafe.Pointer(&numParams), C.OCI_ATTR_NUM_PARAMS)
+	if err != nil {
+		return nil, err
+	}
+
+	// argument infos of the procedure, the function return value is first with an empty name
+	start, end := 1, int(numParams)
+	if ptype == C.OCI_PTYPE_FUNC {
+		start, end = 0, int(numParams)-1
+	}
+	argInfos := make([]ParameterInfo, 0, end-start+1)
+	for position := start; position <= end; position++ {
+		var arg *C.OCIParam
+		arg, err = stmt.conn.ociParamGet(argList, C.ub4(position))
+		if err != nil {
+			return nil, err
+		}
+		var argInfo ParameterInfo
+		argInfo, err = stmt.conn.describeArgument(arg)
+		if err != nil {
+			return nil, err
+		}
+		argInfos = append(argInfos, argInfo)
+	}
+
+	setParameter := func(bind string, argInfo ParameterInfo) {
+		for i := range parameters {
+			if strings.EqualFold(parameters[i].Name, bind) {
+				parameters[i].Direction = argInfo.Direction
+				parameters[i].DataType = argInfo.DataType
+				parameters[i].MaxLength = argInfo.MaxLength
+				return
+			}
+		}
+	}
+
+	if ptype == C.OCI_PTYPE_FUNC {
+		if returnBind != "" {
+			returnInfo := argInfos[0]
+			returnInfo.Direction = ParameterOut
+			setParameter(returnBind, returnInfo)
+		}
+		argInfos = argInfos[1:]
+	}
+
+	for i, arg := range args {
+		argName, bind := splitNamedArg(arg)
+		if !strings.HasPrefix(bind, ":") || !isBindName(bind[1:]) {
+			continue
+		}
+		if argName == "" {
+			if i < len(argInfos) {
+				setParameter(bind[1:], argInfos[i])
+			}
+			continue
+		}
+		for _, argInfo := range argInfos {
+			if strings.EqualFold(argInfo.Name, argName) {
+				setParameter(bind[1:], argInfo)
+				break
+			}
+		}
+	}
+
+	return parameters, nil
+}
+
+// describeArgument returns the name, direction, data type, and max length of a procedure argument parameter
+func (conn *OCI8Conn) describeArgument(param *C.OCIParam) (ParameterInfo, error) {
+	var parameterInfo ParameterInfo
+
+	var name *C.OraText // name of the argument, empty for a function return value
+	size, err := conn.ociAttrGet(param, unsafe.Pointer(&name), C.OCI_ATTR_NAME)
+	if err != nil {
+		return parameterInfo, err
+	}
+	parameterInfo.Name = cGoStringN(name, int(size))
+
+	var ioMode C.ub4 // OCI_TYPEPARAM_IN, OCI_TYPEPARAM_OUT, or OCI_TYPEPARAM_INOUT
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&ioMode), C.OCI_ATTR_IOMODE)
+	if err != nil {
+		return parameterInfo, err
+	}
+	switch ioMode {
+	case C.OCI_TYPEPARAM_OUT:
+		parameterInfo.Direction = ParameterOut
+	case C.OCI_TYPEPARAM_INOUT:
+		parameterInfo.Direction = ParameterInOut
+	default:
+		parameterInfo.Direction = ParameterIn
+	}
+
+	var dataType C.ub2 // internal data type of the argument
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&dataType), C.OCI_ATTR_DATA_TYPE)
+	if err != nil {
+		return parameterInfo, err
+	}
+	parameterInfo.DataType = int(dataType)
+
+	var dataSize C.ub2 // max size of the argument data in bytes
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&dataSize), C.OCI_ATTR_DATA_SIZE)
+	if err != nil {
+		return parameterInfo, err
+	}
+	parameterInfo.MaxLength = int(dataSize)
+
+	return parameterInfo, nil
+}
+
+// ociStmtGetBindInfo calls OCIStmtGetBindInfo then returns the bind names without duplicates
+func (stmt *OCI8Stmt) ociStmtGetBindInfo() ([]string, error) {
+	size := 32
+	for {
+		var found C.sb4 // number of binds, negative if more than size
+		bindNames := make([]*C.OraText, size)
+		bindNameLengths := make([]C.ub1, size)
+		indicatorNames := make([]*C.OraText, size)
+		indicatorNameLengths := make([]C.ub1, size)
+		duplicates := make([]C.ub1, size)
+		bindHandles := make([]*C.OCIBind, size)
+
+		result := C.OCIStmtGetBindInfo(
+			stmt.stmt,                // statement handle prepared by OCIStmtPrepare2
+			stmt.conn.errHandle,      // error handle
+			C.ub4(size),              // number of elements in each array
+			1,                        // position of the bind to start getting info
+			&found,                   // number of binds found
+			&bindNames[0],            // bind names
+			&bindNameLengths[0],      // bind name lengths
+			&indicatorNames[0],       // indicator names
+			&indicatorNameLengths[0], // indicator name lengths
+			&duplicates[0],           // nonzero if the bind name is a duplicate
+			&bindHandles[0],          // bind handles, nil if not bound
+		)
+		if result == C.OCI_NO_DATA {
+			return nil, nil
+		}
+		err := stmt.conn.getError(result)
+		if err != nil {
+			return nil, err
+		}
+
+		if found < 0 {
+			size = int(-found)
+			continue
+		}
+
+		names := make([]string, 0, int(found))
+		for i := 0; i < int(found); i++ {
+			if duplicates[i] != 0 {
+				continue
+			}
+			names = append(names, cGoStringN(bindNames[i], int(bindNameLengths[i])))
+		}
+		return names, nil
+	}
+}
+
+// procedureCall returns the return bind name, procedure name, and arguments of an anonymous PL/SQL block that only calls a procedure,
+// or ok false if the block is not a single procedure or function call
+func procedureCall(query string) (string, string, []string, bool) {
+	matches := procedureCallRegexp.FindStringSubmatch(query)
+	if matches == nil {
+		return "", "", nil, false
+	}
+	return matches[1], matches[2], splitArgs(matches[3]), true
+}
+
+// splitArgs splits procedure call arguments on commas not in parentheses or quotes, returning the trimmed arguments
+func splitArgs(args string) []string {
+	if strings.TrimSpace(args) == "" {
+		return nil
+	}
+
+	var result []string
+	depth := 0
+	inQuote := false
+	start := 0
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case '\'':
+			inQuote = !inQuote
+		case '(':
+			if !inQuote {
+				depth++
+			}
+		case ')':
+			if !inQuote {
+				depth--
+			}
+		case ',':
+			if !inQuote && depth == 0 {
+				result = append(result, strings.TrimSpace(args[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(result, strings.TrimSpace(args[start:]))
+}
+
+// splitNamedArg splits a named notation argument, name => value, returning the name and value, or an empty name for a positional argument
+func splitNamedArg(arg string) (string, string) {
+	index := strings.Index(arg, "=>")
+	if index < 0 {
+		return "", arg
+	}
+	return strings.TrimSpace(arg[:index]), strings.TrimSpace(arg[index+2:])
+}
+
+// isBindName returns true if the name is a bind name: letters, digits, _, $, or #
+func isBindName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if !(c == '_' || c == '$' || c == '#' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,189 @@
+package oci8
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	// maxPipeWait is DBMS_PIPE.MAXWAIT in seconds
+	maxPipeWait = 86400000
+	// defaultPipeSize is the DBMS_PIPE default max pipe size in bytes
+	defaultPipeSize = 8192
+	// pipeDateFormat is the format of DATE pipe items sent as text
+	pipeDateFormat = "2006-01-02 15:04:05"
+)
+
+// CreatePipe creates the DBMS_PIPE pipe with the max pipe size in bytes, 0 uses the default of 8192.
+// A private pipe can only be used by sessions of the same user. Pipes are removed with RemovePipe.
+// Needs execute on DBMS_PIPE.
+func (conn *OCI8Conn) CreatePipe(pipeName string, maxPipeSize int, private bool) error {
+	if maxPipeSize <= 0 {
+		maxPipeSize = defaultPipeSize
+	}
+	var isPrivate int64
+	if private {
+		isPrivate = 1
+	}
+
+	var returnCode int64
+	err := conn.execArgs(context.Background(), "begin :1 := DBMS_PIPE.CREATE_PIPE(:2, :3, :4 = 1); end;",
+		sql.Out{Dest: &returnCode}, pipeName, int64(maxPipeSize), isPrivate)
+	if err != nil {
+		return err
+	}
+	if returnCode != 0 {
+		return fmt.Errorf("create pipe unknown return code: %v", returnCode)
+	}
+	return nil
+}
+
+// RemovePipe removes the DBMS_PIPE pipe
+func (conn *OCI8Conn) RemovePipe(pipeName string) error {
+	var returnCode int64
+	return conn.execArgs(context.Background(), "begin :1 := DBMS_PIPE.REMOVE_PIPE(:2); end;", sql.Out{Dest: &returnCode}, pipeName)
+}
+
+// SendMessage packs the items into a message then sends it on the DBMS_PIPE pipe, waiting up to the timeout,
+// whole seconds rounded up, for room in the pipe. A negative timeout waits forever. Returns ErrPipeTimeout on timeout.
+// Item types are int, int64, float64 as NUMBER, string as VARCHAR2, []byte as RAW, and time.Time as DATE.
+// If the pipe does not exist, a public pipe is created.
+func (conn *OCI8Conn) SendMessage(pipeName string, timeout time.Duration, items ...interface{}) error {
+	query, args, err := pipeSendQuery(items, conn.timeLocation)
+	if err != nil {
+		return err
+	}
+
+	var returnCode int64
+	args = append(args, sql.Out{Dest: &returnCode}, pipeName, pipeTimeoutSeconds(timeout))
+	err = conn.execArgs(context.Background(), query, args...)
+	if err != nil {
+		return err
+	}
+
+	return pipeError("send message", returnCode)
+}
+
+// ReceiveMessage waits up to the timeout, whole seconds rounded up, for a message on the DBMS_PIPE pipe then unpacks its items.
+// A negative timeout waits forever. Returns ErrPipeTimeout on timeout.
+// If the context is done while waiting, OCIBreak is called and the context error is returned.
+// Item types returned are float64 for NUMBER, string for VARCHAR2 and ROWID, []byte for RAW, and time.Time for DATE.
+func (conn *OCI8Conn) ReceiveMessage(ctx context.Context, pipeName string, timeout time.Duration) ([]interface{}, error) {
+	var returnCode int64
+	err := conn.execArgs(ctx, "begin :1 := DBMS_PIPE.RECEIVE_MESSAGE(:2, :3); end;",
+		sql.Out{Dest: &returnCode}, pipeName, pipeTimeoutSeconds(timeout))
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	err = pipeError("receive message", returnCode)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []interface{}
+	for {
+		var itemType int64
+		err = conn.execArgs(ctx, "begin :1 := DBMS_PIPE.NEXT_ITEM_TYPE; end;", sql.Out{Dest: &itemType})
+		if err != nil {
+			return nil, err
+		}
+
+		var item interface{}
+		switch itemType {
+		case 0: // no more items
+			return items, nil
+		case 6: // NUMBER
+			var value float64
+			err = conn.execArgs(ctx, "declare item number; begin DBMS_PIPE.UNPACK_MESSAGE(item); :1 := item; end;", sql.Out{Dest: &value})
+			item = value
+		case 9: // VARCHAR2
+			var value string
+			err = conn.execArgs(ctx, "declare item varchar2(32767); begin DBMS_PIPE.UNPACK_MESSAGE(item); :1 := item; end;", sql.Out{Dest: &value})
+			item = value
+		case 11: // ROWID
+			var value string
+			err = conn.execArgs(ctx, "declare item rowid; begin DBMS_PIPE.UNPACK_MESSAGE_ROWID(item); :1 := rowidtochar(item); end;", sql.Out{Dest: &value})
+			item = value
+		case 12: // DATE
+			var value string
+			err = conn.execArgs(ctx, "declare item date; begin DBMS_PIPE.UNPACK_MESSAGE(item); :1 := to_char(item, 'YYYY-MM-DD HH24:MI:SS'); end;", sql.Out{Dest: &value})
+			if err == nil {
+				item, err = time.ParseInLocation(pipeDateFormat, value, conn.timeLocation)
+			}
+		case 23: // RAW
+			var value []byte
+			err = conn.execArgs(ctx, "declare item raw(32767); begin DBMS_PIPE.UNPACK_MESSAGE_RAW(item); :1 := item; end;", sql.Out{Dest: &value})
+			item = value
+		default:
+			return nil, fmt.Errorf("unknown pipe item type: %v", itemType)
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+}
+
+// pipeSendQuery returns the PL/SQL block that packs the items then sends the message, and the item binds.
+// The bind after the item binds is the return code, followed by the pipe name and timeout.
+func pipeSendQuery(items []interface{}, location *time.Location) (string, []interface{}, error) {
+	query := "begin DBMS_PIPE.RESET_BUFFER; "
+	args := make([]interface{}, 0, len(items)+3)
+	for i, item := range items {
+		position := strconv.Itoa(i + 1)
+		switch item := item.(type) {
+		case int:
+			query += "DBMS_PIPE.PACK_MESSAGE(:" + position + "); "
+			args = append(args, int64(item))
+		case int64, float64, string:
+			query += "DBMS_PIPE.PACK_MESSAGE(:" + position + "); "
+			args = append(args, item)
+		case []byte:
+			query += "DBMS_PIPE.PACK_MESSAGE_RAW(:" + position + "); "
+			args = append(args, item)
+		case time.Time:
+			query += "DBMS_PIPE.PACK_MESSAGE(to_date(:" + position + ", 'YYYY-MM-DD HH24:MI:SS')); "
+			args = append(args, item.In(location).Format(pipeDateFormat))
+		default:
+			return "", nil, fmt.Errorf("invalid pipe item type: %T", item)
+		}
+	}
+
+	position := len(items) + 1
+	query += fmt.Sprintf(":%v := DBMS_PIPE.SEND_MESSAGE(:%v, :%v); end;", position, position+1, position+2)
+	return query, args, nil
+}
+
+// pipeTimeoutSeconds returns the timeout in whole seconds rounded up, a negative timeout is DBMS_PIPE.MAXWAIT
+func pipeTimeoutSeconds(timeout time.Duration) int64 {
+	if timeout < 0 {
+		return maxPipeWait
+	}
+	seconds := int64((timeout + time.Second - 1) / time.Second)
+	if seconds > maxPipeWait {
+		seconds = maxPipeWait
+	}
+	return seconds
+}
+
+// pipeError returns the error for a DBMS_PIPE.SEND_MESSAGE or RECEIVE_MESSAGE return code
+func pipeError(call string, returnCode int64) error {
+	switch returnCode {
+	case 0:
+		return nil
+	case 1:
+		return ErrPipeTimeout
+	case 2:
+		return errors.New(call + " record too large for buffer")
+	case 3:
+		return errors.New(call + " interrupted")
+	}
+	return fmt.Errorf("%v unknown return code: %v", call, returnCode)
+}
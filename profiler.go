@@ -0,0 +1,65 @@
+package oci8
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// startProfilerQuery starts the profiler with DBMS_PROFILER.START_PROFILER.
+// Binds are the returned status, the run comment, and the returned run number.
+const startProfilerQuery = "begin :1 := DBMS_PROFILER.START_PROFILER(run_comment => :2, run_number => :3); end;"
+
+// profilerError returns the error of a DBMS_PROFILER return status, or nil for 0, success
+func profilerError(function string, status int64) error {
+	switch status {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("%v error: incorrect parameter", function)
+	case 2:
+		return fmt.Errorf("%v error: data flush failed, check the profiler tables exist and are writable", function)
+	case -1:
+		return fmt.Errorf("%v error: profiler version mismatch with the profiler tables", function)
+	}
+	return fmt.Errorf("%v error: status %v", function, status)
+}
+
+// StartProfiler starts the PL/SQL profiler for the session with DBMS_PROFILER.START_PROFILER and returns the run number.
+// The PL/SQL units run until StopProfiler are profiled, the data is in the PLSQL_PROFILER_RUNS, PLSQL_PROFILER_UNITS,
+// and PLSQL_PROFILER_DATA tables for the run number. The tables are created with the rdbms/admin/proftab.sql script.
+// Units need to be compiled with debug information for line level data.
+func (conn *OCI8Conn) StartProfiler(runComment string) (int64, error) {
+	var status int64
+	var runID int64
+	err := conn.execArgs(context.Background(), startProfilerQuery, sql.Out{Dest: &status}, runComment, sql.Out{Dest: &runID})
+	if err != nil {
+		return 0, err
+	}
+
+	err = profilerError("start profiler", status)
+	if err != nil {
+		return 0, err
+	}
+	return runID, nil
+}
+
+// StopProfiler stops the PL/SQL profiler started by StartProfiler with DBMS_PROFILER.STOP_PROFILER, which flushes the profiler data
+func (conn *OCI8Conn) StopProfiler() error {
+	var status int64
+	err := conn.execArgs(context.Background(), "begin :1 := DBMS_PROFILER.STOP_PROFILER; end;", sql.Out{Dest: &status})
+	if err != nil {
+		return err
+	}
+	return profilerError("stop profiler", status)
+}
+
+// FlushProfilerData writes the profiler data collected so far to the profiler tables with DBMS_PROFILER.FLUSH_DATA, without stopping the profiler
+func (conn *OCI8Conn) FlushProfilerData() error {
+	var status int64
+	err := conn.execArgs(context.Background(), "begin :1 := DBMS_PROFILER.FLUSH_DATA; end;", sql.Out{Dest: &status})
+	if err != nil {
+		return err
+	}
+	return profilerError("flush profiler data", status)
+}
@@ -0,0 +1,152 @@
+package oci8
+
+import (
+	"database/sql/driver"
+	"time"
+)
+
+// NewRACPool returns a driver that opens connections to the RAC node DSNs, one per node, balanced by round-robin or latency.
+// A node is removed from the rotation when opening a connection to it fails because the node cannot be reached,
+// an Oracle Net error like ORA-12514, ORA-12541, or ORA-12170, or the instance is down, then the next node is tried.
+// A removed node is tried again after the retry interval, 30 seconds unless changed with SetRetryInterval.
+// Other errors, like ORA-01017: invalid username/password, are returned without removing the node.
+// Register the pool with sql.Register, then sql.Open with the registered name, the data source name is not used.
+func NewRACPool(balance RACBalance, dsns ...string) *OCI8RACPool {
+	pool := &OCI8RACPool{
+		balance:       balance,
+		retryInterval: defaultRACRetry,
+		open:          OCI8Driver.Open,
+		now:           time.Now,
+	}
+	for _, dsn := range dsns {
+		pool.nodes = append(pool.nodes, racNode{dsn: dsn})
+	}
+	return pool
+}
+
+// Open opens a connection to the next active RAC node. The name is not used.
+// Returns ErrNoRACNodes if all the nodes have been removed from the rotation and none is due for a retry.
+// Each Open tries at most as many nodes as the pool has.
+func (pool *OCI8RACPool) Open(name string) (driver.Conn, error) {
+	for i := 0; i < len(pool.nodes); i++ {
+		index, dsn, ok := pool.nextNode()
+		if !ok {
+			break
+		}
+
+		start := time.Now()
+		conn, err := pool.open(dsn)
+		if isRACNodeFailure(err) {
+			pool.removeNode(index)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		pool.addLatency(index, time.Since(start))
+		return conn, nil
+	}
+	return nil, ErrNoRACNodes
+}
+
+// SetRetryInterval sets how long a removed node is out of the rotation before it is tried again
+func (pool *OCI8RACPool) SetRetryInterval(retryInterval time.Duration) {
+	pool.mutex.Lock()
+	pool.retryInterval = retryInterval
+	pool.mutex.Unlock()
+}
+
+// ActiveNodes returns the DSNs of the nodes in the rotation, including removed nodes due for a retry
+func (pool *OCI8RACPool) ActiveNodes() []string {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	now := pool.now()
+	var dsns []string
+	for _, node := range pool.nodes {
+		if pool.isActive(node, now) {
+			dsns = append(dsns, node.dsn)
+		}
+	}
+	return dsns
+}
+
+// RestoreNodes puts all the removed nodes back in the rotation without waiting for the retry interval,
+// for example after a node is back up
+func (pool *OCI8RACPool) RestoreNodes() {
+	pool.mutex.Lock()
+	for i := range pool.nodes {
+		pool.nodes[i].removed = time.Time{}
+	}
+	pool.mutex.Unlock()
+}
+
+// isActive returns true if the node is in the rotation, it has not been removed or its retry interval has passed
+func (pool *OCI8RACPool) isActive(node racNode, now time.Time) bool {
+	return node.removed.IsZero() || now.Sub(node.removed) >= pool.retryInterval
+}
+
+// nextNode returns the index and DSN of the next active node, or false if there are no active nodes.
+// Round-robin is the node after the last one, latency is the node with the lowest average open time,
+// nodes not opened yet first.
+func (pool *OCI8RACPool) nextNode() (int, string, bool) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	now := pool.now()
+	best := -1
+	for i := 0; i < len(pool.nodes); i++ {
+		index := (pool.next + i) % len(pool.nodes)
+		node := pool.nodes[index]
+		if !pool.isActive(node, now) {
+			continue
+		}
+		if pool.balance != RACLatency {
+			best = index
+			break
+		}
+		if best < 0 || node.latency < pool.nodes[best].latency {
+			best = index
+		}
+	}
+	if best < 0 {
+		return 0, "", false
+	}
+
+	pool.next = best + 1
+	return best, pool.nodes[best].dsn, true
+}
+
+// isRACNodeFailure returns true if the error of opening a connection is a node failure: driver.ErrBadConn,
+// which is returned for errors like ORA-01034: ORACLE not available, or an Oracle Net error
+func isRACNodeFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if err == driver.ErrBadConn {
+		return true
+	}
+	return isNetError(oraErrorCode(err))
+}
+
+// removeNode removes the node from the rotation until the retry interval has passed
+func (pool *OCI8RACPool) removeNode(index int) {
+	pool.mutex.Lock()
+	pool.nodes[index].removed = pool.now()
+	pool.mutex.Unlock()
+}
+
+// addLatency adds the open time to the node moving average latency, weighting the new time by a quarter,
+// and puts a retried node back in the rotation
+func (pool *OCI8RACPool) addLatency(index int, latency time.Duration) {
+	pool.mutex.Lock()
+	node := &pool.nodes[index]
+	node.removed = time.Time{}
+	if node.latency == 0 {
+		node.latency = latency
+	} else {
+		node.latency = (3*node.latency + latency) / 4
+	}
+	pool.mutex.Unlock()
+}
@@ -0,0 +1,164 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// rebindMinSize is the min buffer size of string and []byte rebinds, so most new values fit in the buffer without binding again
+const rebindMinSize = 4000
+
+// Rebind executes the statement with the args reusing the binds, and the bind buffers, of the previous Rebind.
+// Values are copied into the existing buffers, so OCIBindByPos or OCIBindByName is only called again
+// when a value has a different type or does not fit the buffer. Values can be nil, int64, float64, bool, string, or []byte
+// up to 32767 bytes, after driver.DefaultParameterConverter conversion. Use ExecContext for other types and sql.Out.
+// Calling Exec, ExecContext, Query, or QueryContext on the statement replaces the binds, so the next Rebind binds again.
+// The binds are freed when the statement is closed. With the autoreturn_rowid DSN parameter, an INSERT statement
+// is prepared again without the added RETURNING clause.
+func (stmt *OCI8Stmt) Rebind(args []driver.NamedValue) error {
+	return stmt.RebindContext(context.Background(), args)
+}
+
+// RebindContext is Rebind with a context, if the context is done while executing, OCIBreak is called and the context error is returned
+func (stmt *OCI8Stmt) RebindContext(ctx context.Context, args []driver.NamedValue) error {
+	if stmt.closed {
+		return fmt.Errorf("statement is closed")
+	}
+	err := stmt.prepareWithoutReturningRowid(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(stmt.rebinds) != len(args) {
+		freeBinds(stmt.rebinds)
+		stmt.rebinds = make([]oci8Bind, len(args))
+		for i := range stmt.rebinds {
+			stmt.rebinds[i].length = (*C.ub2)(C.malloc(C.sizeof_ub2))
+			stmt.rebinds[i].indicator = (*C.sb2)(C.malloc(C.sizeof_sb2))
+		}
+		stmt.rebound = false
+	}
+
+	var bytesSent int64
+	for i, arg := range args {
+		value, err := driver.DefaultParameterConverter.ConvertValue(arg.Value)
+		if err != nil {
+			return fmt.Errorf("rebind value %v error: %v", i, err)
+		}
+		data, dataType, err := rebindData(stmt.conn.normalizeValue(value))
+		if err != nil {
+			return fmt.Errorf("rebind value %v error: %v", i, err)
+		}
+
+		bind := &stmt.rebinds[i]
+		if value == nil && bind.pbuf != nil {
+			// null keeps the current buffer
+			dataType = bind.dataType
+		}
+
+		if !stmt.rebound || bind.pbuf == nil || bind.dataType != dataType || int(bind.maxSize) < len(data) {
+			size := len(data)
+			if (dataType == C.SQLT_AFC || dataType == C.SQLT_BIN) && size < rebindMinSize {
+				size = rebindMinSize
+			}
+			if bind.pbuf == nil || bind.dataType != dataType || int(bind.maxSize) < size {
+				if bind.pbuf != nil {
+					C.free(bind.pbuf)
+				}
+				bind.pbuf = C.malloc(C.size_t(size))
+				bind.maxSize = C.sb4(size)
+				bind.dataType = dataType
+			}
+
+			if len(arg.Name) < 1 {
+				err = stmt.ociBindByPos(C.ub4(i+1), bind)
+			} else {
+				err = stmt.ociBindByName([]byte(":"+arg.Name), bind)
+			}
+			if err != nil {
+				stmt.rebound = false
+				return err
+			}
+		}
+
+		if value == nil {
+			*bind.length = 0
+			*bind.indicator = -1 // set to null
+			continue
+		}
+		buffer := (*[1 << 30]byte)(bind.pbuf)
+		copy(buffer[:], data)
+		*bind.length = C.ub2(len(data))
+		*bind.indicator = 0
+		bytesSent += int64(len(data))
+	}
+	stmt.rebound = true
+
+	stmt.conn.addStats(ConnStats{BytesSent: bytesSent})
+
+	mode := C.ub4(C.OCI_DEFAULT)
+	if stmt.conn.inTransaction == false {
+		mode = mode | C.OCI_COMMIT_ON_SUCCESS
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	done := make(chan struct{})
+	go stmt.conn.ociBreakDone(ctx, done)
+	err = stmt.ociStmtExecute(1, mode)
+	close(done)
+	if err != nil && err != ErrOCISuccessWithInfo {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+
+	return nil
+}
+
+// rebindData returns the bind data and data type of a converted driver value
+func rebindData(value driver.Value) ([]byte, C.ub2, error) {
+	switch value := value.(type) {
+	case nil:
+		return nil, C.SQLT_AFC, nil
+	case int64:
+		buffer := bytes.Buffer{}
+		binary.Write(&buffer, binary.LittleEndian, value)
+		return buffer.Bytes(), C.SQLT_INT, nil
+	case float64:
+		buffer := bytes.Buffer{}
+		binary.Write(&buffer, binary.LittleEndian, value)
+		return buffer.Bytes(), C.SQLT_BDOUBLE, nil
+	case bool: // oracle does not have bool, handle as 0/1 int64, the same size as an int64 rebind
+		buffer := bytes.Buffer{}
+		var number int64
+		if value {
+			number = 1
+		}
+		binary.Write(&buffer, binary.LittleEndian, number)
+		return buffer.Bytes(), C.SQLT_INT, nil
+	case string:
+		if len(value) > 32767 {
+			return nil, 0, fmt.Errorf("string of length %v is larger than 32767", len(value))
+		}
+		return []byte(value), C.SQLT_AFC, nil
+	case []byte:
+		if len(value) > 32767 {
+			return nil, 0, fmt.Errorf("[]byte of length %v is larger than 32767", len(value))
+		}
+		return value, C.SQLT_BIN, nil
+	case time.Time:
+		return nil, 0, fmt.Errorf("time.Time is not supported by rebind")
+	}
+	return nil, 0, fmt.Errorf("invalid rebind type: %T", value)
+}
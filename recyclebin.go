@@ -0,0 +1,62 @@
+package oci8
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"time"
+)
+
+// RecycleBinContents returns the dropped objects of the current user from USER_RECYCLEBIN, newest first
+func (conn *OCI8Conn) RecycleBinContents(ctx context.Context) ([]RecycleBinEntry, error) {
+	stmt, err := conn.PrepareContext(ctx, "select OBJECT_NAME, ORIGINAL_NAME, TYPE, to_date(DROPTIME, 'YYYY-MM-DD:HH24:MI:SS'), CAN_UNDROP, CAN_PURGE from USER_RECYCLEBIN order by DROPTIME desc, OBJECT_NAME")
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.(*OCI8Stmt).QueryContext(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []RecycleBinEntry
+	dest := make([]driver.Value, 6)
+	for {
+		err = rows.Next(dest)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var entry RecycleBinEntry
+		entry.ObjectName, _ = dest[0].(string)
+		entry.OriginalName, _ = dest[1].(string)
+		entry.Type, _ = dest[2].(string)
+		entry.DropTime, _ = dest[3].(time.Time)
+		entry.CanUndrop = dest[4] == "YES"
+		entry.CanPurge = dest[5] == "YES"
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// PurgeRecycleBin removes all the dropped objects of the current user from the recycle bin with PURGE RECYCLEBIN
+func (conn *OCI8Conn) PurgeRecycleBin(ctx context.Context) error {
+	_, err := conn.execScriptStatement(ctx, "purge recyclebin")
+	return err
+}
+
+// RestoreFromRecycleBin restores the most recently dropped table with the original name using FLASHBACK TABLE TO BEFORE DROP.
+// The original name must be an unquoted identifier. FLASHBACK TABLE is DDL, so it commits the current transaction.
+func (conn *OCI8Conn) RestoreFromRecycleBin(ctx context.Context, originalName string) error {
+	if !isIdentifier(originalName) {
+		return fmt.Errorf("invalid table name: %v", originalName)
+	}
+	_, err := conn.execScriptStatement(ctx, "flashback table "+originalName+" to before drop")
+	return err
+}
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"reflect"
@@ -23,20 +24,42 @@ func (rows *OCI8Rows) Close() error {
 	rows.closed = true
 	close(rows.done)
 
+	rows.stmt.conn.freeDefineObjects(rows.defines)
 	freeDefines(rows.defines)
+	if rows.pieceBuffer != nil {
+		C.free(rows.pieceBuffer)
+	}
+	if rows.pieceLength != nil {
+		C.free(unsafe.Pointer(rows.pieceLength))
+	}
+
+	rows.stmt.conn.logDBMSOutput()
+
+	if rows.closeStmt {
+		return rows.stmt.Close()
+	}
 
 	return nil
 }
 
-// Columns returns column names
+// Columns returns a copy of the column names, which are read once when the rows are created
 func (rows *OCI8Rows) Columns() []string {
-	names := make([]string, len(rows.defines))
-	for i, define := range rows.defines {
-		names[i] = define.name
-	}
+	names := make([]string, len(rows.columnNames))
+	copy(names, rows.columnNames)
 	return names
 }
 
+// ColumnNameMap returns a map of upper case column names to zero based column index.
+// To look up a column name case-insensitively use strings.ToUpper on the name.
+// If column names are duplicated the first column index is used.
+// The map is shared by all calls and should not be modified.
+func (rows *OCI8Rows) ColumnNameMap() (map[string]int, error) {
+	if rows.closed {
+		return nil, errors.New("rows are closed")
+	}
+	return rows.columnNameMap, nil
+}
+
 // Next gets next row
 func (rows *OCI8Rows) Next(dest []driver.Value) error {
 	if rows.closed {
@@ -47,20 +70,50 @@ func (rows *OCI8Rows) Next(dest []driver.Value) error {
 		return rows.ctx.Err()
 	}
 
-	result := C.OCIStmtFetch2(
-		rows.stmt.stmt,
-		rows.stmt.conn.errHandle,
-		1,
-		C.OCI_FETCH_NEXT,
-		0,
-		C.OCI_DEFAULT)
+	var result C.sword
+	var err error
+	if rows.dynamicFetch {
+		result, err = rows.fetchPieces()
+	} else {
+		result = C.OCIStmtFetch2(
+			rows.stmt.stmt,
+			rows.stmt.conn.errHandle,
+			1,
+			C.OCI_FETCH_NEXT,
+			0,
+			C.OCI_DEFAULT)
+	}
 	if result == C.OCI_NO_DATA {
 		return io.EOF
 	} else if result != C.OCI_SUCCESS && result != C.OCI_SUCCESS_WITH_INFO {
+		rows.stmt.conn.addStats(ConnStats{ErrorCount: 1})
+		if err != nil {
+			return err
+		}
 		return rows.stmt.conn.getError(result)
 	}
+	if err != nil {
+		return err
+	}
+
+	counts := ConnStats{FetchCount: 1}
+	for i := range rows.defines {
+		if *rows.defines[i].indicator != -1 && rows.defines[i].length != nil {
+			counts.BytesReceived += int64(*rows.defines[i].length)
+		}
+	}
+	rows.stmt.conn.addStats(counts)
 
 	for i := range dest {
+		if _, ok := rows.longWriters[i]; ok {
+			// streamed to the ScanLONG writer
+			if *rows.defines[i].indicator == -1 {
+				dest[i] = nil
+			} else {
+				dest[i] = rows.longLengths[i]
+			}
+			continue
+		}
 		if *rows.defines[i].indicator == -1 { // Null
 			dest[i] = nil
 			continue
@@ -85,6 +138,14 @@ func (rows *OCI8Rows) Next(dest []driver.Value) error {
 				0,
 				rows.stmt.conn.timeLocation)
 
+		// SQLT_NTY
+		case C.SQLT_NTY: // object and collection types
+			value, err := rows.stmt.conn.defineObjectValue(&rows.defines[i])
+			if err != nil {
+				return fmt.Errorf("object value for column %v - error: %v", i, err)
+			}
+			dest[i] = value
+
 		// SQLT_BLOB and SQLT_CLOB
 		case C.SQLT_BLOB, C.SQLT_CLOB:
 			lobLocator := (**C.OCILobLocator)(rows.defines[i].pbuf)
@@ -94,18 +155,32 @@ func (rows *OCI8Rows) Next(dest []driver.Value) error {
 			}
 
 			// set dest to buffer
-			if rows.defines[i].dataType == C.SQLT_BLOB {
+			if rows.defines[i].dataType == C.SQLT_BLOB || rows.defines[i].json {
 				dest[i] = buffer
 			} else {
 				dest[i] = string(buffer)
 			}
 
+		// SQLT_FILE
+		case C.SQLT_FILE: // BFILE
+			fileLocator := (**C.OCILobLocator)(rows.defines[i].pbuf)
+			bfile, err := rows.stmt.conn.ociLobFileGetName(*fileLocator)
+			if err != nil {
+				return fmt.Errorf("BFILE name for column %v - error: %v", i, err)
+			}
+			dest[i] = bfile
+
 		// SQLT_CHR, SQLT_STR, SQLT_AFC, SQLT_AVC, and SQLT_LNG
 		case C.SQLT_CHR, C.SQLT_STR, C.SQLT_AFC, C.SQLT_AVC, C.SQLT_LNG:
+			if rows.defines[i].json {
+				// inline JSON type
+				dest[i] = C.GoBytes(rows.defines[i].pbuf, C.int(*rows.defines[i].length))
+				break
+			}
 			dest[i] = C.GoStringN((*C.char)(rows.defines[i].pbuf), C.int(*rows.defines[i].length))
 
-		// SQLT_BIN
-		case C.SQLT_BIN: // RAW
+		// SQLT_BIN and SQLT_LBI
+		case C.SQLT_BIN, C.SQLT_LBI: // RAW and inline BLOB
 			buf := (*[1 << 30]byte)(rows.defines[i].pbuf)[0:*rows.defines[i].length]
 			dest[i] = buf
 
@@ -145,7 +220,7 @@ func (rows *OCI8Rows) Next(dest []driver.Value) error {
 			if err != nil {
 				return fmt.Errorf("ociDateTimeToTime for column %v - error: %v", i, err)
 			}
-			dest[i] = *aTime
+			dest[i] = truncateFractionalSeconds(*aTime, int(rows.defines[i].fsPrecision))
 
 		// SQLT_TIMESTAMP_TZ and SQLT_TIMESTAMP_LTZ
 		case C.SQLT_TIMESTAMP_TZ, C.SQLT_TIMESTAMP_LTZ:
@@ -153,7 +228,7 @@ func (rows *OCI8Rows) Next(dest []driver.Value) error {
 			if err != nil {
 				return fmt.Errorf("ociDateTimeToTime for column %v - error: %v", i, err)
 			}
-			dest[i] = *aTime
+			dest[i] = truncateFractionalSeconds(*aTime, int(rows.defines[i].fsPrecision))
 
 		// SQLT_INTERVAL_DS
 		case C.SQLT_INTERVAL_DS:
@@ -213,6 +288,10 @@ func (rows *OCI8Rows) ColumnTypeDatabaseTypeName(i int) string {
 		return ""
 	}
 
+	if rows.defines[i].json {
+		return "SQLT_JSON"
+	}
+
 	switch rows.defines[i].dataType {
 	case C.SQLT_CHR:
 		return "SQLT_CHR"
@@ -304,10 +383,14 @@ func (rows *OCI8Rows) ColumnTypeScanType(i int) reflect.Type {
 		return typeNil
 	}
 
+	if rows.defines[i].json {
+		return typeJSON
+	}
+
 	switch rows.defines[i].dataType {
 	case C.SQLT_AFC, C.SQLT_CHR, C.SQLT_VCS, C.SQLT_AVC, C.SQLT_CLOB, C.SQLT_RDD:
 		return typeString
-	case C.SQLT_BIN, C.SQLT_BLOB:
+	case C.SQLT_BIN, C.SQLT_BLOB, C.SQLT_LBI:
 		return typeSliceByte
 	case C.SQLT_INT:
 		return typeInt64
@@ -317,6 +400,8 @@ func (rows *OCI8Rows) ColumnTypeScanType(i int) reflect.Type {
 		return typeTime
 	case C.SQLT_INTERVAL_DS, C.SQLT_INTERVAL_YM:
 		return typeInt64
+	case C.SQLT_FILE:
+		return typeBFileRef
 	}
 
 	return typeNil
@@ -0,0 +1,42 @@
+package oci8
+
+import (
+	"context"
+	"errors"
+)
+
+// CreateSchedulerJob creates the DBMS_SCHEDULER job with DBMS_SCHEDULER.CREATE_JOB.
+// Creating a job does a commit. Needs the CREATE JOB privilege.
+func (conn *OCI8Conn) CreateSchedulerJob(ctx context.Context, job SchedulerJob) error {
+	if job.JobName == "" {
+		return errors.New("job name is empty")
+	}
+	jobType := job.JobType
+	if jobType == "" {
+		jobType = "PLSQL_BLOCK"
+	}
+
+	var startDate, repeatInterval interface{}
+	if !job.StartDate.IsZero() {
+		startDate = job.StartDate
+	}
+	if job.RepeatInterval != "" {
+		repeatInterval = job.RepeatInterval
+	}
+	var enabled int64
+	if job.Enabled {
+		enabled = 1
+	}
+
+	return conn.execArgs(ctx, "begin DBMS_SCHEDULER.CREATE_JOB(job_name => :1, job_type => :2, job_action => :3, start_date => :4, repeat_interval => :5, enabled => :6 = 1); end;",
+		job.JobName, jobType, job.JobAction, startDate, repeatInterval, enabled)
+}
+
+// DropSchedulerJob drops the DBMS_SCHEDULER job with DBMS_SCHEDULER.DROP_JOB. When force is true, a running job is stopped first.
+func (conn *OCI8Conn) DropSchedulerJob(ctx context.Context, jobName string, force bool) error {
+	var forceValue int64
+	if force {
+		forceValue = 1
+	}
+	return conn.execArgs(ctx, "begin DBMS_SCHEDULER.DROP_JOB(job_name => :1, force => :2 = 1); end;", jobName, forceValue)
+}
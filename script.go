@@ -0,0 +1,464 @@
+package oci8
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// ExecuteScript splits a SQL script into statements then executes each statement in order,
+// returning the results of the statements executed.
+// Like SQL*Plus, SQL statements are ended by a ; or a / on its own line.
+// PL/SQL blocks (BEGIN, DECLARE, CREATE PROCEDURE, etc.) are only ended by a / on its own line or the end of the script.
+func (conn *OCI8Conn) ExecuteScript(ctx context.Context, script string) ([]driver.Result, error) {
+	statements := splitScript(script)
+	results := make([]driver.Result, 0, len(statements))
+
+	for i, query := range statements {
+		result, err := conn.execScriptStatement(ctx, query)
+		if err != nil {
+			return results, fmt.Errorf("script statement %v error: %v", i+1, err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// ScriptOption is an option of ParseScript
+type ScriptOption func(*scriptOptions)
+
+// scriptOptions are the ParseScript options
+type scriptOptions struct {
+	strict bool
+}
+
+// ScriptStrict sets if ParseScript returns an error for undefined substitution variables, the default,
+// or when false leaves them in the script as is.
+func ScriptStrict(strict bool) ScriptOption {
+	return func(options *scriptOptions) {
+		options.strict = strict
+	}
+}
+
+// ParseScript replaces SQL*Plus style &name and &&name substitution variables in the script with the vars values,
+// so SQL*Plus migration scripts can be run with ExecuteScript. Like SQL*Plus, names are case insensitive
+// and a . right after the name ends it and is removed, so &prefix._TABLE works.
+// Values are escaped: in a single quoted string quotes are doubled, in a quoted identifier the value cannot have a double quote,
+// in a q'[...]' string the value cannot have the closing delimiter followed by a quote,
+// and elsewhere the value must be a number or an identifier, optionally with a schema, otherwise an error is returned.
+// Variables in comments are not replaced. Undefined variables return an error, unless ScriptStrict(false) is used.
+func ParseScript(script string, vars map[string]string, options ...ScriptOption) (string, error) {
+	scriptOptions := scriptOptions{strict: true}
+	for _, option := range options {
+		option(&scriptOptions)
+	}
+
+	upperVars := make(map[string]string, len(vars))
+	for name, value := range vars {
+		upperVars[strings.ToUpper(name)] = value
+	}
+
+	var parsed bytes.Buffer
+	var quote byte      // the quote of the quoted string or identifier the script is in, q for a q'[...]' string, or 0
+	var closeQuote byte // the closing delimiter of the q'[...]' string the script is in
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+
+		switch {
+		case quote == 0 && (c == 'q' || c == 'Q') && isAlternativeQuoteStart(script, i):
+			// alternative quoting, the string is ended by the closing delimiter then a quote, like q'[it's]'
+			quote = 'q'
+			closeQuote = alternativeQuoteClose(script[i+2])
+			parsed.WriteString(script[i : i+3])
+			i += 2
+
+		case quote == 'q' && c == closeQuote && i+1 < len(script) && script[i+1] == '\'':
+			quote = 0
+			parsed.WriteString(script[i : i+2])
+			i++
+
+		case quote == 0 && c == '-' && i+1 < len(script) && script[i+1] == '-':
+			// copy line comment
+			j := strings.IndexByte(script[i:], '\n')
+			if j < 0 {
+				j = len(script) - i
+			}
+			parsed.WriteString(script[i : i+j])
+			i += j - 1
+
+		case quote == 0 && c == '/' && i+1 < len(script) && script[i+1] == '*':
+			// copy block comment
+			j := strings.Index(script[i+2:], "*/")
+			if j < 0 {
+				parsed.WriteString(script[i:])
+				i = len(script)
+				break
+			}
+			parsed.WriteString(script[i : i+j+4])
+			i += j + 3
+
+		case quote != 'q' && (c == '\'' || c == '"'):
+			// '' is an escaped quote, which ends then starts the string again
+			if quote == 0 {
+				quote = c
+			} else if quote == c {
+				quote = 0
+			}
+			parsed.WriteByte(c)
+
+		case c == '&':
+			start := i
+			j := i + 1
+			if j < len(script) && script[j] == '&' {
+				j++
+			}
+			nameStart := j
+			for j < len(script) && isSubstitutionNameByte(script[j]) {
+				j++
+			}
+			if j == nameStart {
+				// not a substitution variable, like a & b
+				parsed.WriteByte(c)
+				break
+			}
+			name := script[nameStart:j]
+			if j < len(script) && script[j] == '.' {
+				j++
+			}
+			i = j - 1
+
+			value, ok := upperVars[strings.ToUpper(name)]
+			if !ok {
+				if scriptOptions.strict {
+					return "", fmt.Errorf("undefined substitution variable: %v", name)
+				}
+				parsed.WriteString(script[start:j])
+				break
+			}
+
+			switch quote {
+			case '\'':
+				value = strings.Replace(value, "'", "''", -1)
+			case '"':
+				if strings.IndexByte(value, '"') >= 0 {
+					return "", fmt.Errorf("substitution variable %v value has a double quote: %v", name, value)
+				}
+			case 'q':
+				if strings.Contains(value, string(closeQuote)+"'") {
+					return "", fmt.Errorf("substitution variable %v value ends the quoted string: %v", name, value)
+				}
+			default:
+				if !isTableName(value) && !isNumberLiteral(value) {
+					return "", fmt.Errorf("substitution variable %v value is not a number or identifier: %v", name, value)
+				}
+			}
+			parsed.WriteString(value)
+
+		default:
+			parsed.WriteByte(c)
+		}
+	}
+
+	return parsed.String(), nil
+}
+
+// isAlternativeQuoteStart returns true if a q'[...]' or nq'[...]' string starts at the q at index i of the script
+func isAlternativeQuoteStart(script string, i int) bool {
+	if i+2 >= len(script) || script[i+1] != '\'' {
+		return false
+	}
+	switch script[i+2] {
+	case ' ', '\t', '\r', '\n':
+		return false
+	}
+	start := i
+	if start > 0 && (script[start-1] == 'n' || script[start-1] == 'N') {
+		start--
+	}
+	return start == 0 || !isSubstitutionNameByte(script[start-1])
+}
+
+// alternativeQuoteClose returns the closing delimiter of a q'[...]' string for the opening delimiter
+func alternativeQuoteClose(c byte) byte {
+	switch c {
+	case '[':
+		return ']'
+	case '{':
+		return '}'
+	case '(':
+		return ')'
+	case '<':
+		return '>'
+	}
+	return c
+}
+
+// isSubstitutionNameByte returns true if the byte can be in a substitution variable name
+func isSubstitutionNameByte(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// isNumberLiteral returns true if the value is digits, with an optional leading minus sign and decimal point
+func isNumberLiteral(value string) bool {
+	value = strings.TrimPrefix(value, "-")
+	digits := 0
+	point := false
+	for i := 0; i < len(value); i++ {
+		switch {
+		case '0' <= value[i] && value[i] <= '9':
+			digits++
+		case value[i] == '.' && !point:
+			point = true
+		default:
+			return false
+		}
+	}
+	return digits > 0
+}
+
+// execScriptStatement prepares and executes a single script statement
+func (conn *OCI8Conn) execScriptStatement(ctx context.Context, query string) (driver.Result, error) {
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	return stmt.(*OCI8Stmt).exec(ctx, nil)
+}
+
+// execArgs prepares and executes a statement with the args as positional binds.
+// Positional binds are in the order the placeholders appear, so number the placeholders in that order.
+func (conn *OCI8Conn) execArgs(ctx context.Context, query string, args ...interface{}) error {
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	namedValues := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		namedValues[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+
+	_, err = stmt.(*OCI8Stmt).ExecContext(ctx, namedValues)
+	return err
+}
+
+// cachedStmt returns the statement for the query prepared by an earlier call, or prepares it.
+// Cached statements are used for internal queries run often and are closed when the connection is closed.
+func (conn *OCI8Conn) cachedStmt(ctx context.Context, query string) (*OCI8Stmt, error) {
+	if stmt, ok := conn.cachedStmts[query]; ok {
+		return stmt, nil
+	}
+
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if conn.cachedStmts == nil {
+		conn.cachedStmts = make(map[string]*OCI8Stmt)
+	}
+	conn.cachedStmts[query] = stmt.(*OCI8Stmt)
+
+	return stmt.(*OCI8Stmt), nil
+}
+
+// queryRowArgs prepares and runs a query with the args as positional binds then returns the values of the first row.
+// If there are no rows, io.EOF is returned.
+func (conn *OCI8Conn) queryRowArgs(ctx context.Context, query string, args ...interface{}) ([]driver.Value, error) {
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	namedValues := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		namedValues[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+
+	rows, err := stmt.(*OCI8Stmt).QueryContext(ctx, namedValues)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dest := make([]driver.Value, len(rows.Columns()))
+	err = rows.Next(dest)
+	if err != nil {
+		return nil, err
+	}
+
+	return dest, nil
+}
+
+// splitScript splits a SQL script into statements.
+// Quoted strings, quoted identifiers, and comments are not checked for delimiters.
+func splitScript(script string) []string {
+	var statements []string
+	var current bytes.Buffer
+
+	addStatement := func() {
+		statement := strings.TrimSpace(current.String())
+		current.Reset()
+		if scriptLeadingWords(statement, 1) != nil {
+			statements = append(statements, statement)
+		}
+	}
+
+	lineStart := true
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+
+		if lineStart {
+			lineStart = false
+			// a / on its own line ends the statement
+			line := script[i:]
+			if j := strings.IndexByte(line, '\n'); j >= 0 {
+				line = line[:j]
+			}
+			if strings.TrimSpace(line) == "/" {
+				addStatement()
+				i += len(line)
+				lineStart = true
+				continue
+			}
+		}
+
+		switch {
+		case c == '\n':
+			lineStart = true
+			current.WriteByte(c)
+
+		case (c == 'q' || c == 'Q') && isAlternativeQuoteStart(script, i):
+			// copy q'[...]' string up to the closing delimiter then a quote
+			j := strings.Index(script[i+3:], string(alternativeQuoteClose(script[i+2]))+"'")
+			if j < 0 {
+				current.WriteString(script[i:])
+				i = len(script)
+				break
+			}
+			quoted := script[i : i+j+5]
+			current.WriteString(quoted)
+			i += len(quoted) - 1
+
+		case c == '\'' || c == '"':
+			// copy quoted string or identifier, '' is an escaped quote which works out the same
+			j := strings.IndexByte(script[i+1:], c)
+			if j < 0 {
+				current.WriteString(script[i:])
+				i = len(script)
+				break
+			}
+			quoted := script[i : i+j+2]
+			current.WriteString(quoted)
+			i += len(quoted) - 1
+
+		case c == '-' && i+1 < len(script) && script[i+1] == '-':
+			// copy line comment without the new line
+			j := strings.IndexByte(script[i:], '\n')
+			if j < 0 {
+				j = len(script) - i
+			}
+			current.WriteString(script[i : i+j])
+			i += j - 1
+
+		case c == '/' && i+1 < len(script) && script[i+1] == '*':
+			// copy block comment
+			j := strings.Index(script[i+2:], "*/")
+			if j < 0 {
+				current.WriteString(script[i:])
+				i = len(script)
+				break
+			}
+			comment := script[i : i+j+4]
+			current.WriteString(comment)
+			i += len(comment) - 1
+
+		case c == ';' && !isPLSQLStatement(current.String()):
+			addStatement()
+
+		default:
+			current.WriteByte(c)
+		}
+	}
+
+	addStatement()
+
+	return statements
+}
+
+// isPLSQLStatement returns true if the statement starts a PL/SQL block or stored PL/SQL unit
+func isPLSQLStatement(statement string) bool {
+	words := scriptLeadingWords(statement, 5)
+	if len(words) < 1 {
+		return false
+	}
+
+	switch words[0] {
+	case "BEGIN", "DECLARE":
+		return true
+	case "CREATE":
+	default:
+		return false
+	}
+
+	for _, word := range words[1:] {
+		switch word {
+		case "OR", "REPLACE", "EDITIONABLE", "NONEDITIONABLE":
+		case "PROCEDURE", "FUNCTION", "PACKAGE", "TRIGGER", "TYPE", "LIBRARY", "JAVA":
+			return true
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+// scriptLeadingWords returns up to max upper case leading words of a statement, skipping comments
+func scriptLeadingWords(statement string, max int) []string {
+	var words []string
+
+	for len(statement) > 0 && len(words) < max {
+		statement = strings.TrimLeft(statement, " \t\r\n")
+		switch {
+		case statement == "":
+
+		case strings.HasPrefix(statement, "--"):
+			i := strings.IndexByte(statement, '\n')
+			if i < 0 {
+				return words
+			}
+			statement = statement[i+1:]
+
+		case strings.HasPrefix(statement, "/*"):
+			i := strings.Index(statement[2:], "*/")
+			if i < 0 {
+				return words
+			}
+			statement = statement[i+4:]
+
+		default:
+			i := strings.IndexFunc(statement, func(r rune) bool {
+				return !(r == '_' || r == '$' || r == '#' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+			})
+			if i == 0 {
+				// not a word, such as ( or a quote
+				return append(words, statement[:1])
+			}
+			if i < 0 {
+				i = len(statement)
+			}
+			words = append(words, strings.ToUpper(statement[:i]))
+			statement = statement[i:]
+		}
+	}
+
+	return words
+}
@@ -0,0 +1,51 @@
+package oci8
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+)
+
+// GenerateSequenceValues returns count NEXTVAL values of the sequence in one round trip,
+// with a CONNECT BY LEVEL select that is prefetched in one fetch.
+// The values are in the order returned by the sequence, which are not consecutive if other sessions use it or it is cached in RAC.
+// The sequence must be an unquoted identifier with an optional schema.
+func (conn *OCI8Conn) GenerateSequenceValues(ctx context.Context, seq string, count int) ([]int64, error) {
+	if !isTableName(seq) {
+		return nil, fmt.Errorf("invalid sequence name: %v", seq)
+	}
+	if count < 1 {
+		return nil, fmt.Errorf("invalid count: %v", count)
+	}
+
+	// NEXTVAL is an unconstrained NUMBER, which is fetched as float64, so cast it to fetch it as int64
+	stmt, err := conn.prepare(ctx, "select cast("+seq+".NEXTVAL as NUMBER(19)) from dual connect by level <= :1", false)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	// one more row than count so the end of the rows is returned with the prefetch
+	rows, err := stmt.QueryContext(WithPrefetchRows(ctx, uint32(count+1)), []driver.NamedValue{{Ordinal: 1, Value: int64(count)}})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make([]int64, 0, count)
+	dest := make([]driver.Value, 1)
+	for {
+		err = rows.Next(dest)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		value, _ := dest[0].(int64)
+		values = append(values, value)
+	}
+
+	return values, nil
+}
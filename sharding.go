@@ -0,0 +1,147 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// AddComponent adds a column value to the sharding key. Compound sharding keys have a component for each column.
+// Supported value types are string, int, int64, and []byte.
+func (shardingKey *OCI8ShardingKey) AddComponent(value interface{}) error {
+	switch value := value.(type) {
+	case string, int64, []byte:
+		shardingKey.components = append(shardingKey.components, value)
+	case int:
+		shardingKey.components = append(shardingKey.components, int64(value))
+	default:
+		return fmt.Errorf("invalid sharding key component type: %T", value)
+	}
+	return nil
+}
+
+// ociShardingKey allocates a sharding key descriptor then adds the sharding key components with OCIShardingKeyColumnAdd.
+// The descriptor needs to be freed with OCI_DTYPE_SHARDING_KEY.
+func (conn *OCI8Conn) ociShardingKey(shardingKey *OCI8ShardingKey) (unsafe.Pointer, error) {
+	descriptorP, _, err := conn.ociDescriptorAlloc(C.OCI_DTYPE_SHARDING_KEY, 0)
+	if err != nil {
+		return nil, fmt.Errorf("allocate sharding key descriptor error: %v", err)
+	}
+	descriptor := *descriptorP
+
+	for _, component := range shardingKey.components {
+		var result C.sword
+		switch value := component.(type) {
+		case string:
+			valueP := cString(value)
+			result = C.OCIShardingKeyColumnAdd(
+				(*C.OCIShardingKey)(descriptor), // sharding key descriptor
+				conn.errHandle,                  // error handle
+				unsafe.Pointer(valueP),          // column value
+				C.ub4(len(value)),               // length of the column value
+				C.SQLT_CHR,                      // data type of the column value
+				C.OCI_DEFAULT,                   // mode
+			)
+			C.free(unsafe.Pointer(valueP))
+		case int64:
+			valueC := C.sb8(value)
+			result = C.OCIShardingKeyColumnAdd((*C.OCIShardingKey)(descriptor), conn.errHandle, unsafe.Pointer(&valueC), C.ub4(unsafe.Sizeof(valueC)), C.SQLT_INT, C.OCI_DEFAULT)
+		case []byte:
+			valueP := C.CBytes(value)
+			result = C.OCIShardingKeyColumnAdd((*C.OCIShardingKey)(descriptor), conn.errHandle, valueP, C.ub4(len(value)), C.SQLT_BIN, C.OCI_DEFAULT)
+			C.free(valueP)
+		}
+		if result != C.OCI_SUCCESS {
+			C.OCIDescriptorFree(descriptor, C.OCI_DTYPE_SHARDING_KEY)
+			return nil, conn.getError(result)
+		}
+	}
+
+	return descriptor, nil
+}
+
+// shardingSessionGet gets a session with OCISessionGet so the connection is routed to the shard for the DSN sharding keys
+func (conn *OCI8Conn) shardingSessionGet(dsn *DSN, connect string) error {
+	authInfoP, _, err := conn.ociHandleAlloc(C.OCI_HTYPE_AUTHINFO, 0)
+	if err != nil {
+		return fmt.Errorf("allocate authentication information handle error: %v", err)
+	}
+	defer C.OCIHandleFree(*authInfoP, C.OCI_HTYPE_AUTHINFO)
+
+	mode := C.ub4(C.OCI_SESSGET_CREDEXT)
+	if len(dsn.Username) > 0 {
+		username := cString(dsn.Username)
+		defer C.free(unsafe.Pointer(username))
+		password := cString(dsn.Password)
+		defer C.free(unsafe.Pointer(password))
+
+		// specifies a username to use for authentication
+		err = conn.ociAttrSet(*authInfoP, C.OCI_HTYPE_AUTHINFO, unsafe.Pointer(username), C.ub4(len(dsn.Username)), C.OCI_ATTR_USERNAME)
+		if err != nil {
+			return fmt.Errorf("username attribute set error: %v", err)
+		}
+
+		// specifies a password to use for authentication
+		err = conn.ociAttrSet(*authInfoP, C.OCI_HTYPE_AUTHINFO, unsafe.Pointer(password), C.ub4(len(dsn.Password)), C.OCI_ATTR_PASSWORD)
+		if err != nil {
+			return fmt.Errorf("password attribute set error: %v", err)
+		}
+
+		mode = C.OCI_DEFAULT
+	}
+
+	shardingKey, err := conn.ociShardingKey(dsn.shardingKey)
+	if err != nil {
+		return fmt.Errorf("sharding key error: %v", err)
+	}
+	defer C.OCIDescriptorFree(shardingKey, C.OCI_DTYPE_SHARDING_KEY)
+
+	// sets the sharding key attribute of the authentication information
+	err = conn.ociAttrSet(*authInfoP, C.OCI_HTYPE_AUTHINFO, shardingKey, 0, C.OCI_ATTR_SHARDING_KEY)
+	if err != nil {
+		return fmt.Errorf("sharding key attribute set error: %v", err)
+	}
+
+	if dsn.superShardingKey != nil {
+		var superShardingKey unsafe.Pointer
+		superShardingKey, err = conn.ociShardingKey(dsn.superShardingKey)
+		if err != nil {
+			return fmt.Errorf("super sharding key error: %v", err)
+		}
+		defer C.OCIDescriptorFree(superShardingKey, C.OCI_DTYPE_SHARDING_KEY)
+
+		// sets the super sharding key attribute of the authentication information
+		err = conn.ociAttrSet(*authInfoP, C.OCI_HTYPE_AUTHINFO, superShardingKey, 0, C.OCI_ATTR_SUPER_SHARDING_KEY)
+		if err != nil {
+			return fmt.Errorf("super sharding key attribute set error: %v", err)
+		}
+	}
+
+	connectString := cString(connect)
+	defer C.free(unsafe.Pointer(connectString))
+
+	var svc *C.OCISvcCtx
+	result := C.OCISessionGet(
+		conn.env,                     // environment handle
+		conn.errHandle,               // error handle
+		&svc,                         // returns the service context
+		(*C.OCIAuthInfo)(*authInfoP), // authentication information handle with the sharding keys
+		connectString,                // connect string
+		C.ub4(len(connect)),          // length of the connect string
+		nil,                          // session tag, not used
+		0,                            // length of the session tag
+		nil,                          // returned session tag, not used
+		nil,                          // length of the returned session tag
+		nil,                          // found matching tag, not used
+		mode,                         // mode: OCI_DEFAULT or OCI_SESSGET_CREDEXT for external credentials
+	)
+	if result != C.OCI_SUCCESS && result != C.OCI_SUCCESS_WITH_INFO {
+		return conn.getError(result)
+	}
+	conn.svc = svc
+	conn.sessionGet = true
+
+	return nil
+}
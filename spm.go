@@ -0,0 +1,76 @@
+package oci8
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// loadPlansQuery loads the plans of the SQL id, and plan hash value when not null, from the cursor cache into SQL plan baselines.
+// Binds are the returned number of plans loaded, sql id, and plan hash value.
+const loadPlansQuery = "begin :1 := DBMS_SPM.LOAD_PLANS_FROM_CURSOR_CACHE(sql_id => :2, plan_hash_value => :3); end;"
+
+// evolveBaselineQuery verifies the non-accepted plans of the SQL plan baseline and accepts the ones that perform better.
+// Binds are the returned report and the plan name.
+const evolveBaselineQuery = "begin :1 := DBMS_SPM.EVOLVE_SQL_PLAN_BASELINE(plan_name => :2); end;"
+
+// LoadSQLPlanBaseline loads the plan of the SQL id with the plan hash value from the cursor cache as an accepted SQL plan baseline,
+// using DBMS_SPM.LOAD_PLANS_FROM_CURSOR_CACHE. An empty plan hash loads all the plans of the SQL id.
+// Returns an error if no plans were loaded. Needs the ADMINISTER SQL MANAGEMENT OBJECT privilege.
+func (conn *OCI8Conn) LoadSQLPlanBaseline(ctx context.Context, sqlID string, planHash string) error {
+	if sqlID == "" {
+		return errors.New("sql id is empty")
+	}
+	planHashValue, err := parsePlanHash(planHash)
+	if err != nil {
+		return err
+	}
+
+	var loaded int64
+	err = conn.execArgs(ctx, loadPlansQuery, sql.Out{Dest: &loaded}, sqlID, planHashValue)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	if loaded < 1 {
+		return fmt.Errorf("no plans loaded for sql id: %v", sqlID)
+	}
+
+	return nil
+}
+
+// EvolveBaseline evolves the SQL plan baseline plan name with DBMS_SPM.EVOLVE_SQL_PLAN_BASELINE,
+// which verifies and accepts better performing plans, then returns the evolve report.
+// The plans are executed to compare them, which can take a while. Needs the ADMINISTER SQL MANAGEMENT OBJECT privilege.
+func (conn *OCI8Conn) EvolveBaseline(ctx context.Context, baselineName string) (string, error) {
+	if baselineName == "" {
+		return "", errors.New("baseline name is empty")
+	}
+
+	var report string
+	err := conn.execArgs(ctx, evolveBaselineQuery, lobOut{dest: &report}, baselineName)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", err
+	}
+
+	return report, nil
+}
+
+// parsePlanHash returns the plan hash value bind of the plan hash, nil if empty
+func parsePlanHash(planHash string) (interface{}, error) {
+	if planHash == "" {
+		return nil, nil
+	}
+	planHashValue, err := strconv.ParseUint(planHash, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid plan hash: %v", planHash)
+	}
+	return int64(planHashValue), nil
+}
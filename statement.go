@@ -9,7 +9,9 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 	"unsafe"
@@ -22,6 +24,13 @@ func (stmt *OCI8Stmt) Close() error {
 	}
 	stmt.closed = true
 
+	if stmt.cursor {
+		// cursor statement handles are allocated with OCIHandleAlloc, not prepared
+		result := C.OCIHandleFree(unsafe.Pointer(stmt.stmt), C.OCI_HTYPE_STMT)
+		stmt.stmt = nil
+		return stmt.conn.getError(result)
+	}
+
 	result := C.OCIStmtRelease(
 		stmt.stmt,            // statement handle
 		stmt.conn.errHandle,  // error handle
@@ -30,6 +39,8 @@ func (stmt *OCI8Stmt) Close() error {
 		C.ub4(C.OCI_DEFAULT), // mode
 	)
 	stmt.stmt = nil
+	freeBinds(stmt.rebinds)
+	stmt.rebinds = nil
 
 	return stmt.conn.getError(result)
 }
@@ -41,6 +52,14 @@ func (stmt *OCI8Stmt) NumInput() int {
 	if err != nil {
 		return -1
 	}
+	if stmt.returnRowid {
+		// do not count the added :oci8_rowid bind
+		bindCount--
+	}
+	if stmt.largePLSQL != "" {
+		// do not count the added :lob_sql bind
+		bindCount--
+	}
 
 	return int(bindCount)
 }
@@ -48,21 +67,44 @@ func (stmt *OCI8Stmt) NumInput() int {
 // CheckNamedValue checks a named value
 func (stmt *OCI8Stmt) CheckNamedValue(namedValue *driver.NamedValue) error {
 	switch namedValue.Value.(type) {
-	case sql.Out:
+	case sql.Out, *OCICursorBind, BFileRef, LargePLSQL:
 		return nil
 	}
+	if stmt.conn.oci8Driver != nil && namedValue.Value != nil {
+		mapping, ok := stmt.conn.oci8Driver.typeMapping(reflect.TypeOf(namedValue.Value))
+		if ok {
+			value, err := mapping.toDriver(namedValue.Value)
+			if err != nil {
+				return err
+			}
+			namedValue.Value = value
+			return nil
+		}
+	}
 	return driver.ErrSkip
 }
 
+// normalizeValue returns a string value converted to the unicode_normalization DSN parameter form, other values are returned unchanged.
+// All the string bind paths use it, so a value is sent the same with Exec, ExecBatch, Rebind, and direct path loads.
+func (conn *OCI8Conn) normalizeValue(value driver.Value) driver.Value {
+	if text, ok := value.(string); ok && conn.normalizeUnicode {
+		return conn.unicodeNormalization.String(text)
+	}
+	return value
+}
+
 // bindValues binds the values to the stmt
 func (stmt *OCI8Stmt) bindValues(ctx context.Context, values []driver.Value, namedValues []driver.NamedValue) ([]oci8Bind, error) {
 	if len(values) == 0 && len(namedValues) == 0 {
 		return nil, nil
 	}
+	// the binds replace any Rebind binds
+	stmt.rebound = false
 
 	var err error
 	var binds []oci8Bind
 	var useValues bool
+	var bytesSent int64
 	count := len(namedValues)
 	if count == 0 {
 		useValues = true
@@ -90,8 +132,25 @@ func (stmt *OCI8Stmt) bindValues(ctx context.Context, values []driver.Value, nam
 
 		var isOut bool
 		var isNill bool
-		sbind.out, isOut = valueInterface.(sql.Out)
-		if isOut {
+		var isLOB bool
+		if lob, ok := valueInterface.(lobOut); ok {
+			sbind.out = sql.Out{Dest: lob.dest}
+			isOut = true
+			isLOB = true
+			switch lob.dest.(type) {
+			case *string:
+				valueInterface = ""
+			case *[]byte:
+				valueInterface = []byte(nil)
+			default:
+				binds = append(binds, sbind)
+				freeBinds(binds)
+				return nil, fmt.Errorf("invalid LOB out bind type: %T", lob.dest)
+			}
+		} else {
+			sbind.out, isOut = valueInterface.(sql.Out)
+		}
+		if isOut && !isLOB {
 			valueInterface, err = driver.DefaultParameterConverter.ConvertValue(sbind.out.Dest)
 			if err != nil {
 				binds = append(binds, sbind)
@@ -123,10 +182,43 @@ func (stmt *OCI8Stmt) bindValues(ctx context.Context, values []driver.Value, nam
 			sbind.maxSize = 0
 			*sbind.indicator = -1 // set to null
 
+		case LargePLSQL:
+			err = stmt.conn.bindTemporaryCLOB(&sbind, value.Text)
+			if err != nil {
+				binds = append(binds, sbind)
+				freeBinds(binds)
+				return nil, err
+			}
+
+		case BFileRef:
+			var fileP *unsafe.Pointer
+			fileP, err = stmt.conn.ociLobFileSetName(value)
+			if err != nil {
+				freeBinds(binds)
+				return nil, err
+			}
+			sbind.dataType = C.SQLT_FILE
+			sbind.pbuf = unsafe.Pointer(fileP)
+			sbind.maxSize = C.sb4(sizeOfNilPointer)
+			*sbind.length = C.ub2(sizeOfNilPointer)
+
+		case *OCICursorBind:
+			err = value.allocate(stmt.conn)
+			if err != nil {
+				freeBinds(binds)
+				return nil, err
+			}
+			// the bind value is a pointer to the statement handle, in C memory since OCI keeps it until execute
+			sbind.dataType = C.SQLT_RSET
+			sbind.pbuf = C.malloc(C.size_t(sizeOfNilPointer))
+			*(*unsafe.Pointer)(sbind.pbuf) = unsafe.Pointer(value.stmt)
+			sbind.maxSize = C.sb4(sizeOfNilPointer)
+			*sbind.length = C.ub2(sizeOfNilPointer)
+
 		case []byte:
 			if isOut {
 
-				if len(value) > 32767 {
+				if len(value) > maxStringBindSize || isLOB {
 					var lobP *unsafe.Pointer
 					lobP, _, err = stmt.conn.ociDescriptorAlloc(C.OCI_DTYPE_LOB, 0)
 					if err != nil {
@@ -143,10 +235,12 @@ func (stmt *OCI8Stmt) bindValues(ctx context.Context, values []driver.Value, nam
 						freeBinds(binds)
 						return nil, err
 					}
-					err = stmt.conn.ociLobWrite(*lobLocator, C.SQLCS_IMPLICIT, value)
-					if err != nil {
-						freeBinds(binds)
-						return nil, err
+					if len(value) > 0 {
+						err = stmt.conn.ociLobWrite(*lobLocator, C.SQLCS_IMPLICIT, value)
+						if err != nil {
+							freeBinds(binds)
+							return nil, err
+						}
 					}
 				} else {
 					sbind.dataType = C.SQLT_BIN
@@ -206,9 +300,10 @@ func (stmt *OCI8Stmt) bindValues(ctx context.Context, values []driver.Value, nam
 			sbind.pbuf = unsafe.Pointer(dateTimePP)
 
 		case string:
+			value = stmt.conn.normalizeValue(value).(string)
 			if isOut {
 
-				if len(value) > 32767 {
+				if len(value) > maxStringBindSize || isLOB {
 					var lobP *unsafe.Pointer
 					lobP, _, err = stmt.conn.ociDescriptorAlloc(C.OCI_DTYPE_LOB, 0)
 					if err != nil {
@@ -225,10 +320,12 @@ func (stmt *OCI8Stmt) bindValues(ctx context.Context, values []driver.Value, nam
 						freeBinds(binds)
 						return nil, err
 					}
-					err = stmt.conn.ociLobWrite(*lobLocator, C.SQLCS_IMPLICIT, []byte(value))
-					if err != nil {
-						freeBinds(binds)
-						return nil, err
+					if len(value) > 0 {
+						err = stmt.conn.ociLobWrite(*lobLocator, C.SQLCS_IMPLICIT, []byte(value))
+						if err != nil {
+							freeBinds(binds)
+							return nil, err
+						}
 					}
 				} else {
 					sbind.dataType = C.SQLT_CHR
@@ -243,7 +340,9 @@ func (stmt *OCI8Stmt) bindValues(ctx context.Context, values []driver.Value, nam
 
 			} else {
 
-				if len(value) > 32767 {
+				// strings larger than the PL/SQL VARCHAR2 max size are bound as CLOB,
+				// for example as an EXECUTE IMMEDIATE bind, which would otherwise get ORA-01461
+				if len(value) > maxStringBindSize {
 					var lobP *unsafe.Pointer
 					lobP, _, err = stmt.conn.ociDescriptorAlloc(C.OCI_DTYPE_LOB, 0)
 					if err != nil {
@@ -348,8 +447,13 @@ func (stmt *OCI8Stmt) bindValues(ctx context.Context, values []driver.Value, nam
 			return nil, err
 		}
 
+		if !isOut && *sbind.indicator != -1 {
+			bytesSent += int64(sbind.maxSize)
+		}
 	}
 
+	stmt.conn.addStats(ConnStats{BytesSent: bytesSent})
+
 	return binds, nil
 }
 
@@ -373,8 +477,68 @@ func (stmt *OCI8Stmt) QueryContext(ctx context.Context, namedValues []driver.Nam
 	return stmt.query(ctx, binds)
 }
 
+// WithPrefetchRows returns a context that sets the number of top level rows prefetched by queries run with it,
+// overriding the prefetch_rows DSN parameter for those statements only. A 0 means only prefetch memory limits the rows.
+func WithPrefetchRows(parent context.Context, prefetchRows uint32) context.Context {
+	return context.WithValue(parent, prefetchRowsKey{}, prefetchRows)
+}
+
+// contextPrefetchRows returns the prefetch rows set by WithPrefetchRows and true, or the connection prefetch rows and false if not set
+func contextPrefetchRows(ctx context.Context, connPrefetchRows C.ub4) (C.ub4, bool) {
+	if prefetchRows, ok := ctx.Value(prefetchRowsKey{}).(uint32); ok {
+		return C.ub4(prefetchRows), true
+	}
+	return connPrefetchRows, false
+}
+
+// WithHint returns a context that adds the optimizer hint, like PARALLEL(t, 8), to statements prepared with it.
+// The hint is added in a /*+ */ comment after the first keyword of SELECT, INSERT, UPDATE, DELETE, and MERGE statements.
+// If the statement already has a hint comment there, the hint is added to the end of it. Other statements are not changed.
+func WithHint(parent context.Context, hint string) context.Context {
+	return context.WithValue(parent, hintKey{}, hint)
+}
+
+// hintQuery returns the query with the hint added after the first keyword, see WithHint
+func hintQuery(query string, hint string) (string, error) {
+	hint = strings.TrimSpace(hint)
+	if hint == "" {
+		return query, nil
+	}
+	if strings.Contains(hint, "*/") {
+		return "", fmt.Errorf("invalid hint: %v", hint)
+	}
+
+	start := len(query) - len(strings.TrimLeft(query, " \t\r\n"))
+	end := start
+	for end < len(query) && (query[end] >= 'a' && query[end] <= 'z' || query[end] >= 'A' && query[end] <= 'Z') {
+		end++
+	}
+	switch strings.ToUpper(query[start:end]) {
+	case "SELECT", "INSERT", "UPDATE", "DELETE", "MERGE":
+	default:
+		return query, nil
+	}
+
+	rest := strings.TrimLeft(query[end:], " \t\r\n")
+	if strings.HasPrefix(rest, "/*+") {
+		hintEnd := strings.Index(rest, "*/")
+		if hintEnd > 0 {
+			return query[:end] + " /*+ " + strings.TrimSpace(rest[3:hintEnd]) + " " + hint + " */" + rest[hintEnd+2:], nil
+		}
+	}
+
+	return query[:end] + " /*+ " + hint + " */" + query[end:], nil
+}
+
 // query runs a query with context
 func (stmt *OCI8Stmt) query(ctx context.Context, binds []oci8Bind) (driver.Rows, error) {
+	if stmt.largePLSQL != "" {
+		var err error
+		binds, err = stmt.bindLargePLSQL(binds)
+		if err != nil {
+			return nil, err
+		}
+	}
 	defer freeBinds(binds)
 
 	var stmtType C.ub2
@@ -388,8 +552,8 @@ func (stmt *OCI8Stmt) query(ctx context.Context, binds []oci8Bind) (driver.Rows,
 		iter = 0
 	}
 
-	if stmt.conn.prefetchRows != 1 {
-		prefetchRows := stmt.conn.prefetchRows
+	prefetchRows, ok := contextPrefetchRows(ctx, stmt.conn.prefetchRows)
+	if prefetchRows != 1 || ok {
 		// OCI_ATTR_PREFETCH_ROWS sets the number of top level rows to be prefetched. The default value is 1 row. Value of 0 seems to mean only prefetch memory size limits the number of rows to prefetch.
 		err = stmt.conn.ociAttrSet(unsafe.Pointer(stmt.stmt), C.OCI_HTYPE_STMT, unsafe.Pointer(&prefetchRows), 0, C.OCI_ATTR_PREFETCH_ROWS)
 		if err != nil {
@@ -424,14 +588,20 @@ func (stmt *OCI8Stmt) query(ctx context.Context, binds []oci8Bind) (driver.Rows,
 		return nil, err
 	}
 
+	return stmt.defineRows(ctx)
+}
+
+// defineRows defines the select list columns of an executed statement then returns the rows
+func (stmt *OCI8Stmt) defineRows(ctx context.Context) (*OCI8Rows, error) {
 	var paramCountUb4 C.ub4 // number of columns in the select-list
-	_, err = stmt.ociAttrGet(unsafe.Pointer(&paramCountUb4), C.OCI_ATTR_PARAM_COUNT)
+	_, err := stmt.ociAttrGet(unsafe.Pointer(&paramCountUb4), C.OCI_ATTR_PARAM_COUNT)
 	if err != nil {
 		return nil, err
 	}
 	paramCount := int(paramCountUb4)
 
 	defines := make([]oci8Define, paramCount)
+	dynamicFetch := false
 
 	for i := 0; i < paramCount; i++ {
 		if ctx.Err() != nil {
@@ -483,6 +653,11 @@ func (stmt *OCI8Stmt) query(ctx context.Context, binds []oci8Bind) (driver.Rows,
 			// For a database with character set to ZHS16GBK the OCI C driver does not seem to report the correct max size, not sure exactly why.
 			// Doubling the max size of the buffer seems to fix the issue, not sure if there is a better fix.
 			defines[i].maxSize = C.sb4(maxSize * 2)
+			if stmt.conn.smartAlloc && defines[i].maxSize > smartAllocSize {
+				// fetched piecewise, the buffer is grown to the full size by fetchPieces when a value does not fit
+				defines[i].fullSize = defines[i].maxSize
+				defines[i].maxSize = smartAllocSize
+			}
 			defines[i].pbuf = C.malloc(C.size_t(defines[i].maxSize))
 
 		case C.SQLT_BIN:
@@ -511,6 +686,7 @@ func (stmt *OCI8Stmt) query(ctx context.Context, binds []oci8Bind) (driver.Rows,
 			// https://docs.oracle.com/cd/E11882_01/appdev.112/e10646/oci06des.htm#LNOCI16458
 
 			// note that select sum and count both return as precision == 0 && scale == 0 so use float64 (SQLT_BDOUBLE) to handle both
+			// unconstrained NUMBER columns are precision == 0 && scale == -127, also float64, which database/sql can scan into a string
 
 			if (precision == 0 && scale == 0) || scale > 0 || scale == -127 {
 				defines[i].dataType = C.SQLT_BDOUBLE
@@ -537,7 +713,23 @@ func (stmt *OCI8Stmt) query(ctx context.Context, binds []oci8Bind) (driver.Rows,
 			defines[i].maxSize = 4000
 			defines[i].pbuf = C.malloc(C.size_t(defines[i].maxSize))
 
-		case C.SQLT_CLOB, C.SQLT_BLOB:
+		case C.SQLT_CLOB, C.SQLT_BLOB, C.SQLT_JSON:
+			if dataType == C.SQLT_JSON {
+				// JSON type columns are fetched as JSON text like a CLOB, then returned as []byte
+				defines[i].json = true
+				dataType = C.SQLT_CLOB
+			}
+			if stmt.conn.lobInlineThreshold > 0 {
+				// fetch the LOB data inline instead of a LOB locator
+				if dataType == C.SQLT_CLOB {
+					defines[i].dataType = C.SQLT_CHR
+				} else {
+					defines[i].dataType = C.SQLT_LBI
+				}
+				defines[i].maxSize = stmt.conn.lobInlineThreshold
+				defines[i].pbuf = C.malloc(C.size_t(defines[i].maxSize))
+				break
+			}
 			defines[i].dataType = dataType
 			defines[i].maxSize = C.sb4(sizeOfNilPointer)
 			var lobP *unsafe.Pointer
@@ -548,9 +740,29 @@ func (stmt *OCI8Stmt) query(ctx context.Context, binds []oci8Bind) (driver.Rows,
 			}
 			defines[i].pbuf = unsafe.Pointer(lobP)
 
+		case C.SQLT_FILE:
+			defines[i].dataType = C.SQLT_FILE
+			defines[i].maxSize = C.sb4(sizeOfNilPointer)
+			var fileP *unsafe.Pointer
+			fileP, _, err = stmt.conn.ociDescriptorAlloc(C.OCI_DTYPE_FILE, 0)
+			if err != nil {
+				freeDefines(defines)
+				return nil, err
+			}
+			defines[i].pbuf = unsafe.Pointer(fileP)
+
 		case C.SQLT_TIMESTAMP, C.SQLT_DAT:
 			defines[i].dataType = C.SQLT_TIMESTAMP
 			defines[i].maxSize = C.sb4(sizeOfNilPointer)
+			defines[i].fsPrecision = 9
+			if dataType == C.SQLT_TIMESTAMP {
+				// the fractional seconds precision, the n in TIMESTAMP(n)
+				_, err = stmt.conn.ociAttrGet(param, unsafe.Pointer(&defines[i].fsPrecision), C.OCI_ATTR_FSPRECISION)
+				if err != nil {
+					freeDefines(defines)
+					return nil, err
+				}
+			}
 			var timestampP *unsafe.Pointer
 			timestampP, _, err = stmt.conn.ociDescriptorAlloc(C.OCI_DTYPE_TIMESTAMP, 0)
 			if err != nil {
@@ -562,6 +774,12 @@ func (stmt *OCI8Stmt) query(ctx context.Context, binds []oci8Bind) (driver.Rows,
 		case C.SQLT_TIMESTAMP_TZ, C.SQLT_TIMESTAMP_LTZ:
 			defines[i].dataType = C.SQLT_TIMESTAMP_TZ
 			defines[i].maxSize = C.sb4(sizeOfNilPointer)
+			// the fractional seconds precision, the n in TIMESTAMP(n) WITH TIME ZONE
+			_, err = stmt.conn.ociAttrGet(param, unsafe.Pointer(&defines[i].fsPrecision), C.OCI_ATTR_FSPRECISION)
+			if err != nil {
+				freeDefines(defines)
+				return nil, err
+			}
 			var timestampP *unsafe.Pointer
 			timestampP, _, err = stmt.conn.ociDescriptorAlloc(C.OCI_DTYPE_TIMESTAMP_TZ, 0)
 			if err != nil {
@@ -597,40 +815,90 @@ func (stmt *OCI8Stmt) query(ctx context.Context, binds []oci8Bind) (driver.Rows,
 			defines[i].maxSize = 40
 			defines[i].pbuf = C.malloc(C.size_t(defines[i].maxSize))
 
+		case C.SQLT_NTY: // object and collection types
+			defines[i].dataType = C.SQLT_NTY
+			defines[i].objectType, err = stmt.conn.describeObjectColumn(param)
+			if err != nil {
+				freeDefines(defines)
+				return nil, err
+			}
+			defines[i].maxSize = 0
+			// object instance pointer and null indicator structure pointer, set by OCIDefineObject
+			defines[i].pbuf = C.malloc(C.size_t(2 * sizeOfNilPointer))
+
 		default:
 			defines[i].dataType = C.SQLT_AFC
 			defines[i].maxSize = C.sb4(maxSize)
 			defines[i].pbuf = C.malloc(C.size_t(defines[i].maxSize))
 		}
 
+		valueP := defines[i].pbuf
+		valueSize := defines[i].maxSize
+		indicatorP := unsafe.Pointer(defines[i].indicator)
+		lengthP := defines[i].length
+		defineMode := C.ub4(C.OCI_DEFAULT)
+		if defines[i].dataType == C.SQLT_NTY {
+			// objects are fetched into the object cache and have null indicator structures, both set by OCIDefineObject
+			valueP = nil
+			indicatorP = nil
+		}
+		if defines[i].fullSize > 0 {
+			// smart alloc columns are fetched piecewise, the buffer, indicator, and length of each piece are set by fetchPieces
+			valueP = nil
+			valueSize = defines[i].fullSize
+			indicatorP = nil
+			lengthP = nil
+			defineMode = C.OCI_DYNAMIC_FETCH
+			dynamicFetch = true
+		}
+
 		result := C.OCIDefineByPos(
-			stmt.stmt,                            // statement handle
-			&defines[i].defineHandle,             // pointer to a pointer to a define handle. If NULL, this call implicitly allocates the define handle.
-			stmt.conn.errHandle,                  // error handle
-			C.ub4(i+1),                           // position of this value in the select list. Positions are 1-based and are numbered from left to right.
-			defines[i].pbuf,                      // pointer to a buffer
-			defines[i].maxSize,                   // size of each valuep buffer in bytes
-			defines[i].dataType,                  // datatype
-			unsafe.Pointer(defines[i].indicator), // pointer to an indicator variable or array
-			defines[i].length,                    // pointer to array of length of data fetched
-			nil,                                  // pointer to array of column-level return codes
-			C.OCI_DEFAULT,                        // mode - OCI_DEFAULT - This is the default mode.
+			stmt.stmt,                // statement handle
+			&defines[i].defineHandle, // pointer to a pointer to a define handle. If NULL, this call implicitly allocates the define handle.
+			stmt.conn.errHandle,      // error handle
+			C.ub4(i+1),               // position of this value in the select list. Positions are 1-based and are numbered from left to right.
+			valueP,                   // pointer to a buffer
+			valueSize,                // size of each valuep buffer in bytes
+			defines[i].dataType,      // datatype
+			indicatorP,               // pointer to an indicator variable or array
+			lengthP,                  // pointer to array of length of data fetched
+			nil,                      // pointer to array of column-level return codes
+			defineMode,               // mode - OCI_DEFAULT, or OCI_DYNAMIC_FETCH for piecewise fetch
 		)
 		if result != C.OCI_SUCCESS {
 			freeDefines(defines)
 			return nil, stmt.conn.getError(result)
 		}
+
+		if defines[i].dataType == C.SQLT_NTY {
+			err = stmt.conn.ociDefineObject(&defines[i])
+			if err != nil {
+				freeDefines(defines)
+				return nil, err
+			}
+		}
 	}
 
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
 
+	columnNames := make([]string, len(defines))
+	columnNameMap := make(map[string]int, len(defines))
+	for i := len(defines) - 1; i >= 0; i-- {
+		columnNames[i] = defines[i].name
+		// loop backwards so duplicate column names map to the first column
+		columnNameMap[strings.ToUpper(defines[i].name)] = i
+	}
+
 	rows := &OCI8Rows{
-		stmt:    stmt,
-		defines: defines,
-		ctx:     ctx,
-		done:    make(chan struct{}),
+		stmt:          stmt,
+		defines:       defines,
+		columnNames:   columnNames,
+		columnNameMap: columnNameMap,
+		ctx:           ctx,
+		done:          make(chan struct{}),
+		dynamicFetch:  dynamicFetch,
 	}
 
 	go stmt.conn.ociBreakDone(ctx, rows.done)
@@ -694,6 +962,22 @@ func (stmt *OCI8Stmt) ExecContext(ctx context.Context, namedValues []driver.Name
 }
 
 func (stmt *OCI8Stmt) exec(ctx context.Context, binds []oci8Bind) (driver.Result, error) {
+	var rowidBind *oci8Bind
+	if stmt.returnRowid {
+		var err error
+		binds, err = stmt.bindReturningRowid(binds)
+		if err != nil {
+			return nil, err
+		}
+		rowidBind = &binds[len(binds)-1]
+	}
+	if stmt.largePLSQL != "" {
+		var err error
+		binds, err = stmt.bindLargePLSQL(binds)
+		if err != nil {
+			return nil, err
+		}
+	}
 	defer freeBinds(binds)
 
 	mode := C.ub4(C.OCI_DEFAULT)
@@ -705,16 +989,36 @@ func (stmt *OCI8Stmt) exec(ctx context.Context, binds []oci8Bind) (driver.Result
 		return nil, ctx.Err()
 	}
 
-	done := make(chan struct{})
-	go stmt.conn.ociBreakDone(ctx, done)
-	err := stmt.ociStmtExecute(1, mode)
-	close(done)
+	err := retryOnErrors(ctx, stmt.conn.retryOnErrors, stmt.conn.retryCount, stmt.conn.retryDelay, func() (int, error) {
+		done := make(chan struct{})
+		go stmt.conn.ociBreakDone(ctx, done)
+		err := stmt.ociStmtExecute(1, mode)
+		close(done)
+		if err == nil || err == ErrOCISuccessWithInfo || len(stmt.conn.retryOnErrors) == 0 || ctx.Err() != nil {
+			return 0, err
+		}
+		// the error handle still has the error of the execute
+		errorCode, _ := stmt.conn.ociGetError()
+		return errorCode, err
+	})
 	if err != nil && err != ErrOCISuccessWithInfo {
 		return nil, err
 	}
 
 	result := OCI8Result{stmt: stmt}
 
+	if stmt.conn.collectCallTime {
+		result.callTime, result.callTimeErr = stmt.conn.ociCallTime()
+	} else {
+		result.callTimeErr = ErrNoCallTime
+	}
+
+	result.returnRowidErr = ErrNoRowid
+	if rowidBind != nil && *rowidBind.indicator == 0 {
+		result.returnRowid = C.GoStringN((*C.char)(rowidBind.pbuf), C.int(*rowidBind.length))
+		result.returnRowidErr = nil
+	}
+
 	result.rowsAffected, result.rowsAffectedErr = stmt.rowsAffected()
 	if result.rowsAffectedErr != nil || result.rowsAffected < 1 {
 		result.rowidErr = ErrNoRowid
@@ -994,5 +1298,172 @@ func (stmt *OCI8Stmt) ociStmtExecute(iters C.ub4, mode C.ub4) error {
 		mode,                // The mode: https://docs.oracle.com/cd/E11882_01/appdev.112/e10646/oci17msc001.htm#LNOCI17163
 	)
 
+	counts := ConnStats{ExecCount: 1}
+	if result != C.OCI_SUCCESS && result != C.OCI_SUCCESS_WITH_INFO {
+		counts.ErrorCount = 1
+	}
+	stmt.conn.addStats(counts)
+
 	return stmt.conn.getError(result)
 }
+
+// retryOnErrors calls execute, and while it fails with an error code in retryErrors, waits retryDelay then calls it again,
+// up to retryCount times. execute returns the ORA error code and the error. Returns the last error of execute,
+// or the context error if the context is done while waiting.
+func retryOnErrors(ctx context.Context, retryErrors []int, retryCount int, retryDelay time.Duration, execute func() (int, error)) error {
+	for retry := 0; ; retry++ {
+		errorCode, err := execute()
+		if err == nil || retry >= retryCount || !containsErrorCode(retryErrors, errorCode) {
+			return err
+		}
+
+		if retryDelay > 0 {
+			timer := time.NewTimer(retryDelay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// containsErrorCode returns true if the error codes have the error code
+func containsErrorCode(errorCodes []int, errorCode int) bool {
+	for _, code := range errorCodes {
+		if code == errorCode {
+			return true
+		}
+	}
+	return false
+}
+
+// returningRowidQuery adds RETURNING ROWID INTO :oci8_rowid to an INSERT ... VALUES query without a RETURNING clause.
+// Keywords in quoted strings, quoted identifiers, and comments are ignored, and trailing comments are removed
+// so they do not comment out the added clause. Returns the query and true if it was changed.
+func returningRowidQuery(query string) (string, bool) {
+	words, end := sqlWords(query)
+	if len(words) < 2 || words[0] != "INSERT" || words[1] == "ALL" || words[1] == "FIRST" {
+		return query, false
+	}
+	values := false
+	for _, word := range words[2:] {
+		switch word {
+		case "VALUES":
+			values = true
+		case "RETURNING", "RETURN":
+			return query, false
+		}
+	}
+	if !values {
+		return query, false
+	}
+	return strings.TrimLeft(query[:end], " \t\r\n") + " returning rowid into :" + returningRowidBind, true
+}
+
+// isLargePLSQL returns true if the query is a PL/SQL block, starting with BEGIN or DECLARE, longer than 32767 bytes
+func isLargePLSQL(query string) bool {
+	if len(query) <= maxPLSQLSize {
+		return false
+	}
+	trimmed := strings.TrimLeft(query, " \t\r\n")
+	for _, keyword := range []string{"BEGIN", "DECLARE"} {
+		if len(trimmed) > len(keyword) && strings.EqualFold(trimmed[:len(keyword)], keyword) && strings.ContainsRune(" \t\r\n", rune(trimmed[len(keyword)])) {
+			return true
+		}
+	}
+	return false
+}
+
+// bindLargePLSQL binds the PL/SQL block of a statement prepared as EXECUTE IMMEDIATE :lob_sql as a CLOB,
+// then returns the binds with it added. The binds are freed on error.
+func (stmt *OCI8Stmt) bindLargePLSQL(binds []oci8Bind) ([]oci8Bind, error) {
+	if len(binds) > 0 {
+		freeBinds(binds)
+		return nil, errors.New("PL/SQL blocks longer than 32767 bytes can not have binds")
+	}
+
+	var sbind oci8Bind
+	sbind.length = (*C.ub2)(C.malloc(C.sizeof_ub2))
+	sbind.indicator = (*C.sb2)(C.malloc(C.sizeof_sb2))
+	*sbind.indicator = 0
+	err := stmt.conn.bindTemporaryCLOB(&sbind, stmt.largePLSQL)
+	binds = append(binds, sbind)
+	if err != nil {
+		freeBinds(binds)
+		return nil, err
+	}
+
+	err = stmt.ociBindByName([]byte(":"+largePLSQLBind), &binds[len(binds)-1])
+	if err != nil {
+		freeBinds(binds)
+		return nil, err
+	}
+	return binds, nil
+}
+
+// bindTemporaryCLOB sets the bind to a temporary CLOB with the text.
+// The bind length and indicator must be allocated, the CLOB is freed with the binds.
+func (conn *OCI8Conn) bindTemporaryCLOB(sbind *oci8Bind, text string) error {
+	lobP, _, err := conn.ociDescriptorAlloc(C.OCI_DTYPE_LOB, 0)
+	if err != nil {
+		return err
+	}
+	sbind.dataType = C.SQLT_CLOB
+	sbind.pbuf = unsafe.Pointer(lobP)
+	sbind.maxSize = C.sb4(sizeOfNilPointer)
+	*sbind.length = C.ub2(sizeOfNilPointer)
+	lobLocator := (**C.OCILobLocator)(sbind.pbuf)
+	err = conn.ociLobCreateTemporary(*lobLocator, C.SQLCS_IMPLICIT, C.OCI_TEMP_CLOB)
+	if err != nil {
+		return err
+	}
+	return conn.ociLobWrite(*lobLocator, C.SQLCS_IMPLICIT, []byte(text))
+}
+
+// prepareWithoutReturningRowid prepares the statement again without the RETURNING ROWID INTO :oci8_rowid clause
+// added by returningRowidQuery, for ExecBatch and Rebind, which do not bind :oci8_rowid.
+// After that, Exec of the statement does not return the inserted ROWID.
+func (stmt *OCI8Stmt) prepareWithoutReturningRowid(ctx context.Context) error {
+	if !stmt.returnRowid {
+		return nil
+	}
+
+	var queryP *C.OraText // statement text
+	size, err := stmt.ociAttrGet(unsafe.Pointer(&queryP), C.OCI_ATTR_STATEMENT)
+	if err != nil {
+		return err
+	}
+	query := strings.TrimSuffix(cGoStringN(queryP, int(size)), " returning rowid into :"+returningRowidBind)
+
+	newStmt, err := stmt.conn.prepare(ctx, query, false)
+	if err != nil {
+		return err
+	}
+	C.OCIStmtRelease(stmt.stmt, stmt.conn.errHandle, nil, 0, C.OCI_DEFAULT)
+	stmt.stmt = newStmt.stmt
+	stmt.returnRowid = false
+	stmt.rebound = false
+	return nil
+}
+
+// bindReturningRowid binds an out buffer for the :oci8_rowid bind added by returningRowidQuery, then returns the binds with it added
+func (stmt *OCI8Stmt) bindReturningRowid(binds []oci8Bind) ([]oci8Bind, error) {
+	var sbind oci8Bind
+	sbind.length = (*C.ub2)(C.malloc(C.sizeof_ub2))
+	*sbind.length = 0
+	sbind.indicator = (*C.sb2)(C.malloc(C.sizeof_sb2))
+	*sbind.indicator = -1
+	sbind.dataType = C.SQLT_CHR
+	sbind.pbuf = unsafe.Pointer(cStringN("", maxRowidSize))
+	sbind.maxSize = maxRowidSize
+	binds = append(binds, sbind)
+
+	err := stmt.ociBindByName([]byte(":"+returningRowidBind), &binds[len(binds)-1])
+	if err != nil {
+		freeBinds(binds)
+		return nil, err
+	}
+	return binds, nil
+}
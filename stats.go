@@ -0,0 +1,22 @@
+package oci8
+
+// Stats returns the statistics accumulated by the connection since it was opened.
+// It is safe to call from another goroutine while the connection is in use.
+func (conn *OCI8Conn) Stats() ConnStats {
+	conn.statsMutex.Lock()
+	stats := conn.stats
+	conn.statsMutex.Unlock()
+	return stats
+}
+
+// addStats adds the counts to the connection statistics
+func (conn *OCI8Conn) addStats(counts ConnStats) {
+	conn.statsMutex.Lock()
+	conn.stats.PrepareCount += counts.PrepareCount
+	conn.stats.ExecCount += counts.ExecCount
+	conn.stats.FetchCount += counts.FetchCount
+	conn.stats.ErrorCount += counts.ErrorCount
+	conn.stats.BytesSent += counts.BytesSent
+	conn.stats.BytesReceived += counts.BytesReceived
+	conn.statsMutex.Unlock()
+}
@@ -0,0 +1,107 @@
+package oci8
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StreamInsert inserts the rows read from the channel into the table columns, with an ExecBatch array insert
+// for every batch_size rows, which is a DSN parameter that defaults to 1000. It returns when the channel is closed
+// and the last batch is inserted. The number of inserted rows is returned, also with an error.
+// A row that is a single error value stops the insert, so the sender can end the stream with an error.
+// If the context is done, the rows of the current batch are not inserted and the context error is returned.
+// If rows of a batch fail, the other rows of the batch are still inserted and an error of the first failed row is returned.
+// Values in a column must have the same type in each batch, like ExecBatch.
+// The table must be an unquoted identifier with an optional schema and the columns must be unquoted identifiers.
+// When not in a transaction, each batch is committed.
+func (conn *OCI8Conn) StreamInsert(ctx context.Context, table string, columns []string, rows <-chan []interface{}) (int64, error) {
+	query, err := streamInsertQuery(table, columns)
+	if err != nil {
+		return 0, err
+	}
+
+	batchSize := conn.batchSize
+	if batchSize < 1 {
+		batchSize = defaultBatchSize
+	}
+
+	stmt, err := conn.prepare(ctx, query, false)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	var count int64
+	batch := make([][]interface{}, 0, batchSize)
+
+	insertBatch := func() error {
+		result, err := stmt.ExecBatch(ctx, batch)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if len(stmt.batchErrors) > 0 {
+			batchError := stmt.batchErrors[0]
+			err = fmt.Errorf("insert row %v error: %v", count+int64(batchError.RowIndex), batchError.ErrorMessage)
+		}
+		count += rowsAffected
+		batch = batch[:0]
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return count, ctx.Err()
+		case row, ok := <-rows:
+			if !ok {
+				if len(batch) > 0 {
+					err = insertBatch()
+				}
+				return count, err
+			}
+			if len(row) == 1 {
+				if rowErr, isErr := row[0].(error); isErr {
+					return count, rowErr
+				}
+			}
+			if len(row) != len(columns) {
+				return count, fmt.Errorf("row has %v values, expected %v", len(row), len(columns))
+			}
+			batch = append(batch, row)
+			if len(batch) < batchSize {
+				continue
+			}
+			err = insertBatch()
+			if err != nil {
+				return count, err
+			}
+		}
+	}
+}
+
+// streamInsertQuery returns the INSERT statement of StreamInsert, with a positional bind for each column
+func streamInsertQuery(table string, columns []string) (string, error) {
+	if !isTableName(table) {
+		return "", fmt.Errorf("invalid table name: %v", table)
+	}
+	if len(columns) < 1 {
+		return "", errors.New("no columns")
+	}
+
+	binds := make([]string, len(columns))
+	for i, column := range columns {
+		if !isIdentifier(column) {
+			return "", fmt.Errorf("invalid column name: %v", column)
+		}
+		binds[i] = ":" + strconv.Itoa(i+1)
+	}
+
+	return "insert into " + table + " (" + strings.Join(columns, ", ") + ") values (" + strings.Join(binds, ", ") + ")", nil
+}
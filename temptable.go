@@ -0,0 +1,105 @@
+package oci8
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// columnTypeRegexp matches a column data type: a type name, optionally with a size or precision and scale,
+// and a CHAR or BYTE length semantics, like NUMBER, NUMBER(10,2), or VARCHAR2(100 CHAR)
+var columnTypeRegexp = regexp.MustCompile(`(?i)^[a-z][a-z0-9_$#]*(\(\s*\d+\s*(,\s*-?\d+\s*)?((char|byte)\s*)?\))?$`)
+
+// EnsureGlobalTempTable creates the global temporary table if it is not in USER_OBJECTS.
+// The scope sets if rows are deleted on commit, ScopeTransaction, or preserved until the session ends, ScopeSession.
+// Tables that exist or were created are cached by the connection, so later calls for the same name do not query the database.
+// The table and column names must be unquoted identifiers. The column types must be a type name, optionally with
+// a size or precision and scale, and CHAR or BYTE, like VARCHAR2(100 CHAR). CREATE is DDL, so it commits the current transaction.
+func (conn *OCI8Conn) EnsureGlobalTempTable(name string, columns []ColumnDef, scope TempTableScope) error {
+	tableName := strings.ToUpper(name)
+	if conn.tempTables[tableName] {
+		return nil
+	}
+
+	query, err := globalTempTableQuery(tableName, columns, scope)
+	if err != nil {
+		return err
+	}
+
+	values, err := conn.queryRowArgs(context.Background(), "select count(*) from USER_OBJECTS where object_name = :1 and object_type = 'TABLE'", tableName)
+	if err != nil {
+		return fmt.Errorf("check table exists error: %v", err)
+	}
+	count, _ := values[0].(float64)
+
+	if count == 0 {
+		_, err = conn.execScriptStatement(context.Background(), query)
+		if err != nil {
+			return fmt.Errorf("create global temporary table error: %v", err)
+		}
+	}
+
+	if conn.tempTables == nil {
+		conn.tempTables = make(map[string]bool)
+	}
+	conn.tempTables[tableName] = true
+
+	return nil
+}
+
+// globalTempTableQuery returns the CREATE GLOBAL TEMPORARY TABLE statement
+func globalTempTableQuery(tableName string, columns []ColumnDef, scope TempTableScope) (string, error) {
+	if !isIdentifier(tableName) {
+		return "", fmt.Errorf("invalid table name: %v", tableName)
+	}
+	if len(columns) == 0 {
+		return "", fmt.Errorf("table %v has no columns", tableName)
+	}
+
+	var onCommit string
+	switch scope {
+	case ScopeTransaction:
+		onCommit = "delete"
+	case ScopeSession:
+		onCommit = "preserve"
+	default:
+		return "", fmt.Errorf("invalid temp table scope: %v", scope)
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("create global temporary table " + tableName + " ( ")
+	for i, column := range columns {
+		if !isIdentifier(column.Name) {
+			return "", fmt.Errorf("invalid column name: %v", column.Name)
+		}
+		if column.Type == "" {
+			return "", fmt.Errorf("column %v has no type", column.Name)
+		}
+		if !columnTypeRegexp.MatchString(column.Type) {
+			return "", fmt.Errorf("invalid column %v type: %v", column.Name, column.Type)
+		}
+		if i > 0 {
+			buffer.WriteString(", ")
+		}
+		buffer.WriteString(column.Name + " " + column.Type)
+	}
+	buffer.WriteString(" ) on commit " + onCommit + " rows")
+
+	return buffer.String(), nil
+}
+
+// isIdentifier returns true if the name is an unquoted Oracle identifier: a letter then letters, digits, _, $, or #
+func isIdentifier(name string) bool {
+	if name == "" || !('a' <= name[0] && name[0] <= 'z' || 'A' <= name[0] && name[0] <= 'Z') {
+		return false
+	}
+	for i := 1; i < len(name); i++ {
+		c := name[i]
+		if !(c == '_' || c == '$' || c == '#' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
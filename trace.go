@@ -0,0 +1,24 @@
+package oci8
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// TraceFile returns the path of the server trace file of the session, the Default Trace File of V$DIAG_INFO,
+// which is where SQL trace, like ALTER SESSION SET EVENTS '10046 trace name context forever, level 12', is written.
+// Needs select on V$DIAG_INFO, which is granted to SELECT_CATALOG_ROLE, and an Oracle 11g or higher database.
+func (conn *OCI8Conn) TraceFile() (string, error) {
+	values, err := conn.queryRowArgs(context.Background(), "select VALUE from V$DIAG_INFO where NAME = 'Default Trace File'")
+	if err != nil {
+		if err == io.EOF {
+			return "", errors.New("trace file error: no Default Trace File in V$DIAG_INFO")
+		}
+		return "", fmt.Errorf("trace file error: %v", err)
+	}
+
+	traceFile, _ := values[0].(string)
+	return traceFile, nil
+}
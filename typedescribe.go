@@ -0,0 +1,141 @@
+package oci8
+
+// #include "oci8.go.h"
+import "C"
+
+import (
+	"context"
+	"unsafe"
+)
+
+// DescribeType describes a user-defined object or collection type with OCIDescribeAny.
+// For object types the attributes are returned, for collection types, like a table of type, the element is returned.
+// If the context is done while describing, OCIBreak is called.
+func (conn *OCI8Conn) DescribeType(ctx context.Context, typeName string) (*TypeDescriptor, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	done := make(chan struct{})
+	go conn.ociBreakDone(ctx, done)
+	defer close(done)
+
+	describe, param, err := conn.ociDescribeAny(typeName, C.OCI_PTYPE_TYPE)
+	if err != nil {
+		return nil, err
+	}
+	defer C.OCIHandleFree(unsafe.Pointer(describe), C.OCI_HTYPE_DESCRIBE)
+
+	var typeCode C.OCITypeCode
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&typeCode), C.OCI_ATTR_TYPECODE)
+	if err != nil {
+		return nil, err
+	}
+	typeDescriptor := &TypeDescriptor{Name: typeName, TypeCode: int(typeCode)}
+
+	if typeCode == C.OCI_TYPECODE_NAMEDCOLLECTION {
+		var elem *C.OCIParam // parameter of the collection element
+		_, err = conn.ociAttrGet(param, unsafe.Pointer(&elem), C.OCI_ATTR_COLLECTION_ELEMENT)
+		if err != nil {
+			return nil, err
+		}
+
+		var element AttributeInfo
+		element, err = conn.describeAttribute(elem)
+		if err != nil {
+			return nil, err
+		}
+		typeDescriptor.Element = &element
+		return typeDescriptor, nil
+	}
+
+	var attrCount C.ub2 // number of type attributes
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&attrCount), C.OCI_ATTR_NUM_TYPE_ATTRS)
+	if err != nil {
+		return nil, err
+	}
+	if attrCount < 1 {
+		return typeDescriptor, nil
+	}
+
+	var attrList *C.OCIParam // parameter list of the type attributes
+	_, err = conn.ociAttrGet(param, unsafe.Pointer(&attrList), C.OCI_ATTR_LIST_TYPE_ATTRS)
+	if err != nil {
+		return nil, err
+	}
+
+	typeDescriptor.Attributes = make([]AttributeInfo, attrCount)
+	for i := range typeDescriptor.Attributes {
+		var attr *C.OCIParam
+		attr, err = conn.ociParamGet(attrList, C.ub4(i+1))
+		if err != nil {
+			return nil, err
+		}
+
+		typeDescriptor.Attributes[i], err = conn.describeAttribute(attr)
+		if err != nil {
+			return nil, err
+		}
+
+		var name *C.OraText // name of the attribute
+		var size C.ub4
+		size, err = conn.ociAttrGet(attr, unsafe.Pointer(&name), C.OCI_ATTR_NAME)
+		if err != nil {
+			return nil, err
+		}
+		typeDescriptor.Attributes[i].Name = cGoStringN(name, int(size))
+	}
+
+	return typeDescriptor, nil
+}
+
+// describeAttribute returns the type code, type name, precision, and scale of a type attribute or collection element parameter
+func (conn *OCI8Conn) describeAttribute(param *C.OCIParam) (AttributeInfo, error) {
+	var attributeInfo AttributeInfo
+
+	var typeCode C.OCITypeCode
+	_, err := conn.ociAttrGet(param, unsafe.Pointer(&typeCode), C.OCI_ATTR_TYPECODE)
+	if err != nil {
+		return attributeInfo, err
+	}
+	attributeInfo.TypeCode = int(typeCode)
+
+	switch typeCode {
+	case C.OCI_TYPECODE_OBJECT, C.OCI_TYPECODE_NAMEDCOLLECTION:
+		var schemaName string
+		schemaName, attributeInfo.TypeName, err = conn.objectTypeNames(param)
+		if err != nil {
+			return attributeInfo, err
+		}
+		if schemaName != "" {
+			attributeInfo.TypeName = schemaName + "." + attributeInfo.TypeName
+		}
+		return attributeInfo, nil
+	}
+
+	var name *C.OraText // built-in type name
+	size, err := conn.ociAttrGet(param, unsafe.Pointer(&name), C.OCI_ATTR_TYPE_NAME)
+	if err != nil {
+		return attributeInfo, err
+	}
+	attributeInfo.TypeName = cGoStringN(name, int(size))
+
+	switch typeCode {
+	case C.OCI_TYPECODE_NUMBER, C.OCI_TYPECODE_DECIMAL, C.OCI_TYPECODE_FLOAT:
+		var precision C.ub1 // the precision
+		_, err = conn.ociAttrGet(param, unsafe.Pointer(&precision), C.OCI_ATTR_PRECISION)
+		if err != nil {
+			return attributeInfo, err
+		}
+		attributeInfo.Precision = int(precision)
+
+		var scale C.sb1 // the scale (number of digits to the right of the decimal point)
+		_, err = conn.ociAttrGet(param, unsafe.Pointer(&scale), C.OCI_ATTR_SCALE)
+		if err != nil {
+			return attributeInfo, err
+		}
+		attributeInfo.Scale = int(scale)
+	}
+
+	return attributeInfo, nil
+}
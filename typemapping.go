@@ -0,0 +1,67 @@
+package oci8
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+)
+
+// RegisterTypeMapping registers converters for a custom Go type, such as a decimal type.
+// Bind values of the Go type are converted with toDriver before the default conversion.
+// Scanning values into the Go type is done with fromDriver by scanning into MappedScanner.
+// Registering the same Go type again replaces the converters.
+func (oci8Driver *OCI8DriverStruct) RegisterTypeMapping(goType reflect.Type, toDriver func(interface{}) (driver.Value, error), fromDriver func(driver.Value) (interface{}, error)) {
+	oci8Driver.typeMappingsMutex.Lock()
+	if oci8Driver.typeMappings == nil {
+		oci8Driver.typeMappings = make(map[reflect.Type]oci8TypeMapping)
+	}
+	oci8Driver.typeMappings[goType] = oci8TypeMapping{toDriver: toDriver, fromDriver: fromDriver}
+	oci8Driver.typeMappingsMutex.Unlock()
+}
+
+// typeMapping returns the registered type mapping for the Go type
+func (oci8Driver *OCI8DriverStruct) typeMapping(goType reflect.Type) (oci8TypeMapping, bool) {
+	oci8Driver.typeMappingsMutex.RLock()
+	mapping, ok := oci8Driver.typeMappings[goType]
+	oci8Driver.typeMappingsMutex.RUnlock()
+	return mapping, ok
+}
+
+// MappedScanner returns a sql.Scanner that scans into dest, a pointer to a registered Go type, using the fromDriver converter.
+// For example: rows.Scan(OCI8Driver.MappedScanner(&decimal))
+// If the Go type is not registered the Scanner returns an error.
+func (oci8Driver *OCI8DriverStruct) MappedScanner(dest interface{}) sql.Scanner {
+	destValue := reflect.ValueOf(dest)
+	scanner := &oci8MappedScanner{dest: destValue}
+	if destValue.Kind() == reflect.Ptr && !destValue.IsNil() {
+		scanner.mapping, _ = oci8Driver.typeMapping(destValue.Type().Elem())
+	}
+	return scanner
+}
+
+// Scan converts src with fromDriver and stores it in dest
+func (scanner *oci8MappedScanner) Scan(src interface{}) error {
+	if scanner.mapping.fromDriver == nil {
+		if !scanner.dest.IsValid() {
+			return fmt.Errorf("no type mapping registered for nil")
+		}
+		return fmt.Errorf("no type mapping registered for %v", scanner.dest.Type())
+	}
+	value, err := scanner.mapping.fromDriver(src)
+	if err != nil {
+		return err
+	}
+
+	elem := scanner.dest.Elem()
+	if value == nil {
+		elem.Set(reflect.Zero(elem.Type()))
+		return nil
+	}
+	valueOf := reflect.ValueOf(value)
+	if !valueOf.Type().AssignableTo(elem.Type()) {
+		return fmt.Errorf("type mapping returned %T, expected %v", value, elem.Type())
+	}
+	elem.Set(valueOf)
+	return nil
+}
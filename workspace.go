@@ -0,0 +1,60 @@
+package oci8
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// CreateWorkspace creates the Workspace Manager workspace with DBMS_WM.CreateWorkspace, as a child of the current workspace,
+// which is LIVE unless GotoWorkspace was called. Workspace names are case sensitive. Needs the WM_ADMIN_ROLE or the CREATE_ANY_WORKSPACE privilege.
+func (conn *OCI8Conn) CreateWorkspace(ctx context.Context, name string) error {
+	err := conn.execArgs(ctx, "begin DBMS_WM.CreateWorkspace(:1); end;", name)
+	if err != nil {
+		return fmt.Errorf("create workspace error: %v", err)
+	}
+	return nil
+}
+
+// GotoWorkspace moves the session to the Workspace Manager workspace with DBMS_WM.GotoWorkspace,
+// so version-enabled tables show and change the rows of the workspace. LIVE is the production workspace.
+func (conn *OCI8Conn) GotoWorkspace(ctx context.Context, name string) error {
+	err := conn.execArgs(ctx, "begin DBMS_WM.GotoWorkspace(:1); end;", name)
+	if err != nil {
+		return fmt.Errorf("goto workspace error: %v", err)
+	}
+	return nil
+}
+
+// MergeWorkspace applies the changes of the Workspace Manager workspace to the target workspace with DBMS_WM.MergeWorkspace.
+// DBMS_WM only merges a workspace into its parent, so an error is returned if the target is not the parent workspace.
+// The workspace is kept, remove it with RemoveWorkspace.
+func (conn *OCI8Conn) MergeWorkspace(ctx context.Context, name string, targetWorkspace string) error {
+	values, err := conn.queryRowArgs(ctx, "select PARENT_WORKSPACE from ALL_WORKSPACES where WORKSPACE = :1", name)
+	if err != nil {
+		if err == io.EOF {
+			return fmt.Errorf("workspace not found: %v", name)
+		}
+		return fmt.Errorf("merge workspace error: %v", err)
+	}
+	parent, _ := values[0].(string)
+	if parent != targetWorkspace {
+		return fmt.Errorf("workspace %v can only be merged into its parent workspace %v", name, parent)
+	}
+
+	err = conn.execArgs(ctx, "begin DBMS_WM.MergeWorkspace(:1); end;", name)
+	if err != nil {
+		return fmt.Errorf("merge workspace error: %v", err)
+	}
+	return nil
+}
+
+// RemoveWorkspace discards the rows of the Workspace Manager workspace and removes it with DBMS_WM.RemoveWorkspace.
+// No session can be in the workspace, so first GotoWorkspace another workspace, like LIVE.
+func (conn *OCI8Conn) RemoveWorkspace(ctx context.Context, name string) error {
+	err := conn.execArgs(ctx, "begin DBMS_WM.RemoveWorkspace(:1); end;", name)
+	if err != nil {
+		return fmt.Errorf("remove workspace error: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+//go:build !xml_native
+// +build !xml_native
+
+package oci8
+
+import (
+	"unsafe"
+)
+
+// xmlNative is true when built with the xml_native build tag, which fetches XMLTYPE columns with the OCI XML DB API
+const xmlNative = false
+
+// xmlTypeValue returns ErrXMLNative, XMLTYPE values need the xml_native build tag.
+// With the xml_as_clob DSN parameter, queries with XMLTYPE columns are prepared with the columns converted to CLOB, see selectListQuery.
+func (conn *OCI8Conn) xmlTypeValue(instance unsafe.Pointer) (interface{}, error) {
+	return nil, ErrXMLNative
+}
+
+// freeXMLContext does nothing, there is no XML context without the xml_native build tag
+func (conn *OCI8Conn) freeXMLContext() {}
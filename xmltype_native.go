@@ -0,0 +1,68 @@
+//go:build xml_native
+// +build xml_native
+
+package oci8
+
+// #include "oci8.go.h"
+// #include <ocixmldb.h>
+//
+// // oci8XmlSaveDom calls XmlSaveDom, which has variable args, to serialize the document to the buffer
+// static ubig_ora oci8XmlSaveDom(xmlctx *xctx, xmlerr *err, xmlnode *root, oratext *buffer, ubig_ora size) {
+//   return XmlSaveDom(xctx, err, root, "buffer", buffer, "buffer_length", size, NULL);
+// }
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// xmlNative is true when built with the xml_native build tag, which fetches XMLTYPE columns with the OCI XML DB API.
+// The xml.h and ocixmldb.h headers are in the Oracle client xdk/include directory, add it to CGO_CFLAGS with -I.
+// The XML DB API needs a full Oracle client, Instant Client does not include the XDK headers.
+const xmlNative = true
+
+// xmlMaxSize is the max size of a serialized XMLTYPE value
+const xmlMaxSize = 1 << 30
+
+// xmlTypeValue serializes the XMLTYPE object instance, an OCIXMLType document, with XmlSaveDom and returns the XML string.
+// Schema-based XMLTYPE values stored as binary XML are converted to text by the XML DB API.
+func (conn *OCI8Conn) xmlTypeValue(instance unsafe.Pointer) (interface{}, error) {
+	if conn.xmlContext == nil {
+		conn.xmlContext = unsafe.Pointer(C.OCIXmlDbInitXmlCtx(conn.env, conn.svc, conn.errHandle, nil, 0))
+		if conn.xmlContext == nil {
+			return nil, errors.New("OCIXmlDbInitXmlCtx failed")
+		}
+	}
+
+	size := 32768
+	for {
+		buffer := make([]byte, size)
+		var xmlErr C.xmlerr
+		length := C.oci8XmlSaveDom(
+			(*C.xmlctx)(conn.xmlContext),
+			&xmlErr,
+			(*C.xmlnode)(instance),
+			(*C.oratext)(unsafe.Pointer(&buffer[0])),
+			C.ubig_ora(size),
+		)
+		if xmlErr == C.XMLERR_OK {
+			return string(buffer[:length]), nil
+		}
+		// only a buffer that is too small is retried, with a buffer twice the size
+		if xmlErr != C.XMLERR_SAVE_OVERFLOW || size >= xmlMaxSize {
+			return nil, fmt.Errorf("XmlSaveDom error: %v", xmlErr)
+		}
+		size *= 2
+	}
+}
+
+// freeXMLContext frees the XML context of the connection created by xmlTypeValue
+func (conn *OCI8Conn) freeXMLContext() {
+	if conn.xmlContext == nil {
+		return
+	}
+	C.OCIXmlDbFreeXmlCtx((*C.xmlctx)(conn.xmlContext))
+	conn.xmlContext = nil
+}